@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+)
+
+// accountView is how the admin commands print an account.
+type accountView struct {
+	AccountId      int64      `json:"account_id"`
+	DocumentNumber string     `json:"document_number"`
+	CreatedAt      time.Time  `json:"created_at"`
+	BlockedAt      *time.Time `json:"blocked_at,omitempty"`
+}
+
+// accountCommand runs `account create|show|block`.
+func accountCommand(args []string) {
+	positional, options, configArgs := splitAdminArgs(args)
+	if len(positional) != 2 {
+		exitWithUsage(usage)
+	}
+	subcommand, argument := positional[0], positional[1]
+	if subcommand != "create" && subcommand != "show" && subcommand != "block" {
+		exitWithUsage(usage)
+	}
+	var request models.CreateAccountRequest
+	var accountId int64
+	if subcommand == "create" {
+		request = models.CreateAccountRequest{DocumentNumber: argument}
+		if err := request.Validate(); err != nil {
+			exitWithUsage(err.Error())
+		}
+	} else {
+		accountId = parseId("account-id", argument)
+	}
+
+	a, closeAdmin := newAdmin(options, configArgs)
+	defer closeAdmin()
+
+	var account *domain.Account
+	var err error
+	switch subcommand {
+	case "create":
+		account, err = a.accounts.RegisterAccount(a.ctx, request)
+	case "show":
+		account, err = a.accounts.GetAccount(a.ctx, accountId)
+	case "block":
+		account, err = a.accounts.BlockAccount(a.ctx, accountId)
+	}
+	if err != nil {
+		a.fail("account "+subcommand, err)
+	}
+	a.printAccount(*account)
+}
+
+func (a *admin) printAccount(account domain.Account) {
+	view := accountView{
+		AccountId:      account.Id,
+		DocumentNumber: a.documentNumber(account.DocumentNumber),
+		CreatedAt:      account.CreatedAt,
+		BlockedAt:      account.BlockedAt,
+	}
+	blockedAt := "-"
+	if view.BlockedAt != nil {
+		blockedAt = view.BlockedAt.Format(time.RFC3339)
+	}
+	a.print(view, []string{"ACCOUNT", "DOCUMENT NUMBER", "CREATED AT", "BLOCKED AT"}, [][]string{
+		{strconv.FormatInt(view.AccountId, 10), view.DocumentNumber, view.CreatedAt.Format(time.RFC3339), blockedAt},
+	})
+}
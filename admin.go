@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/credit-card-api/internal/audit"
+	"github.com/credit-card-api/internal/clearing"
+	"github.com/credit-card-api/internal/config"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/redact"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/services"
+	"github.com/jackc/pgx/v5/pgxpool"
+	logger "github.com/sirupsen/logrus"
+)
+
+const usage = `usage: credit-card-api [command] [arguments] [flags]
+
+  serve                       run the API, the default without a command
+  migrate <command>           apply or roll back schema migrations, see credit-card-api migrate
+  reencrypt                   move every document number to the primary key of the keyring
+  account create <document-number>
+  account show <account-id>
+  account block <account-id>  stop the account from taking new transactions
+  transaction post <account-id> <operation-type-id> <amount>
+  transaction list <account-id>
+  recompute-balances <account-id>...
+                              replay the transactions of accounts and correct their balances
+  seed [accounts]             create demo accounts with a few transactions each, 10 by default
+  import transactions <file> [--format=csv|fixed] [--source=<name>] [--rejects=<file>]
+                              post the records of a clearing file, resuming where an earlier run stopped;
+                              a reference of the source is posted once, network being the default source;
+                              rejected records go to <file>.rejects.csv unless --rejects is given
+
+Every command takes the configuration flags of the server. The account, transaction, recompute-balances, seed
+and import commands print tables, or JSON with --json. Document numbers are masked unless --reveal is given.`
+
+// adminOptions are the flags the admin commands take on top of the configuration flags.
+type adminOptions struct {
+	json   bool
+	reveal bool
+	// format, source and rejects are the clearing file format, reference source and rejects file of import.
+	format  string
+	source  string
+	rejects string
+}
+
+// admin runs support tasks through the same services as the API, on a pool of its own.
+type admin struct {
+	ctx          context.Context
+	pool         *pgxpool.Pool
+	options      adminOptions
+	accounts     services.AccountService
+	transactions services.TransactionService
+	importer     *clearing.Importer
+}
+
+// newAdmin wires the services. The returned function releases the pool and the signal handler.
+func newAdmin(options adminOptions, configArgs []string) (*admin, func()) {
+	cfg, cfgErr := config.Load(configArgs, os.LookupEnv)
+	if cfgErr != nil {
+		log.Fatalf("invalid configuration, see README.md:\n%s", cfgErr)
+	}
+	configureLogger(cfg.Log)
+	if cfg.Storage == config.StorageMemory {
+		log.Fatal("admin commands need postgres storage, changes to memory storage would be lost on exit.")
+	}
+
+	keyring, keyringErr := encryption.LoadKeyring(cfg.Encryption.KeyringFile)
+	if keyringErr != nil {
+		log.Fatal("unable to load KEYRING_FILE:", keyringErr)
+	}
+	dbPool, dbErr := newPool(cfg.Database, nil)
+	if dbErr != nil {
+		log.Fatal("unable to connect with database:", dbErr)
+	}
+
+	repositories := repository.NewPostgresRepositories(dbPool, keyring)
+	recorder := metrics.Noop{}
+	auditService := services.NewAuditService(repositories.Audit, repositories.Transactor)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	// Changes made from the command line are audited under the operator's login.
+	ctx = audit.WithMetadata(ctx, &audit.Metadata{Actor: cliActor()})
+
+	transactionService := services.NewTransactionService(repositories.Transactions, repositories.Accounts, repositories.Outbox, repositories.Transactor, auditService, recorder)
+	a := &admin{
+		ctx:          ctx,
+		pool:         dbPool,
+		options:      options,
+		accounts:     services.NewAccountService(repositories.Accounts, repositories.Outbox, repositories.Transactor, auditService, recorder),
+		transactions: transactionService,
+		importer:     clearing.NewImporter(transactionService, repositories.ImportCheckpoints, repositories.Transactor),
+	}
+	return a, func() {
+		stop()
+		dbPool.Close()
+	}
+}
+
+// splitAdminArgs takes the leading arguments up to the first flag as positional, negative numbers included.
+// --json, --reveal, --format, --source and --rejects are picked from the flags, the others are configuration flags.
+func splitAdminArgs(args []string) ([]string, adminOptions, []string) {
+	var positional []string
+	for len(args) > 0 && !isFlag(args[0]) {
+		positional, args = append(positional, args[0]), args[1:]
+	}
+	var options adminOptions
+	var configArgs []string
+	for _, arg := range args {
+		name, value, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch name {
+		case "json":
+			options.json = true
+		case "reveal":
+			options.reveal = true
+		case "format":
+			options.format = value
+		case "source":
+			options.source = value
+		case "rejects":
+			options.rejects = value
+		default:
+			configArgs = append(configArgs, arg)
+		}
+	}
+	return positional, options, configArgs
+}
+
+func isFlag(arg string) bool {
+	if !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	_, err := strconv.ParseFloat(arg, 64)
+	return err != nil
+}
+
+func cliActor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return "cli:" + current.Username
+	}
+	return "cli"
+}
+
+// print writes value as indented JSON with --json, otherwise a table of header and rows.
+func (a *admin) print(value any, header []string, rows [][]string) {
+	if a.options.json {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			a.fail("print", err)
+		}
+		return
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	if err := writer.Flush(); err != nil {
+		a.fail("print", err)
+	}
+}
+
+func (a *admin) documentNumber(documentNumber string) string {
+	if a.options.reveal {
+		return documentNumber
+	}
+	return redact.Mask(documentNumber)
+}
+
+// fail reports err and exits; the deferred cleanup does not run, so the pool is closed here.
+func (a *admin) fail(command string, err error) {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		logger.Errorf("%s failed: %s (%s)", command, appErr.Message, appErr.Code)
+	} else {
+		logger.Errorf("%s failed: %s", command, err.Error())
+	}
+	a.pool.Close()
+	os.Exit(1)
+}
+
+func parseId(name string, value string) int64 {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		log.Fatalf("%s must be a positive integer, got %q", name, value)
+	}
+	return id
+}
+
+func exitWithUsage(usage string) {
+	_, _ = fmt.Fprintln(os.Stderr, usage)
+	os.Exit(2)
+}
@@ -0,0 +1,16 @@
+// Package db embeds the versioned schema migrations, so the binary can upgrade its own database.
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrations holds the NNNNNN_name.up.sql and NNNNNN_name.down.sql files of db/migrations.
+func Migrations() fs.FS {
+	sub, _ := fs.Sub(migrations, "migrations")
+	return sub
+}
@@ -0,0 +1,3 @@
+DROP TABLE transactions;
+DROP TABLE operation_types;
+DROP TABLE accounts;
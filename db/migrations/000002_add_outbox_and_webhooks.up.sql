@@ -0,0 +1,43 @@
+-- Account and transaction changes are written here in the same transaction as the change, then delivered to the
+-- webhook subscriptions by the dispatcher.
+CREATE TABLE outbox_events
+(
+    event_id       BIGSERIAL PRIMARY KEY,
+    event_type     VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50)  NOT NULL,
+    aggregate_id   BIGINT       NOT NULL,
+    account_id     BIGINT       NOT NULL REFERENCES accounts (account_id),
+    payload        JSONB        NOT NULL,
+    dispatched_at  TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_events_undispatched ON outbox_events (event_id) WHERE dispatched_at IS NULL;
+
+CREATE TABLE webhook_subscriptions
+(
+    subscription_id BIGSERIAL PRIMARY KEY,
+    url             VARCHAR(2048) NOT NULL,
+    secret          VARCHAR(128)  NOT NULL,
+    event_types     TEXT[]        NOT NULL,
+    active          BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE webhook_deliveries
+(
+    delivery_id      BIGSERIAL PRIMARY KEY,
+    event_id         BIGINT      NOT NULL REFERENCES outbox_events (event_id),
+    subscription_id  BIGINT      NOT NULL REFERENCES webhook_subscriptions (subscription_id) ON DELETE CASCADE,
+    status           VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts         INT         NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status_code INT,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, subscription_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
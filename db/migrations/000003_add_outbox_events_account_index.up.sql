@@ -0,0 +1,2 @@
+-- The transaction stream reads the events of an account after the last event id it sent.
+CREATE INDEX idx_outbox_events_account ON outbox_events (account_id, event_id);
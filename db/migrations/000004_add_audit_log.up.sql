@@ -0,0 +1,38 @@
+-- Append-only log of every change, each entry chained to the hash of the one before it.
+CREATE TABLE audit_log
+(
+    audit_id      BIGSERIAL PRIMARY KEY,
+    actor         VARCHAR(255) NOT NULL,
+    action        VARCHAR(100) NOT NULL,
+    resource_type VARCHAR(50)  NOT NULL,
+    resource_id   VARCHAR(255) NOT NULL,
+    before_state  JSONB,
+    after_state   JSONB,
+    diff          JSONB        NOT NULL,
+    request_id    VARCHAR(100) NOT NULL,
+    source_ip     VARCHAR(64)  NOT NULL,
+    created_at    TIMESTAMPTZ  NOT NULL,
+    prev_hash     CHAR(64)     NOT NULL,
+    hash          CHAR(64)     NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_audit_log_resource ON audit_log (resource_type, resource_id, audit_id);
+
+CREATE FUNCTION audit_log_reject_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_reject_change();
+
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE
+    ON audit_log
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_log_reject_change();
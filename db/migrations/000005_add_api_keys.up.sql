@@ -0,0 +1,12 @@
+-- Api keys are stored as the SHA-256 of the key, the prefix only helps to recognize them.
+CREATE TABLE api_keys
+(
+    key_id       BIGSERIAL PRIMARY KEY,
+    name         VARCHAR(100) NOT NULL,
+    key_prefix   VARCHAR(16)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL UNIQUE,
+    scopes       TEXT[]       NOT NULL,
+    rotated_from BIGINT REFERENCES api_keys (key_id),
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMPTZ
+);
@@ -0,0 +1,18 @@
+-- The backfill decrypts every document number from Go into the plain column before the sealed ones are dropped.
+ALTER TABLE accounts ADD COLUMN plain_document_number VARCHAR(20);
+
+-- migrate:backfill
+
+DROP INDEX idx_accounts_document_key;
+
+ALTER TABLE accounts DROP COLUMN document_number_index;
+
+ALTER TABLE accounts DROP COLUMN document_key_id;
+
+ALTER TABLE accounts DROP COLUMN document_number;
+
+ALTER TABLE accounts RENAME COLUMN plain_document_number TO document_number;
+
+ALTER TABLE accounts ALTER COLUMN document_number SET NOT NULL;
+
+ALTER TABLE accounts ADD CONSTRAINT accounts_document_number_key UNIQUE (document_number);
@@ -0,0 +1,27 @@
+-- The keyring is only known to the application: the sealed columns are added empty, the backfill encrypts every
+-- document number from Go, then they are constrained and the plain column is dropped.
+ALTER TABLE accounts DROP CONSTRAINT accounts_document_number_key;
+
+ALTER TABLE accounts RENAME COLUMN document_number TO plain_document_number;
+
+-- AES-GCM nonce and ciphertext of the document number, sealed with the keyring key document_key_id.
+ALTER TABLE accounts ADD COLUMN document_number BYTEA;
+
+ALTER TABLE accounts ADD COLUMN document_key_id VARCHAR(64);
+
+-- HMAC-SHA256 blind index of the document number, keeps it unique without decrypting it.
+ALTER TABLE accounts ADD COLUMN document_number_index BYTEA;
+
+-- migrate:backfill
+
+ALTER TABLE accounts DROP COLUMN plain_document_number;
+
+ALTER TABLE accounts ALTER COLUMN document_number SET NOT NULL;
+
+ALTER TABLE accounts ALTER COLUMN document_key_id SET NOT NULL;
+
+ALTER TABLE accounts ALTER COLUMN document_number_index SET NOT NULL;
+
+ALTER TABLE accounts ADD CONSTRAINT accounts_document_number_index_key UNIQUE (document_number_index);
+
+CREATE INDEX idx_accounts_document_key ON accounts (document_key_id);
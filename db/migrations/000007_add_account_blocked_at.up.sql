@@ -0,0 +1,2 @@
+-- Blocked accounts keep their history but no longer accept transactions.
+ALTER TABLE accounts ADD COLUMN blocked_at TIMESTAMPTZ;
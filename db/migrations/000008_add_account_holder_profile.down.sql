@@ -0,0 +1,13 @@
+ALTER TABLE accounts
+    DROP COLUMN updated_at,
+    DROP COLUMN version,
+    DROP COLUMN birth_date,
+    DROP COLUMN address_country,
+    DROP COLUMN address_postal_code,
+    DROP COLUMN address_state,
+    DROP COLUMN address_city,
+    DROP COLUMN address_line2,
+    DROP COLUMN address_line1,
+    DROP COLUMN phone,
+    DROP COLUMN email,
+    DROP COLUMN full_name;
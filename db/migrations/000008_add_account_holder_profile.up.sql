@@ -0,0 +1,15 @@
+-- Holder profile of an account. version grows with every change of the row and is the ETag of the account, so
+-- concurrent edits cannot overwrite each other.
+ALTER TABLE accounts
+    ADD COLUMN full_name           VARCHAR(200),
+    ADD COLUMN email               VARCHAR(254),
+    ADD COLUMN phone               VARCHAR(16),
+    ADD COLUMN address_line1       VARCHAR(200),
+    ADD COLUMN address_line2       VARCHAR(200),
+    ADD COLUMN address_city        VARCHAR(100),
+    ADD COLUMN address_state       VARCHAR(100),
+    ADD COLUMN address_postal_code VARCHAR(20),
+    ADD COLUMN address_country     CHAR(2),
+    ADD COLUMN birth_date          DATE,
+    ADD COLUMN version             INTEGER     NOT NULL DEFAULT 1,
+    ADD COLUMN updated_at          TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -0,0 +1,13 @@
+-- Progress of the clearing files imported by the import transactions command, keyed by the SHA-256 of the file
+-- content. line is the last line handled; it is advanced in the same transaction as the transaction the line
+-- posted, so a resumed import never posts a line twice.
+CREATE TABLE import_checkpoints
+(
+    file_hash  CHAR(64) PRIMARY KEY,
+    file_name  TEXT        NOT NULL,
+    line       BIGINT      NOT NULL DEFAULT 0,
+    posted     BIGINT      NOT NULL DEFAULT 0,
+    rejected   BIGINT      NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+-- The masked digits are gone, there is nothing to restore.
+SELECT 1;
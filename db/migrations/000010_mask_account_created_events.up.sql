@@ -0,0 +1,10 @@
+-- account.created events carried the plain document number; keep only its last four digits, as new events do.
+UPDATE outbox_events
+SET payload = jsonb_set(payload, '{document_number}', to_jsonb(
+        repeat('*', greatest(length(payload ->> 'document_number') - 4, 0)) ||
+        CASE
+            WHEN length(payload ->> 'document_number') > 4 THEN right(payload ->> 'document_number', 4)
+            ELSE repeat('*', length(payload ->> 'document_number'))
+        END))
+WHERE event_type = 'account.created'
+  AND payload ? 'document_number';
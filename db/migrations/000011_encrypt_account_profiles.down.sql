@@ -0,0 +1,18 @@
+ALTER TABLE accounts
+    ADD COLUMN full_name           VARCHAR(200),
+    ADD COLUMN email               VARCHAR(254),
+    ADD COLUMN phone               VARCHAR(16),
+    ADD COLUMN address_line1       VARCHAR(200),
+    ADD COLUMN address_line2       VARCHAR(200),
+    ADD COLUMN address_city        VARCHAR(100),
+    ADD COLUMN address_state       VARCHAR(100),
+    ADD COLUMN address_postal_code VARCHAR(20),
+    ADD COLUMN address_country     CHAR(2),
+    ADD COLUMN birth_date          DATE;
+
+-- migrate:backfill
+
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_profile_key_check,
+    DROP COLUMN profile_key_id,
+    DROP COLUMN profile;
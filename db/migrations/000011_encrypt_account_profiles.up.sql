@@ -0,0 +1,24 @@
+-- The holder profile is sealed with the keyring like the document number: the sealed columns are added empty, the
+-- backfill encrypts every profile from Go, then the plain columns are dropped.
+
+-- AES-GCM nonce and ciphertext of the profile as a JSON document, sealed with the keyring key profile_key_id. Both
+-- are NULL while the holder has no profile.
+ALTER TABLE accounts
+    ADD COLUMN profile        BYTEA,
+    ADD COLUMN profile_key_id VARCHAR(64);
+
+-- migrate:backfill
+
+ALTER TABLE accounts
+    DROP COLUMN birth_date,
+    DROP COLUMN address_country,
+    DROP COLUMN address_postal_code,
+    DROP COLUMN address_state,
+    DROP COLUMN address_city,
+    DROP COLUMN address_line2,
+    DROP COLUMN address_line1,
+    DROP COLUMN phone,
+    DROP COLUMN email,
+    DROP COLUMN full_name;
+
+ALTER TABLE accounts ADD CONSTRAINT accounts_profile_key_check CHECK ((profile IS NULL) = (profile_key_id IS NULL));
@@ -0,0 +1,6 @@
+-- The transaction that wrote the event. event_id is drawn when the row is inserted, not when it commits, so a lower
+-- id can still commit after a higher one: readers that resume after an event id only read the events of
+-- transactions older than every transaction still running. Events written before have no xid and are all visible.
+ALTER TABLE outbox_events ADD COLUMN created_xid XID8 NOT NULL DEFAULT '0';
+
+ALTER TABLE outbox_events ALTER COLUMN created_xid SET DEFAULT pg_current_xact_id();
@@ -0,0 +1,12 @@
+-- The references posted by the import transactions command, one row per record posted. A source names who issued
+-- the references of a clearing file; the key stops a reference from being posted twice, even from a file with other
+-- content, such as one re-sent with a new header. The row is written in the same transaction as the transaction.
+CREATE TABLE imported_references
+(
+    source     TEXT        NOT NULL,
+    reference  TEXT        NOT NULL,
+    file_hash  CHAR(64)    NOT NULL REFERENCES import_checkpoints (file_hash),
+    line       BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (source, reference)
+);
@@ -1,9 +1,47 @@
+-- name: BlockAccount :one
+UPDATE accounts
+SET blocked_at = COALESCE(blocked_at, now()),
+    version    = CASE WHEN blocked_at IS NULL THEN version + 1 ELSE version END,
+    updated_at = CASE WHEN blocked_at IS NULL THEN now() ELSE updated_at END
+WHERE account_id = $1
+    RETURNING *;
+
 -- name: CreateAccount :one
-INSERT INTO accounts (document_number)
-VALUES ($1)
+INSERT INTO accounts (document_number, document_key_id, document_number_index)
+VALUES ($1, $2, $3)
     RETURNING *;
 
 -- name: GetAccountByID :one
 SELECT * FROM accounts
 WHERE account_id = $1 LIMIT 1;
 
+-- name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE account_id = $1 LIMIT 1
+    FOR UPDATE;
+
+-- name: ListAccountsToReencrypt :many
+SELECT * FROM accounts
+WHERE (document_key_id <> @primary_key_id OR profile_key_id <> @primary_key_id)
+  AND account_id > @after_account_id
+ORDER BY account_id
+LIMIT @batch_size;
+
+-- name: UpdateAccountProfile :one
+UPDATE accounts
+SET profile        = $3,
+    profile_key_id = $4,
+    version        = version + 1,
+    updated_at     = now()
+WHERE account_id = $1
+  AND version = $2
+    RETURNING *;
+
+-- name: ResealAccount :execrows
+UPDATE accounts
+SET document_number = $3,
+    document_key_id = $4,
+    profile         = $5,
+    profile_key_id  = $6
+WHERE account_id = $1
+  AND version = $2;
@@ -0,0 +1,20 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (name, key_prefix, key_hash, scopes, rotated_from)
+VALUES ($1, $2, $3, $4, $5)
+    RETURNING *;
+
+-- name: GetActiveApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1
+  AND revoked_at IS NULL LIMIT 1;
+
+-- name: ListApiKeys :many
+SELECT * FROM api_keys
+ORDER BY key_id ASC;
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE key_id = $1
+  AND revoked_at IS NULL
+    RETURNING *;
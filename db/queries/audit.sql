@@ -0,0 +1,24 @@
+-- name: AcquireAuditChainLock :exec
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_key)::BIGINT);
+
+-- name: GetLatestAuditHash :one
+SELECT hash FROM audit_log
+ORDER BY audit_id DESC
+LIMIT 1;
+
+-- name: CreateAuditEntry :one
+INSERT INTO audit_log (actor, action, resource_type, resource_id, before_state, after_state, diff, request_id,
+                       source_ip, created_at, prev_hash, hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    RETURNING *;
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit_log
+WHERE (sqlc.narg(resource_type)::VARCHAR IS NULL OR resource_type = sqlc.narg(resource_type))
+  AND (sqlc.narg(resource_id)::VARCHAR IS NULL OR resource_id = sqlc.narg(resource_id))
+  AND (sqlc.narg(actor)::VARCHAR IS NULL OR actor = sqlc.narg(actor))
+  AND (sqlc.narg(from_time)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(to_time))
+  AND audit_id > sqlc.arg(after_id)
+ORDER BY audit_id ASC
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,7 @@
+-- name: GetSchemaVersion :one
+SELECT * FROM schema_migrations
+ORDER BY version DESC LIMIT 1;
+
+-- name: CountOperationTypes :one
+SELECT COUNT(*) FROM operation_types
+WHERE operation_type_id = ANY(@operation_type_ids::int[]);
@@ -0,0 +1,20 @@
+-- name: StartImportCheckpoint :one
+INSERT INTO import_checkpoints (file_hash, file_name)
+VALUES ($1, $2)
+ON CONFLICT (file_hash) DO UPDATE SET file_name = import_checkpoints.file_name
+    RETURNING *;
+
+-- name: LockImportCheckpoint :one
+SELECT * FROM import_checkpoints
+WHERE file_hash = $1
+    FOR UPDATE;
+
+-- name: UpdateImportCheckpoint :exec
+UPDATE import_checkpoints
+SET line = $2, posted = $3, rejected = $4, updated_at = NOW()
+WHERE file_hash = $1;
+
+-- name: ClaimImportedReference :execrows
+INSERT INTO imported_references (source, reference, file_hash, line)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (source, reference) DO NOTHING;
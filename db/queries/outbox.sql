@@ -0,0 +1,31 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, account_id, payload)
+VALUES ($1, $2, $3, $4, $5)
+    RETURNING *;
+
+-- name: ListUndispatchedOutboxEvents :many
+SELECT * FROM outbox_events
+WHERE dispatched_at IS NULL
+ORDER BY event_id ASC
+LIMIT $1
+    FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_events
+SET dispatched_at = NOW()
+WHERE event_id = $1;
+
+-- name: ListAccountOutboxEventsAfter :many
+SELECT * FROM outbox_events
+WHERE account_id = sqlc.arg(account_id)
+  AND event_id > sqlc.arg(after_event_id)
+  AND event_type = ANY (sqlc.arg(event_types)::TEXT[])
+  AND created_xid < pg_snapshot_xmin(pg_current_snapshot())
+ORDER BY event_id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetLatestAccountOutboxEventID :one
+SELECT COALESCE(MAX(event_id), 0)::BIGINT
+FROM outbox_events
+WHERE account_id = $1
+  AND created_xid < pg_snapshot_xmin(pg_current_snapshot());
@@ -0,0 +1,21 @@
+-- name: CreateSchemaMigrationsTable :exec
+CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version BIGINT PRIMARY KEY,
+    dirty   BOOLEAN NOT NULL
+);
+
+-- name: AcquireMigrationLock :exec
+SELECT pg_advisory_lock(sqlc.arg(lock_key)::BIGINT);
+
+-- name: ReleaseMigrationLock :exec
+SELECT pg_advisory_unlock(sqlc.arg(lock_key)::BIGINT);
+
+-- name: SetSchemaVersion :exec
+INSERT INTO schema_migrations (version, dirty)
+VALUES ($1, $2)
+ON CONFLICT (version) DO UPDATE SET dirty = EXCLUDED.dirty;
+
+-- name: DeleteSchemaVersionsFrom :exec
+DELETE FROM schema_migrations
+WHERE version >= $1;
@@ -11,7 +11,7 @@ WHERE transaction_id = $1 LIMIT 1;
 SELECT *
 FROM transactions
 WHERE account_id = $1
-ORDER BY created_at ASC;
+ORDER BY created_at ASC, transaction_id ASC;
 
 
 -- name: UpdateTransaction :one
@@ -23,4 +23,17 @@ WHERE transaction_id = $1
 -- name: ListTransactionsByAccount :many
 SELECT * FROM transactions
 WHERE account_id = $1
-ORDER BY created_at DESC;
\ No newline at end of file
+ORDER BY created_at DESC, transaction_id DESC;
+
+-- name: DeclareTransactionExportCursor :exec
+DECLARE transaction_export NO SCROLL CURSOR FOR
+SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, t.balance, t.created_at, o.description
+FROM transactions t
+         JOIN operation_types o ON o.operation_type_id = t.operation_type_id
+WHERE t.account_id = sqlc.arg(account_id)
+  AND (sqlc.narg(from_time)::TIMESTAMPTZ IS NULL OR t.created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::TIMESTAMPTZ IS NULL OR t.created_at < sqlc.narg(to_time))
+ORDER BY t.created_at ASC, t.transaction_id ASC;
+
+-- name: FetchTransactionExportCursor :many
+FETCH FORWARD 500 FROM transaction_export;
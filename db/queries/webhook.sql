@@ -0,0 +1,73 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, event_types)
+VALUES ($1, $2, $3)
+    RETURNING *;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT * FROM webhook_subscriptions
+WHERE subscription_id = $1 LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY subscription_id ASC;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET url         = $2,
+    event_types = $3,
+    active      = $4,
+    updated_at  = NOW()
+WHERE subscription_id = $1
+    RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE subscription_id = $1;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (event_id, subscription_id)
+SELECT sqlc.arg(event_id)::BIGINT, subscription_id
+FROM webhook_subscriptions
+WHERE active = TRUE
+  AND sqlc.arg(event_type)::TEXT = ANY (event_types)
+ON CONFLICT (event_id, subscription_id) DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = NOW() + sqlc.arg(lease)::INTERVAL
+FROM outbox_events e,
+     webhook_subscriptions s
+WHERE d.delivery_id IN (SELECT delivery_id
+                        FROM webhook_deliveries
+                        WHERE status = 'pending'
+                          AND next_attempt_at <= NOW()
+                        ORDER BY next_attempt_at ASC
+                        LIMIT sqlc.arg(batch_size) FOR UPDATE SKIP LOCKED)
+  AND e.event_id = d.event_id
+  AND s.subscription_id = d.subscription_id
+    RETURNING d.delivery_id, d.attempts, e.event_id, e.event_type, e.payload, e.created_at, s.subscription_id, s.url, s.secret;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status           = 'delivered',
+    attempts         = attempts + 1,
+    last_status_code = $2,
+    last_error       = NULL,
+    delivered_at     = NOW()
+WHERE delivery_id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status           = $2,
+    attempts         = attempts + 1,
+    last_status_code = $3,
+    last_error       = $4,
+    next_attempt_at  = $5
+WHERE delivery_id = $1;
+
+-- name: ListWebhookDeliveriesBySubscription :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status))
+ORDER BY delivery_id DESC
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,6 @@
+-- Owned by the migration runner, which creates it before the first migration; declared here for sqlc.
+CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version BIGINT PRIMARY KEY,
+    dirty   BOOLEAN NOT NULL
+);
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/credit-card-api/internal/clearing"
+)
+
+var importSummaryHeader = []string{"FILE", "SOURCE", "FORMAT", "ALREADY IMPORTED", "POSTED", "REJECTED", "REJECTS FILE"}
+
+// importSummaryView is how import prints the outcome of a run.
+type importSummaryView struct {
+	*clearing.Summary
+	RejectsFile string `json:"rejects_file"`
+}
+
+// importCommand runs `import transactions <file>`. The format defaults to the file extension: csv for .csv files,
+// fixed otherwise, and the source to clearing.DefaultSource.
+func importCommand(args []string) {
+	positional, options, configArgs := splitAdminArgs(args)
+	if len(positional) != 2 || positional[0] != "transactions" {
+		exitWithUsage(usage)
+	}
+	path := positional[1]
+	format := options.format
+	if format == "" {
+		format = clearing.FormatFixed
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = clearing.FormatCSV
+		}
+	}
+	if !slices.Contains(clearing.Formats, format) {
+		exitWithUsage("format must be one of " + strings.Join(clearing.Formats, ", ") + ", got " + strconv.Quote(format))
+	}
+	source := options.source
+	if source == "" {
+		source = clearing.DefaultSource
+	}
+	rejectsPath := options.rejects
+	if rejectsPath == "" {
+		rejectsPath = path + ".rejects.csv"
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		exitWithUsage(err.Error())
+	}
+	defer file.Close()
+
+	a, closeAdmin := newAdmin(options, configArgs)
+	defer closeAdmin()
+
+	// Resumed runs append to the rejects of the earlier ones.
+	rejects, err := os.OpenFile(rejectsPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		a.fail("import", err)
+	}
+	defer rejects.Close()
+	if info, statErr := rejects.Stat(); statErr == nil && info.Size() == 0 {
+		_, err = rejects.WriteString(strings.Join(clearing.RejectsHeader, ",") + "\n")
+	}
+	if err != nil {
+		a.fail("import", err)
+	}
+
+	summary, err := a.importer.Import(a.ctx, source, filepath.Base(path), file, format, rejects)
+	if err != nil {
+		a.fail("import", err)
+	}
+	a.print(importSummaryView{Summary: summary, RejectsFile: rejectsPath}, importSummaryHeader, [][]string{{
+		summary.File,
+		summary.Source,
+		summary.Format,
+		strconv.FormatInt(summary.AlreadyImported, 10),
+		strconv.FormatInt(summary.Posted, 10),
+		strconv.FormatInt(summary.Rejected, 10),
+		rejectsPath,
+	}})
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/redact"
+)
+
+// GenesisHash is the prev_hash of the first entry of the chain.
+var GenesisHash = strings.Repeat("0", 64)
+
+type chainedContent struct {
+	PrevHash     string          `json:"prev_hash"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceId   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	Diff         json.RawMessage `json:"diff"`
+	RequestId    string          `json:"request_id"`
+	SourceIp     string          `json:"source_ip"`
+	CreatedAt    string          `json:"created_at"`
+}
+
+// ComputeHash chains entry to its predecessor. JSON columns are canonicalized first because
+// JSONB does not keep the key order or spacing of the document that was inserted.
+func ComputeHash(entry domain.AuditEntry) string {
+	content, _ := json.Marshal(chainedContent{
+		PrevHash:     entry.PrevHash,
+		Actor:        entry.Actor,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceId:   entry.ResourceId,
+		Before:       canonicalize(entry.Before),
+		After:        canonicalize(entry.After),
+		Diff:         canonicalize(entry.Diff),
+		RequestId:    entry.RequestId,
+		SourceIp:     entry.SourceIp,
+		CreatedAt:    entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Snapshot encodes a resource state as JSON, nil stays nil. Fields tagged for redaction are masked: the log is
+// append-only, what it records can never be erased.
+func Snapshot(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(redact.Value(state))
+}
+
+// Diff lists every top level field whose value differs between two snapshots as {"field": {"from": x, "to": y}}.
+func Diff(before json.RawMessage, after json.RawMessage) (json.RawMessage, error) {
+	beforeFields := map[string]any{}
+	afterFields := map[string]any{}
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+	if len(after) > 0 {
+		if err := json.Unmarshal(after, &afterFields); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := map[string]map[string]any{}
+	for field, from := range beforeFields {
+		to, exists := afterFields[field]
+		if !exists || !reflect.DeepEqual(from, to) {
+			changes[field] = map[string]any{"from": from, "to": to}
+		}
+	}
+	for field, to := range afterFields {
+		if _, exists := beforeFields[field]; !exists {
+			changes[field] = map[string]any{"from": nil, "to": to}
+		}
+	}
+	return json.Marshal(changes)
+}
+
+func canonicalize(document json.RawMessage) json.RawMessage {
+	if len(bytes.TrimSpace(document)) == 0 {
+		return json.RawMessage("null")
+	}
+	var value any
+	if err := json.Unmarshal(document, &value); err != nil {
+		return document
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return document
+	}
+	return canonical
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeHash_Ignores_Json_Key_Order(t *testing.T) {
+	entry := domain.AuditEntry{
+		Actor:        AnonymousActor,
+		Action:       domain.AuditActionAccountCreate,
+		ResourceType: domain.AuditResourceAccount,
+		ResourceId:   "1",
+		After:        json.RawMessage(`{"account_id":1,"document_number":"0123456789"}`),
+		CreatedAt:    time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
+		PrevHash:     GenesisHash,
+	}
+	stored := entry
+	stored.After = json.RawMessage(`{"document_number": "0123456789", "account_id": 1}`)
+
+	assert.Len(t, ComputeHash(entry), 64)
+	assert.Equal(t, ComputeHash(entry), ComputeHash(stored))
+}
+
+func TestComputeHash_Changes_With_PrevHash(t *testing.T) {
+	entry := domain.AuditEntry{Action: domain.AuditActionAccountCreate, PrevHash: GenesisHash}
+	chained := entry
+	chained.PrevHash = ComputeHash(entry)
+
+	assert.NotEqual(t, ComputeHash(entry), ComputeHash(chained))
+}
+
+func TestDiff_Lists_Changed_Fields_Only(t *testing.T) {
+	before := json.RawMessage(`{"url":"https://a.example.com","active":true,"removed":1}`)
+	after := json.RawMessage(`{"url":"https://b.example.com","active":true,"added":2}`)
+
+	diff, err := Diff(before, after)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"url":{"from":"https://a.example.com","to":"https://b.example.com"},
+		"removed":{"from":1,"to":null},
+		"added":{"from":null,"to":2}
+	}`, string(diff))
+}
+
+func TestDiff_Of_Creation_Lists_Every_Field(t *testing.T) {
+	diff, err := Diff(nil, json.RawMessage(`{"balance":-50}`))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"balance":{"from":null,"to":-50}}`, string(diff))
+}
+
+func TestSnapshot_Masks_Redacted_Fields(t *testing.T) {
+	snapshot, err := Snapshot(domain.AccountCreatedPayload{AccountId: 1, DocumentNumber: "0123456789"})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"account_id":1,"document_number":"******6789","created_at":"0001-01-01T00:00:00Z"}`, string(snapshot))
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+const (
+	SystemActor    = "system"
+	AnonymousActor = "anonymous"
+)
+
+type metadataKey struct{}
+
+// Metadata describes who triggered the audited change. The middleware attaches one per request,
+// service hooks read it when they record an entry.
+type Metadata struct {
+	Actor     string
+	RequestId string
+	SourceIp  string
+	recorded  atomic.Bool
+}
+
+func WithMetadata(ctx context.Context, metadata *Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// FromContext returns the request metadata, or a system actor for work not started by an API call.
+func FromContext(ctx context.Context) *Metadata {
+	if metadata, ok := ctx.Value(metadataKey{}).(*Metadata); ok {
+		return metadata
+	}
+	return &Metadata{Actor: SystemActor}
+}
+
+func (m *Metadata) MarkRecorded() {
+	m.recorded.Store(true)
+}
+
+func (m *Metadata) Recorded() bool {
+	return m.recorded.Load()
+}
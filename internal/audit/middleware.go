@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/gin-gonic/gin"
+)
+
+type Recorder interface {
+	Record(ctx context.Context, record domain.AuditRecord) error
+}
+
+// ActorFunc resolves who is calling the API.
+type ActorFunc func(ctx *gin.Context) string
+
+func Anonymous(*gin.Context) string {
+	return AnonymousActor
+}
+
+// Middleware attaches audit metadata to the request context. State-changing calls that finish without
+// any service hook recording an entry (validation failures, unknown resources, ...) are still recorded
+// as a request level entry, so every write attempt leaves a trace. It runs after logging.Middleware, whose request
+// id entries reuse so both can be correlated.
+func Middleware(recorder Recorder, actorFunc ActorFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		metadata := &Metadata{
+			Actor:     actorFunc(ctx),
+			RequestId: logging.RequestId(ctx.Request.Context()),
+			SourceIp:  ctx.ClientIP(),
+		}
+		ctx.Request = ctx.Request.WithContext(WithMetadata(ctx.Request.Context(), metadata))
+
+		ctx.Next()
+
+		if !isStateChanging(ctx.Request.Method) || metadata.Recorded() {
+			return
+		}
+		record := domain.AuditRecord{
+			Action:       domain.AuditActionHttpRequest,
+			ResourceType: domain.AuditResourceHttpRoute,
+			ResourceId:   fmt.Sprintf("%s %s", ctx.Request.Method, ctx.FullPath()),
+			After:        map[string]any{"status": ctx.Writer.Status()},
+		}
+		if err := recorder.Record(ctx.Request.Context(), record); err != nil {
+			logging.FromContext(ctx).Errorf("error while record audit entry for %s: %s", record.ResourceId, err.Error())
+		}
+	}
+}
+
+func isStateChanging(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
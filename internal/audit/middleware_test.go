@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorderFunc func(ctx context.Context, record domain.AuditRecord) error
+
+func (f recorderFunc) Record(ctx context.Context, record domain.AuditRecord) error {
+	return f(ctx, record)
+}
+
+func TestMiddleware_Records_Unhandled_Write_Request(t *testing.T) {
+	var records []domain.AuditRecord
+	var metadata *Metadata
+	recorder := recorderFunc(func(ctx context.Context, record domain.AuditRecord) error {
+		metadata = FromContext(ctx)
+		records = append(records, record)
+		return nil
+	})
+	router := gin.New()
+	router.Use(logging.Middleware(), Middleware(recorder, Anonymous))
+	router.POST("/accounts", func(ctx *gin.Context) { ctx.Status(http.StatusBadRequest) })
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", nil)
+	req.Header.Set(constants.RequestIdHeader, "req-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Len(t, records, 1)
+	assert.Equal(t, domain.AuditActionHttpRequest, records[0].Action)
+	assert.Equal(t, "POST /accounts", records[0].ResourceId)
+	assert.Equal(t, map[string]any{"status": http.StatusBadRequest}, records[0].After)
+	assert.Equal(t, AnonymousActor, metadata.Actor)
+	assert.Equal(t, "req-1", metadata.RequestId)
+}
+
+func TestMiddleware_Skips_Request_Recorded_By_Service(t *testing.T) {
+	calls := 0
+	recorder := recorderFunc(func(context.Context, domain.AuditRecord) error {
+		calls++
+		return nil
+	})
+	router := gin.New()
+	router.Use(Middleware(recorder, Anonymous))
+	router.POST("/accounts", func(ctx *gin.Context) {
+		FromContext(ctx.Request.Context()).MarkRecorded()
+		ctx.Status(http.StatusCreated)
+	})
+	router.GET("/accounts", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/accounts", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts", nil))
+
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+var ErrUnknownKey = errors.New("no key in the jwks matches the token kid")
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// ParseJWKS decodes the RSA and EC P-256 signing keys of a JWKS document, keyed by kid.
+func ParseJWKS(document []byte) (map[string]crypto.PublicKey, error) {
+	var keySet jsonWebKeySet
+	if err := json.Unmarshal(document, &keySet); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.Kid == "" {
+			return nil, errors.New("jwks key without kid")
+		}
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %s: %w", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+	return keys, nil
+}
+
+func (key jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBigInt(key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(key.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("rsa exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if key.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", key.Crv)
+		}
+		x, err := decodeBigInt(key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(key.Y)
+		if err != nil {
+			return nil, err
+		}
+		publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if _, err := publicKey.ECDH(); err != nil {
+			return nil, errors.New("ec point is not on the curve")
+		}
+		return publicKey, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", key.Kty)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
+
+// JWKSFile serves the keys of a JWKS file, or of every *.json file in a directory, and reloads
+// them when a file is added, removed or modified. A broken reload keeps the previous keys.
+type JWKSFile struct {
+	path    string
+	mu      sync.RWMutex
+	keys    map[string]crypto.PublicKey
+	version string
+}
+
+func LoadJWKSFile(path string) (*JWKSFile, error) {
+	jwks := &JWKSFile{path: path}
+	if _, err := jwks.Reload(); err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
+
+func (j *JWKSFile) Key(kid string) (crypto.PublicKey, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// Reload re-reads the key files when they changed since the last load and reports whether it did.
+func (j *JWKSFile) Reload() (bool, error) {
+	files, version, err := j.files()
+	if err != nil {
+		return false, err
+	}
+	j.mu.RLock()
+	unchanged := version == j.version
+	j.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, file := range files {
+		document, readErr := os.ReadFile(file)
+		if readErr != nil {
+			return false, readErr
+		}
+		fileKeys, parseErr := ParseJWKS(document)
+		if parseErr != nil {
+			return false, fmt.Errorf("%s: %w", file, parseErr)
+		}
+		for kid, key := range fileKeys {
+			keys[kid] = key
+		}
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.version = version
+	j.mu.Unlock()
+	logger.Infof("loaded %d jwks keys from %s", len(keys), j.path)
+	return true, nil
+}
+
+// Watch polls the key files until ctx is done.
+func (j *JWKSFile) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := j.Reload(); err != nil {
+				logger.Errorf("error while reload jwks from %s: %s", j.path, err.Error())
+			}
+		}
+	}
+}
+
+// files lists the key files with a version string made of their names, sizes and modification times.
+func (j *JWKSFile) files() ([]string, string, error) {
+	info, err := os.Stat(j.path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	files := []string{j.path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(j.path, "*.json"))
+		if err != nil {
+			return nil, "", err
+		}
+		sort.Strings(files)
+	}
+
+	var version strings.Builder
+	for _, file := range files {
+		fileInfo, statErr := os.Stat(file)
+		if statErr != nil {
+			return nil, "", statErr
+		}
+		fmt.Fprintf(&version, "%s:%d:%d;", file, fileInfo.Size(), fileInfo.ModTime().UnixNano())
+	}
+	return files, version.String(), nil
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/credit-card-api/internal/audit"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (*domain.Principal, error)
+}
+
+// Middleware authenticates a bearer token from the Authorization header, or else an api key from the
+// X-API-Key header, and attaches the principal to the request context. A nil tokenAuthenticator
+// rejects every bearer token.
+func Middleware(apiKeyAuthenticator Authenticator, tokenAuthenticator Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var principal *domain.Principal
+		var err error
+		if token, ok := bearerToken(ctx.GetHeader(constants.AuthorizationHeader)); ok {
+			if tokenAuthenticator == nil {
+				abortWithError(ctx, http.StatusUnauthorized, domain.ErrUnauthorized)
+				return
+			}
+			principal, err = tokenAuthenticator.Authenticate(ctx.Request.Context(), token)
+		} else {
+			principal, err = apiKeyAuthenticator.Authenticate(ctx.Request.Context(), ctx.GetHeader(constants.ApiKeyHeader))
+		}
+		if err != nil {
+			if !errors.Is(err, domain.ErrUnauthorized) {
+				logging.FromContext(ctx).Errorf("error while authenticate api key: %s", err.Error())
+				abortWithError(ctx, http.StatusInternalServerError, domain.ErrInternal)
+				return
+			}
+			abortWithError(ctx, http.StatusUnauthorized, domain.ErrUnauthorized)
+			return
+		}
+
+		logging.AddField(ctx.Request.Context(), logging.PrincipalField, principal.Subject)
+		ctx.Request = ctx.Request.WithContext(WithPrincipal(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+// RequireScope lets the request through when the principal holds scope or admin.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := PrincipalFromContext(ctx.Request.Context())
+		if !ok {
+			abortWithError(ctx, http.StatusUnauthorized, domain.ErrUnauthorized)
+			return
+		}
+		if !principal.HasScope(scope) {
+			logging.FromContext(ctx).Infof("%s is missing scope %s for %s %s", principal.Subject, scope, ctx.Request.Method, ctx.FullPath())
+			abortWithError(ctx, http.StatusForbidden, domain.ErrForbidden)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// CanAccessAccount reports whether the caller may act on accountId. End users are bound to the account
+// of their token, service api keys are not.
+func CanAccessAccount(ctx context.Context, accountId int64) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return true
+	}
+	return principal.CanAccessAccount(accountId)
+}
+
+// CanReadPII reports whether the caller may see sensitive response fields unmasked. Like CanAccessAccount,
+// work not started by an API call is trusted.
+func CanReadPII(ctx context.Context) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return true
+	}
+	return principal.HasScope(domain.ScopePiiRead)
+}
+
+// Actor resolves the audit actor from the authenticated principal.
+func Actor(ctx *gin.Context) string {
+	if principal, ok := PrincipalFromContext(ctx.Request.Context()); ok {
+		return principal.Subject
+	}
+	return audit.AnonymousActor
+}
+
+func bearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func abortWithError(ctx *gin.Context, status int, appErr *domain.AppError) {
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type authenticatorFunc func(ctx context.Context, key string) (*domain.Principal, error)
+
+func (f authenticatorFunc) Authenticate(ctx context.Context, key string) (*domain.Principal, error) {
+	return f(ctx, key)
+}
+
+func newTestRouter(scope string) *gin.Engine {
+	authenticator := authenticatorFunc(func(_ context.Context, key string) (*domain.Principal, error) {
+		switch key {
+		case "reader":
+			return &domain.Principal{Subject: "api-key:1", Scopes: []string{domain.ScopeAccountsRead}}, nil
+		case "admin":
+			return &domain.Principal{Subject: "api-key:2", Scopes: []string{domain.ScopeAdmin}}, nil
+		case "broken":
+			return nil, errors.New("connection refused")
+		}
+		return nil, domain.ErrUnauthorized
+	})
+
+	tokenAuthenticator := authenticatorFunc(func(_ context.Context, token string) (*domain.Principal, error) {
+		if token == "user-token" {
+			accountId := int64(7)
+			return &domain.Principal{Subject: "user:alice", Scopes: []string{domain.ScopeAccountsRead}, AccountId: &accountId}, nil
+		}
+		return nil, domain.ErrUnauthorized
+	})
+
+	router := gin.New()
+	router.Use(Middleware(authenticator, tokenAuthenticator))
+	router.GET("/resource", RequireScope(scope), func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, Actor(ctx))
+	})
+	return router
+}
+
+func serve(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+	if strings.HasPrefix(key, "Bearer ") {
+		req.Header.Set(constants.AuthorizationHeader, key)
+	} else if key != "" {
+		req.Header.Set(constants.ApiKeyHeader, key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestMiddleware_Rejects_Missing_Or_Unknown_Key(t *testing.T) {
+	router := newTestRouter(domain.ScopeAccountsRead)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(router, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, "unknown").Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(router, "broken").Code)
+}
+
+func TestRequireScope(t *testing.T) {
+	router := newTestRouter(domain.ScopeAccountsWrite)
+
+	forbidden := serve(router, "reader")
+	allowed := serve(router, "admin")
+
+	assert.Equal(t, http.StatusForbidden, forbidden.Code)
+	assert.JSONEq(t, `{"error_code":"ERR_CC_FORBIDDEN","error_message":"credentials are not allowed to perform this operation.","status_code":403}`, forbidden.Body.String())
+	assert.Equal(t, http.StatusOK, allowed.Code)
+	assert.Equal(t, "api-key:2", allowed.Body.String())
+}
+
+func TestMiddleware_Bearer_Token(t *testing.T) {
+	router := newTestRouter(domain.ScopeAccountsRead)
+
+	allowed := serve(router, "Bearer user-token")
+
+	assert.Equal(t, http.StatusOK, allowed.Code)
+	assert.Equal(t, "user:alice", allowed.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, serve(router, "Bearer forged").Code)
+}
+
+func TestMiddleware_Rejects_Bearer_Token_When_Tokens_Are_Disabled(t *testing.T) {
+	router := gin.New()
+	router.Use(Middleware(authenticatorFunc(func(context.Context, string) (*domain.Principal, error) {
+		return &domain.Principal{Subject: "api-key:1"}, nil
+	}), nil))
+	router.GET("/resource", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusUnauthorized, serve(router, "Bearer user-token").Code)
+}
+
+func TestCanAccessAccount(t *testing.T) {
+	accountId := int64(7)
+	endUser := WithPrincipal(context.TODO(), &domain.Principal{Subject: "user:alice", AccountId: &accountId})
+	service := WithPrincipal(context.TODO(), &domain.Principal{Subject: "api-key:1"})
+
+	assert.True(t, CanAccessAccount(endUser, 7))
+	assert.False(t, CanAccessAccount(endUser, 8))
+	assert.True(t, CanAccessAccount(service, 8))
+}
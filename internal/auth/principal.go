@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/credit-card-api/internal/domain"
+)
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, false for work not started by an API call.
+func PrincipalFromContext(ctx context.Context) (*domain.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal, ok
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// endUserScopes are the only scopes a token can grant, whatever else its scope claim lists.
+var endUserScopes = []string{domain.ScopeAccountsRead, domain.ScopeTransactionsWrite}
+
+type KeySource interface {
+	Key(kid string) (crypto.PublicKey, error)
+}
+
+type TokenConfig struct {
+	// Issuer and Audience are only checked when set.
+	Issuer   string `yaml:"issuer" env:"JWT_ISSUER"`
+	Audience string `yaml:"audience" env:"JWT_AUDIENCE"`
+	// AccountClaim names the claim holding the id of the account the end user owns.
+	AccountClaim string        `yaml:"account_claim" validate:"required"`
+	Leeway       time.Duration `yaml:"leeway" validate:"gte=0"`
+}
+
+func DefaultTokenConfig() TokenConfig {
+	return TokenConfig{AccountClaim: "account_id", Leeway: 30 * time.Second}
+}
+
+// TokenAuthenticator validates RS256 and ES256 end-user tokens forwarded by the gateway.
+type TokenAuthenticator struct {
+	keys   KeySource
+	config TokenConfig
+	parser *jwt.Parser
+}
+
+func NewTokenAuthenticator(keys KeySource, config TokenConfig) *TokenAuthenticator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	return &TokenAuthenticator{keys: keys, config: config, parser: jwt.NewParser(options...)}
+}
+
+func (ta *TokenAuthenticator) Authenticate(ctx context.Context, token string) (*domain.Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := ta.parser.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return ta.keys.Key(kid)
+	})
+	if err != nil {
+		logging.FromContext(ctx).Infof("rejected bearer token: %s", err.Error())
+		return nil, domain.ErrUnauthorized
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		logging.FromContext(ctx).Info("rejected bearer token without subject")
+		return nil, domain.ErrUnauthorized
+	}
+	accountId, err := accountIdClaim(claims[ta.config.AccountClaim])
+	if err != nil {
+		logging.FromContext(ctx).Infof("rejected bearer token of %s: %s", subject, err.Error())
+		return nil, domain.ErrUnauthorized
+	}
+
+	return &domain.Principal{
+		Subject:   "user:" + subject,
+		Scopes:    scopeClaims(claims),
+		AccountId: &accountId,
+	}, nil
+}
+
+// scopeClaims reads the OAuth "scope" string or the "scp" list and keeps the end-user scopes.
+func scopeClaims(claims jwt.MapClaims) []string {
+	var requested []string
+	if scope, ok := claims["scope"].(string); ok {
+		requested = append(requested, strings.Fields(scope)...)
+	}
+	switch scp := claims["scp"].(type) {
+	case string:
+		requested = append(requested, strings.Fields(scp)...)
+	case []any:
+		for _, value := range scp {
+			if scope, ok := value.(string); ok {
+				requested = append(requested, scope)
+			}
+		}
+	}
+
+	scopes := make([]string, 0, len(endUserScopes))
+	for _, scope := range endUserScopes {
+		if slices.Contains(requested, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func accountIdClaim(value any) (int64, error) {
+	switch accountId := value.(type) {
+	case float64:
+		if accountId > 0 && accountId == float64(int64(accountId)) {
+			return int64(accountId), nil
+		}
+	case string:
+		if parsed, err := strconv.ParseInt(accountId, 10, 64); err == nil && parsed > 0 {
+			return parsed, nil
+		}
+	case nil:
+		return 0, errors.New("account claim is missing")
+	}
+	return 0, fmt.Errorf("account claim %v is not an account id", value)
+}
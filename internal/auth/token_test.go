@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticKeys map[string]crypto.PublicKey
+
+func (k staticKeys) Key(kid string) (crypto.PublicKey, error) {
+	if key, ok := k[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key crypto.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":        "alice",
+		"iss":        "https://gateway.example.com",
+		"aud":        "credit-card-api",
+		"exp":        time.Now().Add(time.Hour).Unix(),
+		"scope":      "accounts:read transactions:write admin",
+		"account_id": 7,
+	}
+}
+
+func TestTokenAuthenticator_RS256_And_ES256(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	config := DefaultTokenConfig()
+	config.Issuer = "https://gateway.example.com"
+	config.Audience = "credit-card-api"
+	authenticator := NewTokenAuthenticator(staticKeys{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey}, config)
+
+	for _, token := range []string{
+		signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims()),
+		signToken(t, jwt.SigningMethodES256, "ec", ecKey, validClaims()),
+	} {
+		principal, err := authenticator.Authenticate(context.TODO(), token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user:alice", principal.Subject)
+		assert.Equal(t, []string{domain.ScopeAccountsRead, domain.ScopeTransactionsWrite}, principal.Scopes)
+		assert.Equal(t, int64(7), *principal.AccountId)
+	}
+}
+
+func TestTokenAuthenticator_Rejects_Invalid_Tokens(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	config := DefaultTokenConfig()
+	config.Audience = "credit-card-api"
+	authenticator := NewTokenAuthenticator(staticKeys{"rsa": &rsaKey.PublicKey}, config)
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	withoutAccount := validClaims()
+	delete(withoutAccount, "account_id")
+	otherAudience := validClaims()
+	otherAudience["aud"] = "another-api"
+
+	tokens := map[string]string{
+		"expired":         signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, expired),
+		"without account": signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, withoutAccount),
+		"other audience":  signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, otherAudience),
+		"unknown kid":     signToken(t, jwt.SigningMethodRS256, "other", rsaKey, validClaims()),
+		"wrong key":       signToken(t, jwt.SigningMethodRS256, "rsa", otherKey, validClaims()),
+		"hs256":           signToken(t, jwt.SigningMethodHS256, "rsa", []byte("secret"), validClaims()),
+	}
+	for name, token := range tokens {
+		_, err := authenticator.Authenticate(context.TODO(), token)
+
+		assert.Equal(t, domain.ErrUnauthorized, err, name)
+	}
+}
+
+func TestJWKSFile_Reloads_Directory_On_Change(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	writeJWKS(t, filepath.Join(dir, "rsa.json"), map[string]string{
+		"kty": "RSA", "kid": "rsa", "use": "sig",
+		"n": encodeBigInt(rsaKey.N), "e": encodeBigInt(big.NewInt(int64(rsaKey.E))),
+	})
+
+	jwks, err := LoadJWKSFile(dir)
+	require.NoError(t, err)
+	key, err := jwks.Key("rsa")
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(key))
+	_, err = jwks.Key("ec")
+	assert.Equal(t, ErrUnknownKey, err)
+
+	writeJWKS(t, filepath.Join(dir, "ec.json"), map[string]string{
+		"kty": "EC", "kid": "ec", "crv": "P-256",
+		"x": encodeBigInt(ecKey.X), "y": encodeBigInt(ecKey.Y),
+	})
+	reloaded, err := jwks.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	key, err = jwks.Key("ec")
+	require.NoError(t, err)
+	assert.True(t, ecKey.PublicKey.Equal(key))
+
+	reloaded, err = jwks.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+}
+
+func TestJWKSFile_Keeps_Keys_When_Reload_Fails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	writeJWKS(t, path, map[string]string{
+		"kty": "RSA", "kid": "rsa",
+		"n": encodeBigInt(rsaKey.N), "e": encodeBigInt(big.NewInt(int64(rsaKey.E))),
+	})
+	jwks, err := LoadJWKSFile(path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys": [`), 0o600))
+	_, err = jwks.Reload()
+
+	assert.Error(t, err)
+	_, err = jwks.Key("rsa")
+	assert.NoError(t, err)
+}
+
+func writeJWKS(t *testing.T, path string, key map[string]string) {
+	document, _ := json.Marshal(map[string]any{"keys": []map[string]string{key}})
+	require.NoError(t, os.WriteFile(path, document, 0o600))
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
@@ -0,0 +1,186 @@
+package clearing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/services"
+)
+
+// RejectInvalidRecord is the reject code of a record that fails the validation of a transaction request.
+const RejectInvalidRecord = "invalid_record"
+
+// RejectsHeader is the first row of a rejects file.
+var RejectsHeader = []string{"line", "reference", "code", "reason", "record"}
+
+// DefaultSource is the source of the references of a clearing file when none is given.
+const DefaultSource = "network"
+
+// Summary is the outcome of an import run. AlreadyImported counts the records handled by earlier runs, and the
+// records whose reference was already posted from another file.
+type Summary struct {
+	File            string `json:"file"`
+	Source          string `json:"source"`
+	Format          string `json:"format"`
+	AlreadyImported int64  `json:"already_imported"`
+	Posted          int64  `json:"posted"`
+	Rejected        int64  `json:"rejected"`
+}
+
+// Importer posts the records of clearing files through the TransactionService. The progress of a file is
+// checkpointed in the same database transaction as each posted record, so an import that stops for any reason
+// resumes after the last record it posted. The reference of each posted record is claimed in that transaction
+// too, so a record is never posted twice, even from a file with other content.
+type Importer struct {
+	transactions services.TransactionService
+	checkpoints  repository.ImportCheckpointRepository
+	transactor   repository.Transactor
+}
+
+func NewImporter(transactions services.TransactionService, checkpoints repository.ImportCheckpointRepository, transactor repository.Transactor) *Importer {
+	return &Importer{transactions: transactions, checkpoints: checkpoints, transactor: transactor}
+}
+
+// Import reads the whole file to identify it by content, then posts its records from the checkpoint on. source
+// names who issued the references of the file. Every rejected record is written to rejects before its line is
+// checkpointed. Any other error, ErrInternal included, stops the import before the checkpoint of its record;
+// running it again resumes it.
+func (im *Importer) Import(ctx context.Context, source string, name string, file io.ReadSeeker, format string, rejects io.Writer) (*Summary, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(file, format)
+	if err != nil {
+		return nil, err
+	}
+	fileHash := hex.EncodeToString(hash.Sum(nil))
+	checkpoint, err := im.checkpoints.Start(ctx, fileHash, name)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{File: name, Source: source, Format: format}
+	rejectWriter := csv.NewWriter(rejects)
+	for {
+		record, readErr := reader.Next()
+		if errors.Is(readErr, io.EOF) {
+			return summary, nil
+		}
+		var lineErr *LineError
+		if errors.As(readErr, &lineErr) {
+			record, readErr = &Record{Line: lineErr.Line, Raw: lineErr.Raw, Reference: lineErr.Reference}, nil
+		}
+		if readErr != nil {
+			return summary, readErr
+		}
+		if record.Line <= checkpoint.Line {
+			summary.AlreadyImported++
+			continue
+		}
+
+		var rejectErr *domain.AppError
+		if lineErr != nil {
+			rejectErr = &domain.AppError{Code: lineErr.Code, Message: lineErr.Reason}
+		} else if validationErr := record.Request.Validate(); validationErr != nil {
+			rejectErr = &domain.AppError{Code: RejectInvalidRecord, Message: validationErr.Error()}
+		} else {
+			rejectErr, err = im.post(ctx, source, fileHash, record, summary)
+			if err != nil {
+				return summary, err
+			}
+		}
+		if rejectErr == nil {
+			continue
+		}
+		if err = im.reject(ctx, fileHash, record, rejectErr, rejectWriter, summary); err != nil {
+			return summary, err
+		}
+	}
+}
+
+// post claims the reference of record, creates its transaction and checkpoints its line. It returns the AppError
+// that rejects the record, or the error that stops the import.
+func (im *Importer) post(ctx context.Context, source string, fileHash string, record *Record, summary *Summary) (*domain.AppError, error) {
+	err := im.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		checkpoint, err := im.checkpoints.Lock(txCtx, fileHash)
+		if err != nil {
+			return err
+		}
+		// A concurrent import of the same file got to the line first.
+		if checkpoint.Line >= record.Line {
+			summary.AlreadyImported++
+			return nil
+		}
+		claimed, err := im.checkpoints.ClaimReference(txCtx, source, record.Reference, fileHash, record.Line)
+		if err != nil {
+			return err
+		}
+		// A reference another file already posted only moves the checkpoint.
+		if claimed {
+			if _, err := im.transactions.CreateTransaction(txCtx, record.Request); err != nil {
+				return err
+			}
+			checkpoint.Posted++
+		}
+		checkpoint.Line = record.Line
+		if err := im.checkpoints.Save(txCtx, *checkpoint); err != nil {
+			return err
+		}
+		if claimed {
+			summary.Posted++
+		} else {
+			summary.AlreadyImported++
+		}
+		return nil
+	})
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) && rejectable(appErr) {
+		return appErr, nil
+	}
+	return nil, err
+}
+
+// rejectable tells whether appErr is about the record itself, so posting it again could only fail the same way.
+func rejectable(appErr *domain.AppError) bool {
+	switch appErr.Code {
+	case domain.ErrInvalidOperationType.Code, domain.ErrTransactionAccountNotFound.Code, domain.ErrAccountBlocked.Code:
+		return true
+	}
+	return false
+}
+
+// reject writes record to the rejects file, then checkpoints its line. A crash in between writes the record again
+// on resume rather than losing it.
+func (im *Importer) reject(ctx context.Context, fileHash string, record *Record, appErr *domain.AppError, rejects *csv.Writer, summary *Summary) error {
+	if err := rejects.Write([]string{strconv.FormatInt(record.Line, 10), record.Reference, appErr.Code, appErr.Message, record.Raw}); err != nil {
+		return err
+	}
+	rejects.Flush()
+	if err := rejects.Error(); err != nil {
+		return err
+	}
+	return im.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		checkpoint, err := im.checkpoints.Lock(txCtx, fileHash)
+		if err != nil || checkpoint.Line >= record.Line {
+			return err
+		}
+		checkpoint.Line = record.Line
+		checkpoint.Rejected++
+		if err := im.checkpoints.Save(txCtx, *checkpoint); err != nil {
+			return err
+		}
+		summary.Rejected++
+		return nil
+	})
+}
@@ -0,0 +1,157 @@
+package clearing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/repository/memory"
+	"github.com/credit-card-api/internal/services"
+	"github.com/stretchr/testify/suite"
+)
+
+// ImporterTestSuite imports over the memory storage, so checkpoints commit and roll back with the transactions.
+type ImporterTestSuite struct {
+	suite.Suite
+	context            context.Context
+	repositories       repository.Repositories
+	accountService     services.AccountService
+	transactionService services.TransactionService
+	importer           *Importer
+}
+
+// crashingTransactionService fails with err, or an infrastructure error, once calls transactions were created.
+type crashingTransactionService struct {
+	services.TransactionService
+	calls int
+	err   error
+}
+
+func (s *crashingTransactionService) CreateTransaction(ctx context.Context, request models.TransactionRequest) (*domain.Transaction, error) {
+	if s.calls == 0 && s.err != nil {
+		return nil, s.err
+	}
+	if s.calls == 0 {
+		return nil, errors.New("connection reset")
+	}
+	s.calls--
+	return s.TransactionService.CreateTransaction(ctx, request)
+}
+
+func TestImporterTestSuite(t *testing.T) {
+	suite.Run(t, new(ImporterTestSuite))
+}
+
+func (suite *ImporterTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.repositories = memory.NewRepositories()
+	auditService := services.NewAuditService(suite.repositories.Audit, suite.repositories.Transactor)
+	suite.accountService = services.NewAccountService(suite.repositories.Accounts, suite.repositories.Outbox, suite.repositories.Transactor, auditService, metrics.Noop{})
+	suite.transactionService = services.NewTransactionService(suite.repositories.Transactions, suite.repositories.Accounts, suite.repositories.Outbox, suite.repositories.Transactor, auditService, metrics.Noop{})
+	suite.importer = NewImporter(suite.transactionService, suite.repositories.ImportCheckpoints, suite.repositories.Transactor)
+
+	for _, documentNumber := range []string{"0123456789", "9876543210"} {
+		_, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: documentNumber})
+		suite.Require().NoError(err)
+	}
+	_, err := suite.accountService.BlockAccount(suite.context, 2)
+	suite.Require().NoError(err)
+}
+
+func (suite *ImporterTestSuite) countTransactions(accountId int64) int {
+	transactions, err := suite.transactionService.ListTransactions(suite.context, accountId)
+	suite.Require().NoError(err)
+	return len(transactions)
+}
+
+func (suite *ImporterTestSuite) TestImport_Posts_And_Rejects_Once() {
+	file := "reference,record_type,account_id,amount\n" +
+		"REF-1,PC,1,50\n" +
+		"REF-2,XX,1,10\n" +
+		"REF-3,PC,1,0\n" +
+		"REF-4,PC,2,10\n" +
+		"REF-5,CV,1,20\n"
+	var rejects bytes.Buffer
+
+	summary, err := suite.importer.Import(suite.context, DefaultSource, "clearing.csv", strings.NewReader(file), FormatCSV, &rejects)
+	again, againErr := suite.importer.Import(suite.context, DefaultSource, "clearing.csv", strings.NewReader(file), FormatCSV, &rejects)
+
+	suite.NoError(err)
+	suite.Equal(Summary{File: "clearing.csv", Source: DefaultSource, Format: FormatCSV, Posted: 2, Rejected: 3}, *summary)
+	suite.Equal(2, suite.countTransactions(1))
+	rejectLines := strings.Split(strings.TrimSpace(rejects.String()), "\n")
+	suite.Require().Len(rejectLines, 3)
+	suite.Equal(`3,REF-2,unknown_record_type,"record type ""XX"" has no operation type","REF-2,XX,1,10"`, rejectLines[0])
+	suite.True(strings.HasPrefix(rejectLines[1], "4,REF-3,invalid_record,"))
+	suite.True(strings.HasPrefix(rejectLines[2], "5,REF-4,ERR_CC_ACCOUNT_BLOCKED,"), rejectLines[2])
+	suite.NoError(againErr)
+	suite.Equal(Summary{File: "clearing.csv", Source: DefaultSource, Format: FormatCSV, AlreadyImported: 5}, *again)
+	suite.Equal(2, suite.countTransactions(1))
+}
+
+func (suite *ImporterTestSuite) TestImport_Resumes_After_Crash_Without_Duplicates() {
+	file := "REF-1,PC,1,10\nREF-2,PC,1,20\nREF-3,PC,1,30\nREF-4,CV,1,5\n"
+	crashing := NewImporter(&crashingTransactionService{TransactionService: suite.transactionService, calls: 2}, suite.repositories.ImportCheckpoints, suite.repositories.Transactor)
+	var rejects bytes.Buffer
+
+	_, crashErr := crashing.Import(suite.context, DefaultSource, "clearing.csv", strings.NewReader(file), FormatCSV, &rejects)
+	resumed, resumeErr := suite.importer.Import(suite.context, DefaultSource, "renamed.csv", strings.NewReader(file), FormatCSV, &rejects)
+
+	suite.EqualError(crashErr, "connection reset")
+	suite.NoError(resumeErr)
+	suite.Equal(int64(2), resumed.AlreadyImported)
+	suite.Equal(int64(2), resumed.Posted)
+	suite.Empty(rejects.String())
+	suite.Equal(4, suite.countTransactions(1))
+}
+
+func (suite *ImporterTestSuite) TestImport_Stops_On_Internal_Error_Without_Rejecting() {
+	file := "REF-1,PC,1,10\nREF-2,PC,1,20\n"
+	failing := NewImporter(&crashingTransactionService{TransactionService: suite.transactionService, calls: 1, err: domain.ErrInternal}, suite.repositories.ImportCheckpoints, suite.repositories.Transactor)
+	var rejects bytes.Buffer
+
+	_, failErr := failing.Import(suite.context, DefaultSource, "clearing.csv", strings.NewReader(file), FormatCSV, &rejects)
+	resumed, resumeErr := suite.importer.Import(suite.context, DefaultSource, "clearing.csv", strings.NewReader(file), FormatCSV, &rejects)
+
+	suite.ErrorIs(failErr, domain.ErrInternal)
+	suite.Empty(rejects.String())
+	suite.NoError(resumeErr)
+	suite.Equal(Summary{File: "clearing.csv", Source: DefaultSource, Format: FormatCSV, AlreadyImported: 1, Posted: 1}, *resumed)
+	suite.Equal(2, suite.countTransactions(1))
+}
+
+func (suite *ImporterTestSuite) TestImport_Posts_A_Reference_Once_Across_Files() {
+	first := "REF-1,PC,1,10\nREF-2,PC,1,20\n"
+	resent := "reference,record_type,account_id,amount\nREF-2,PC,1,20\nREF-3,PC,1,30\nREF-3,PC,1,30\n"
+	var rejects bytes.Buffer
+
+	_, firstErr := suite.importer.Import(suite.context, DefaultSource, "clearing.csv", strings.NewReader(first), FormatCSV, &rejects)
+	summary, err := suite.importer.Import(suite.context, DefaultSource, "resent.csv", strings.NewReader(resent), FormatCSV, &rejects)
+	other, otherErr := suite.importer.Import(suite.context, "acquirer", "acquirer.csv", strings.NewReader("REF-1,PC,1,10\nREF-2,PC,1,25\n"), FormatCSV, &rejects)
+
+	suite.NoError(firstErr)
+	suite.NoError(err)
+	suite.Equal(Summary{File: "resent.csv", Source: DefaultSource, Format: FormatCSV, AlreadyImported: 2, Posted: 1}, *summary)
+	suite.NoError(otherErr)
+	suite.Equal(int64(2), other.Posted)
+	suite.Empty(rejects.String())
+	suite.Equal(5, suite.countTransactions(1))
+}
+
+func (suite *ImporterTestSuite) TestImport_Rejected_Record_Releases_Its_Reference() {
+	var rejects bytes.Buffer
+
+	_, blockedErr := suite.importer.Import(suite.context, DefaultSource, "blocked.csv", strings.NewReader("REF-1,PC,2,10\n"), FormatCSV, &rejects)
+	summary, err := suite.importer.Import(suite.context, DefaultSource, "corrected.csv", strings.NewReader("REF-1,PC,1,10\n"), FormatCSV, &rejects)
+
+	suite.NoError(blockedErr)
+	suite.NoError(err)
+	suite.Equal(int64(1), summary.Posted)
+	suite.Equal(1, suite.countTransactions(1))
+}
@@ -0,0 +1,164 @@
+// Package clearing imports the clearing files of the card network: CSV or fixed-width records, each one a
+// transaction to post. Record types map to operation types through RecordTypes.
+package clearing
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/credit-card-api/internal/models"
+)
+
+// Formats of a clearing file.
+const (
+	// FormatCSV records are reference,record_type,account_id,amount lines, the amount in decimal units. A first
+	// line starting with "reference" is a header.
+	FormatCSV = "csv"
+	// FormatFixed records are 47 characters wide: record type (2), reference (20, space padded), account id (12,
+	// zero padded) and amount in cents (13, zero padded). HD header and TR trailer records are skipped.
+	FormatFixed = "fixed"
+)
+
+var Formats = []string{FormatCSV, FormatFixed}
+
+// RecordTypes maps the record types of a clearing file to operation types.
+var RecordTypes = map[string]int64{
+	"PC": 1, // Normal Purchase
+	"PI": 2, // Purchase with Installments
+	"WD": 3, // Withdrawal
+	"CV": 4, // Credit Voucher
+}
+
+// Reject codes of the lines that are not records.
+const (
+	RejectMalformedRecord   = "malformed_record"
+	RejectUnknownRecordType = "unknown_record_type"
+)
+
+const (
+	fixedHeaderType  = "HD"
+	fixedTrailerType = "TR"
+	fixedWidth       = 47
+)
+
+// Record is a line of a clearing file, numbered from 1.
+type Record struct {
+	Line      int64
+	Raw       string
+	Reference string
+	Request   models.TransactionRequest
+}
+
+// LineError is a line that is not a record. Reading goes on with the next line. Reference is empty when the
+// line is not readable up to it.
+type LineError struct {
+	Line      int64
+	Raw       string
+	Reference string
+	Code      string
+	Reason    string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+}
+
+// Reader reads the records of a clearing file, skipping blank lines, headers and trailers.
+type Reader struct {
+	scanner *bufio.Scanner
+	parse   func(line int64, raw string) (*Record, error)
+	line    int64
+}
+
+func NewReader(r io.Reader, format string) (*Reader, error) {
+	reader := &Reader{scanner: bufio.NewScanner(r)}
+	switch format {
+	case FormatCSV:
+		reader.parse = parseCSV
+	case FormatFixed:
+		reader.parse = parseFixed
+	default:
+		return nil, fmt.Errorf("unknown clearing file format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+	return reader, nil
+}
+
+// Next returns the next record, a *LineError for a line that is not one, or io.EOF after the last line.
+func (r *Reader) Next() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		raw := strings.TrimSuffix(r.scanner.Text(), "\r")
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		record, err := r.parse(r.line, raw)
+		if err != nil || record != nil {
+			return record, err
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// parseCSV returns a nil record for the header.
+func parseCSV(line int64, raw string) (*Record, error) {
+	fields, err := csv.NewReader(strings.NewReader(raw)).Read()
+	if err != nil {
+		return nil, &LineError{Line: line, Raw: raw, Code: RejectMalformedRecord, Reason: err.Error()}
+	}
+	if line == 1 && strings.EqualFold(strings.TrimSpace(fields[0]), "reference") {
+		return nil, nil
+	}
+	if len(fields) != 4 {
+		return nil, &LineError{Line: line, Raw: raw, Code: RejectMalformedRecord, Reason: fmt.Sprintf("record has %d fields, expected 4", len(fields))}
+	}
+	amount, err := strconv.ParseFloat(strings.TrimSpace(fields[3]), 64)
+	if err != nil {
+		return nil, &LineError{Line: line, Raw: raw, Reference: strings.TrimSpace(fields[0]), Code: RejectMalformedRecord, Reason: fmt.Sprintf("amount %q is not a number", fields[3])}
+	}
+	return newRecord(line, raw, fields[0], fields[1], fields[2], amount)
+}
+
+// parseFixed returns a nil record for the header and the trailer.
+func parseFixed(line int64, raw string) (*Record, error) {
+	recordType := raw[:min(2, len(raw))]
+	if recordType == fixedHeaderType || recordType == fixedTrailerType {
+		return nil, nil
+	}
+	if len(raw) < fixedWidth {
+		return nil, &LineError{Line: line, Raw: raw, Code: RejectMalformedRecord, Reason: fmt.Sprintf("record is %d characters wide, expected %d", len(raw), fixedWidth)}
+	}
+	cents, err := strconv.ParseUint(raw[34:47], 10, 64)
+	if err != nil {
+		return nil, &LineError{Line: line, Raw: raw, Reference: strings.TrimSpace(raw[2:22]), Code: RejectMalformedRecord, Reason: fmt.Sprintf("amount %q is not a number of cents", raw[34:47])}
+	}
+	return newRecord(line, raw, raw[2:22], recordType, raw[22:34], float64(cents)/100)
+}
+
+func newRecord(line int64, raw string, reference string, recordType string, accountId string, amount float64) (*Record, error) {
+	reference, recordType = strings.TrimSpace(reference), strings.TrimSpace(recordType)
+	// The reference is what keeps a record from being posted twice, a record without one cannot be imported.
+	if reference == "" {
+		return nil, &LineError{Line: line, Raw: raw, Code: RejectMalformedRecord, Reason: "record has no reference"}
+	}
+	operationTypeId, known := RecordTypes[recordType]
+	if !known {
+		return nil, &LineError{Line: line, Raw: raw, Reference: reference, Code: RejectUnknownRecordType, Reason: fmt.Sprintf("record type %q has no operation type", recordType)}
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(accountId), 10, 64)
+	if err != nil {
+		return nil, &LineError{Line: line, Raw: raw, Reference: reference, Code: RejectMalformedRecord, Reason: fmt.Sprintf("account id %q is not a number", accountId)}
+	}
+	return &Record{
+		Line:      line,
+		Raw:       raw,
+		Reference: reference,
+		Request:   models.TransactionRequest{AccountId: id, OperationTypeId: operationTypeId, Amount: amount},
+	}, nil
+}
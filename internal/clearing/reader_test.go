@@ -0,0 +1,83 @@
+package clearing
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAll returns the records of file and the lines that are not records.
+func readAll(t *testing.T, file string, format string) ([]Record, []LineError) {
+	reader, err := NewReader(strings.NewReader(file), format)
+	require.NoError(t, err)
+	var records []Record
+	var lineErrs []LineError
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records, lineErrs
+		}
+		var lineErr *LineError
+		if errors.As(err, &lineErr) {
+			lineErrs = append(lineErrs, *lineErr)
+			continue
+		}
+		require.NoError(t, err)
+		records = append(records, *record)
+	}
+}
+
+func TestReader_CSV(t *testing.T) {
+	file := "reference,record_type,account_id,amount\r\n" +
+		"REF-1,PC,1,50.25\r\n" +
+		"\r\n" +
+		"REF-2,CV,2,10\r\n" +
+		"REF-3,XX,1,10\r\n" +
+		"REF-4,WD,1\r\n" +
+		"REF-5,PI,abc,10\r\n" +
+		" ,PC,1,10\r\n"
+
+	records, lineErrs := readAll(t, file, FormatCSV)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, Record{Line: 2, Raw: "REF-1,PC,1,50.25", Reference: "REF-1", Request: models.TransactionRequest{AccountId: 1, OperationTypeId: 1, Amount: 50.25}}, records[0])
+	assert.Equal(t, int64(4), records[1].Line)
+	assert.Equal(t, int64(4), records[1].Request.OperationTypeId)
+	require.Len(t, lineErrs, 4)
+	assert.Equal(t, RejectUnknownRecordType, lineErrs[0].Code)
+	assert.Equal(t, int64(5), lineErrs[0].Line)
+	assert.Equal(t, "record has 3 fields, expected 4", lineErrs[1].Reason)
+	assert.Equal(t, `account id "abc" is not a number`, lineErrs[2].Reason)
+	assert.Equal(t, "record has no reference", lineErrs[3].Reason)
+}
+
+func TestReader_Fixed(t *testing.T) {
+	file := "HD20261019CLEARING\n" +
+		"PCREF-1               0000000000010000000012345\n" +
+		"WDREF-2               0000000000020000000010000FILLER\n" +
+		"CVREF-3               000000000001\n" +
+		"PIREF-4               00000000000100000000012A45\n" +
+		"TR0000000004\n"
+
+	records, lineErrs := readAll(t, file, FormatFixed)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, "REF-1", records[0].Reference)
+	assert.Equal(t, models.TransactionRequest{AccountId: 1, OperationTypeId: 1, Amount: 123.45}, records[0].Request)
+	assert.Equal(t, models.TransactionRequest{AccountId: 2, OperationTypeId: 3, Amount: 100}, records[1].Request)
+	require.Len(t, lineErrs, 2)
+	assert.Equal(t, "record is 34 characters wide, expected 47", lineErrs[0].Reason)
+	assert.Equal(t, int64(5), lineErrs[1].Line)
+	assert.Equal(t, RejectMalformedRecord, lineErrs[1].Code)
+}
+
+func TestNewReader_Unknown_Format(t *testing.T) {
+	_, err := NewReader(strings.NewReader(""), "xml")
+
+	assert.EqualError(t, err, `unknown clearing file format "xml", expected one of csv, fixed`)
+}
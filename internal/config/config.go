@@ -0,0 +1,120 @@
+package config
+
+import (
+	"time"
+
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/controllers"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/outbox"
+	"github.com/credit-card-api/internal/ratelimit"
+	"github.com/credit-card-api/internal/statement"
+	"github.com/credit-card-api/internal/tracing"
+)
+
+// Config is the effective configuration. Every leaf can be set from the YAML file, from an environment
+// variable (the `env` tag, or the upper-cased YAML path joined by underscores) and from a flag named
+// after the YAML path, in increasing order of precedence.
+type Config struct {
+	// Storage selects the repository backend. memory needs no database, keyring or migrations and loses
+	// every change on shutdown, it is meant for local development.
+	Storage   string                   `yaml:"storage" validate:"oneof=postgres memory"`
+	HTTP      HTTPConfig               `yaml:"http"`
+	Database  DatabaseConfig           `yaml:"database"`
+	Log       LogConfig                `yaml:"log"`
+	Auth      AuthConfig               `yaml:"auth"`
+	RateLimit ratelimit.Config         `yaml:"rate_limit"`
+	Webhooks  outbox.Config            `yaml:"webhooks"`
+	Stream    controllers.StreamConfig `yaml:"stream"`
+	// TransactionBatch bounds the size of POST /transactions:batch requests.
+	TransactionBatch controllers.BatchConfig `yaml:"transaction_batch"`
+	// Export sets what GET /accounts/{accountId}/transactions/export writes beside the transactions.
+	Export controllers.ExportConfig `yaml:"export"`
+	// Statements sets the rules of GET /accounts/{accountId}/statements/{id}.pdf.
+	Statements statement.Config  `yaml:"statements"`
+	Tracing    tracing.Config    `yaml:"tracing"`
+	Encryption encryption.Config `yaml:"encryption"`
+}
+
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr" validate:"required"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" validate:"gt=0"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" validate:"gt=0"`
+	// WriteTimeout does not apply to the transaction stream, which clears its write deadline.
+	WriteTimeout time.Duration `yaml:"write_timeout" validate:"gt=0"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" validate:"gt=0"`
+	// ShutdownDelay is how long the server keeps serving after SIGINT/SIGTERM with /readyz failing, so load
+	// balancers stop routing to it before it stops accepting connections.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" validate:"gte=0"`
+	// ShutdownTimeout bounds the drain of in-flight requests and background workers on SIGINT/SIGTERM.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" validate:"gt=0"`
+}
+
+type DatabaseConfig struct {
+	URL               string        `yaml:"url" env:"DB_URL" secret:"true" validate:"required"`
+	MaxConns          int32         `yaml:"max_conns" validate:"gte=1"`
+	MinConns          int32         `yaml:"min_conns" validate:"gte=0,ltefield=MaxConns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" validate:"gt=0"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" validate:"gt=0"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" validate:"gt=0"`
+	ConnectTimeout    time.Duration `yaml:"connect_timeout" validate:"gt=0"`
+	// AutoMigrate applies pending migrations on startup, see `credit-card-api migrate`.
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" validate:"oneof=debug info warn error"`
+	Format string `yaml:"format" validate:"oneof=text json"`
+}
+
+type AuthConfig struct {
+	// AdminApiKey is the bootstrap admin key, empty disables it.
+	AdminApiKey string `yaml:"admin_api_key" env:"ADMIN_API_KEY" secret:"true"`
+	// JwksPath is a JWKS file or directory, empty disables bearer tokens.
+	JwksPath           string           `yaml:"jwks_path" env:"JWKS_PATH"`
+	JwksReloadInterval time.Duration    `yaml:"jwks_reload_interval" validate:"gt=0"`
+	Token              auth.TokenConfig `yaml:"token"`
+}
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+func Default() Config {
+	return Config{
+		Storage: StoragePostgres,
+		HTTP: HTTPConfig{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			MaxConns:          10,
+			MinConns:          0,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   30 * time.Minute,
+			HealthCheckPeriod: time.Minute,
+			ConnectTimeout:    5 * time.Second,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Auth: AuthConfig{
+			JwksReloadInterval: 10 * time.Second,
+			Token:              auth.DefaultTokenConfig(),
+		},
+		RateLimit:        ratelimit.DefaultConfig(),
+		Webhooks:         outbox.DefaultConfig(),
+		Stream:           controllers.DefaultStreamConfig(),
+		TransactionBatch: controllers.DefaultBatchConfig(),
+		Export:           controllers.DefaultExportConfig(),
+		Statements:       statement.DefaultConfig(),
+		Tracing:          tracing.DefaultConfig(),
+	}
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	fileFlag = "config"
+	fileEnv  = "CONFIG_FILE"
+	redacted = "*****"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// leaf is a configurable scalar of the Config tree.
+type leaf struct {
+	path   string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+// Load builds the configuration from defaults, the YAML file given by --config or CONFIG_FILE,
+// environment variables and flags, then validates it.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	leaves := collectLeaves(reflect.ValueOf(&cfg).Elem(), "", "")
+
+	flags := flag.NewFlagSet("credit-card-api", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	file := flags.String(fileFlag, "", "path of the YAML configuration file")
+	flagValues := map[string]*string{}
+	for _, l := range leaves {
+		flagValues[l.path] = flags.String(l.path, "", fmt.Sprintf("overrides %s (env %s)", l.path, l.env))
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			flags.SetOutput(os.Stderr)
+			flags.PrintDefaults()
+		}
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	path := *file
+	if path == "" {
+		path, _ = lookupEnv(fileEnv)
+	}
+	if path != "" {
+		if err := loadFile(&cfg, path); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs []error
+	for _, l := range leaves {
+		if raw, ok := lookupEnv(l.env); ok {
+			if err := setValue(l.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("config: env %s: %w", l.env, err))
+			}
+		}
+	}
+	flags.Visit(func(f *flag.Flag) {
+		for _, l := range leaves {
+			if l.path == f.Name {
+				if err := setValue(l.value, *flagValues[l.path]); err != nil {
+					errs = append(errs, fmt.Errorf("config: flag --%s: %w", l.path, err))
+				}
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return nil
+}
+
+// postgresOnly are the settings the memory storage does not need.
+var postgresOnly = map[string]bool{
+	"database.url":            true,
+	"encryption.keyring_file": true,
+}
+
+// Validate reports every invalid setting by its YAML path.
+func (c *Config) Validate() error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("yaml"), ",")[0]
+	})
+
+	err := validate.Struct(c)
+	var validationErrs validator.ValidationErrors
+	if err != nil && !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	var errs []error
+	for _, fieldErr := range validationErrs {
+		path := strings.TrimPrefix(fieldErr.Namespace(), "Config.")
+		if c.Storage == StorageMemory && postgresOnly[path] {
+			continue
+		}
+		rule := fieldErr.Tag()
+		if fieldErr.Param() != "" {
+			rule += "=" + fieldErr.Param()
+		}
+		errs = append(errs, fmt.Errorf("config: %s is invalid, it must satisfy %s", path, rule))
+	}
+	// A batch takes a token per item from the transactions bucket, a larger one than it holds could never pass. It
+	// takes a single token per account, so the per-account burst does not bound it.
+	if burst := c.RateLimit.Transactions.Burst; c.TransactionBatch.MaxItems > burst {
+		errs = append(errs, fmt.Errorf("config: transaction_batch.max_items is invalid, it must not exceed rate_limit.transactions.burst (%d)", burst))
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted lists the effective settings as "path=value" lines with secrets masked.
+func (c *Config) Redacted() []string {
+	var lines []string
+	for _, l := range collectLeaves(reflect.ValueOf(c).Elem(), "", "") {
+		value := formatValue(l.value)
+		if l.secret && value != "" {
+			value = redacted
+		}
+		lines = append(lines, l.path+"="+value)
+	}
+	return lines
+}
+
+func collectLeaves(value reflect.Value, path string, env string) []leaf {
+	var leaves []leaf
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fieldPath := joinPath(path, name, ".")
+		fieldEnv := joinPath(env, strings.ToUpper(name), "_")
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Struct {
+			leaves = append(leaves, collectLeaves(fieldValue, fieldPath, fieldEnv)...)
+			continue
+		}
+		if explicitEnv := field.Tag.Get("env"); explicitEnv != "" {
+			fieldEnv = explicitEnv
+		}
+		leaves = append(leaves, leaf{path: fieldPath, env: fieldEnv, secret: field.Tag.Get("secret") == "true", value: fieldValue})
+	}
+	return leaves
+}
+
+func joinPath(prefix string, name string, separator string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + separator + name
+}
+
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported setting type %s", value.Type())
+	}
+	return nil
+}
+
+func formatValue(value reflect.Value) string {
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String()
+	}
+	return fmt.Sprint(value.Interface())
+}
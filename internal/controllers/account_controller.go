@@ -4,14 +4,18 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/credit-card-api/internal/auth"
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
 	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/redact"
 	"github.com/credit-card-api/internal/services"
 	"github.com/credit-card-api/pkg/constants"
 	"github.com/credit-card-api/pkg/utils"
 	"github.com/gin-gonic/gin"
-	logger "github.com/sirupsen/logrus"
 )
 
 type AccountController struct {
@@ -28,25 +32,29 @@ func NewAccountController(accountService services.AccountService) *AccountContro
 // @Tags         Accounts
 // @Accept       json
 // @Produce      json
+// @Security     ApiKeyAuth
 // @Param CreateAccountRequest body models.CreateAccountRequest true "Request Body"
 // @Success      201  {object}  models.CreateAccountResponse
 // @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
 // @Failure      409  {object}  models.ConflictError
+// @Failure      429  {object}  models.TooManyRequestsError
 // @Failure      500  {object}  models.InternalServerError
 // @Router       /api/credit-card-api/v1/accounts [post]
 func (ac *AccountController) CreateAccount(ctx *gin.Context) {
 	var payload models.CreateAccountRequest
 	err := ctx.ShouldBindJSON(&payload)
 	if err != nil {
-		logger.Error("failed to binding a request payload error: ", err)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
 		return
 	}
 
 	validationErr := payload.Validate()
 	if validationErr != nil {
-		logger.Error("validation failure on request payload error: ", validationErr)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, utils.NewCCBadRequestError(validationErr.Error()))
+		logging.FromContext(ctx).Error("validation failure on request payload error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
 		return
 	}
 
@@ -55,7 +63,7 @@ func (ac *AccountController) CreateAccount(ctx *gin.Context) {
 		ac.respondWithError(ctx, accountErr)
 		return
 	}
-	ctx.JSON(http.StatusCreated, mapToCreateAccountResponse(*account))
+	ctx.JSON(http.StatusCreated, maskSensitive(ctx, mapToCreateAccountResponse(*account)))
 	return
 }
 
@@ -64,17 +72,27 @@ func (ac *AccountController) CreateAccount(ctx *gin.Context) {
 // @Description  Get an account by accountId
 // @Tags         Accounts
 // @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param accountId path string true "accountId"
 // @Success      200  {object}  models.GetAccountResponse
+// @Header       200  {string}  ETag  "version of the account, for the If-Match header of PATCH"
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
 // @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
 // @Failure      500  {object}  models.InternalServerError
 // @Router       /api/credit-card-api/v1/accounts/{accountId} [Get]
 func (ac *AccountController) GetAccount(ctx *gin.Context) {
 	accountIdStr := ctx.Param(constants.AccountIdPathParam)
 	id, err := strconv.ParseInt(accountIdStr, 10, 64)
 	if err != nil {
-		logger.Error("failed to read path param error: ", err)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, utils.NewCCBadRequestError(constants.AccountIdMissingErrMsg))
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.AccountIdMissingErrMsg))
+		return
+	}
+	if !auth.CanAccessAccount(ctx.Request.Context(), id) {
+		ac.respondWithError(ctx, domain.ErrForbidden)
 		return
 	}
 
@@ -83,10 +101,75 @@ func (ac *AccountController) GetAccount(ctx *gin.Context) {
 		ac.respondWithError(ctx, accountErr)
 		return
 	}
-	ctx.JSON(http.StatusOK, mapToGetAccountResponse(*account))
+	ctx.Header(constants.ETagHeader, accountETag(account.Version))
+	ctx.JSON(http.StatusOK, maskSensitive(ctx, mapToGetAccountResponse(*account)))
 	return
 }
 
+// UpdateAccount godoc
+// @Summary      Update the holder profile of an account
+// @Description  Update the holder profile of an account. Fields left out or null are kept, an empty string clears a field
+// @Description  and address replaces the stored address. If-Match must carry the ETag of the account, a stale one is rejected.
+// @Tags         Accounts
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param accountId path string true "accountId"
+// @Param If-Match header string true "ETag of the account, e.g. \"3\""
+// @Param UpdateAccountRequest body models.UpdateAccountRequest true "Request Body"
+// @Success      200  {object}  models.GetAccountResponse
+// @Header       200  {string}  ETag  "version of the updated account"
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      412  {object}  models.PreconditionFailedError
+// @Failure      428  {object}  models.PreconditionRequiredError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/accounts/{accountId} [Patch]
+func (ac *AccountController) UpdateAccount(ctx *gin.Context) {
+	accountIdStr := ctx.Param(constants.AccountIdPathParam)
+	id, err := strconv.ParseInt(accountIdStr, 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.AccountIdMissingErrMsg))
+		return
+	}
+	if !auth.CanAccessAccount(ctx.Request.Context(), id) {
+		ac.respondWithError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	version, versionErr := parseIfMatch(ctx.GetHeader(constants.IfMatchHeader))
+	if versionErr != nil {
+		logging.FromContext(ctx).Error("invalid If-Match header error: ", versionErr)
+		ac.respondWithError(ctx, versionErr)
+		return
+	}
+
+	var payload models.UpdateAccountRequest
+	if bindErr := ctx.ShouldBindJSON(&payload); bindErr != nil {
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", bindErr)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		return
+	}
+	if validationErr := payload.Validate(); validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on request payload error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+
+	account, accountErr := ac.accountService.UpdateAccount(ctx, id, version, payload)
+	if accountErr != nil {
+		ac.respondWithError(ctx, accountErr)
+		return
+	}
+	ctx.Header(constants.ETagHeader, accountETag(account.Version))
+	ctx.JSON(http.StatusOK, maskSensitive(ctx, mapToGetAccountResponse(*account)))
+}
+
 func (ac *AccountController) respondWithError(ctx *gin.Context, err error) {
 	var appErr *domain.AppError
 	if !errors.As(err, &appErr) {
@@ -99,9 +182,15 @@ func (ac *AccountController) respondWithError(ctx *gin.Context, err error) {
 		status = http.StatusNotFound
 	case constants.AccountAlreadyExistErrCode:
 		status = http.StatusConflict
+	case constants.ForbiddenErrCode:
+		status = http.StatusForbidden
+	case constants.PreconditionFailedErrCode:
+		status = http.StatusPreconditionFailed
+	case constants.PreconditionRequiredErrCode:
+		status = http.StatusPreconditionRequired
 	}
 
-	ctx.AbortWithStatusJSON(status, &models.CCError{
+	utils.AbortWithError(ctx, &models.CCError{
 		ErrorCode:    appErr.Code,
 		ErrorMessage: appErr.Message,
 		StatusCode:   status,
@@ -115,10 +204,60 @@ func mapToCreateAccountResponse(account domain.Account) models.CreateAccountResp
 	}
 }
 
-// This is duplicate code currently, but account would have more field to return in response.
 func mapToGetAccountResponse(account domain.Account) models.GetAccountResponse {
-	return models.GetAccountResponse{
+	response := models.GetAccountResponse{
 		AccountId:      account.Id,
 		DocumentNumber: account.DocumentNumber,
+		BlockedAt:      account.BlockedAt,
+		FullName:       account.Profile.FullName,
+		Email:          account.Profile.Email,
+		Phone:          account.Profile.Phone,
+		Version:        account.Version,
+		UpdatedAt:      account.UpdatedAt,
+	}
+	if address := account.Profile.Address; address != nil {
+		response.Address = &models.PostalAddress{
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		}
+	}
+	if account.Profile.BirthDate != nil {
+		response.BirthDate = account.Profile.BirthDate.Format(time.DateOnly)
+	}
+	return response
+}
+
+// accountETag is the strong entity tag of an account version.
+func accountETag(version int32) string {
+	return strconv.Quote(strconv.FormatInt(int64(version), 10))
+}
+
+// parseIfMatch reads the account version from an If-Match header. A missing header or * does not pin a version
+// and returns ErrPreconditionRequired; a weak or unknown tag can never match and returns ErrAccountVersionMismatch.
+func parseIfMatch(header string) (int32, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, domain.ErrPreconditionRequired
+	}
+	unquoted, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, domain.ErrAccountVersionMismatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 32)
+	if err != nil {
+		return 0, domain.ErrAccountVersionMismatch
+	}
+	return int32(version), nil
+}
+
+// maskSensitive masks the redact tagged fields of response for callers without the pii:read scope.
+func maskSensitive(ctx *gin.Context, response any) any {
+	if auth.CanReadPII(ctx.Request.Context()) {
+		return response
 	}
+	return redact.Value(response)
 }
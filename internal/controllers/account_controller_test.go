@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/credit-card-api/internal/auth"
 	"github.com/credit-card-api/internal/domain"
 	"github.com/credit-card-api/internal/models"
 	"github.com/credit-card-api/internal/services/mocks"
@@ -209,8 +211,10 @@ func (suite *AccountControllerTestSuite) TestGetAccount_Success() {
 	accountResponse := &domain.Account{
 		Id:             accountId,
 		DocumentNumber: documentNumber,
+		Version:        3,
+		UpdatedAt:      time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
 	}
-	expectedResponseBody := `{"account_id":1,"document_number":"0123456789"}`
+	expectedResponseBody := `{"account_id":1,"document_number":"0123456789","version":3,"updated_at":"2026-10-01T12:00:00Z"}`
 
 	req := httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1", nil)
 	suite.context.Request = req
@@ -223,9 +227,59 @@ func (suite *AccountControllerTestSuite) TestGetAccount_Success() {
 	suite.controller.GetAccount(suite.context)
 
 	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal(`"3"`, suite.recorder.Header().Get("ETag"))
 	suite.Equal(string(expectedResponseBody), suite.recorder.Body.String())
 }
 
+func (suite *AccountControllerTestSuite) TestGetAccount_Masks_DocumentNumber_Without_PiiRead_Scope() {
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	accountResponse := &domain.Account{
+		Id:             accountId,
+		DocumentNumber: documentNumber,
+		Profile: domain.AccountProfile{
+			FullName:  "Maria da Silva",
+			Phone:     "+5511987654321",
+			Address:   &domain.PostalAddress{Line1: "Av. Paulista, 1000", City: "São Paulo", State: "SP", PostalCode: "01310-100", Country: "BR"},
+			BirthDate: &birthDate,
+		},
+		Version:   1,
+		UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	expectedResponseBody := `{"account_id":1,"document_number":"******6789","full_name":"[REDACTED]","phone":"**********4321",` +
+		`"address":{"line1":"[REDACTED]","city":"São Paulo","state":"SP","postal_code":"[REDACTED]","country":"BR"},` +
+		`"birth_date":"[REDACTED]","version":1,"updated_at":"2026-10-01T12:00:00Z"}`
+
+	req := httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1", nil)
+	suite.context.Request = req.WithContext(auth.WithPrincipal(req.Context(), &domain.Principal{Subject: "api-key:1", Scopes: []string{domain.ScopeAccountsRead}}))
+	suite.context.Params = gin.Params{gin.Param{
+		Key:   "accountId",
+		Value: "1",
+	}}
+	suite.mockAccountService.EXPECT().GetAccount(suite.context, accountId).Return(accountResponse, nil)
+
+	suite.controller.GetAccount(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *AccountControllerTestSuite) TestGetAccount_When_EndUser_Does_Not_Own_Account() {
+	ownedAccountId := int64(2)
+	expectedResponseBody := `{"error_code":"ERR_CC_FORBIDDEN","error_message":"credentials are not allowed to perform this operation.","status_code":403}`
+
+	req := httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1", nil)
+	suite.context.Request = req.WithContext(auth.WithPrincipal(req.Context(), &domain.Principal{Subject: "user:alice", AccountId: &ownedAccountId}))
+	suite.context.Params = gin.Params{gin.Param{
+		Key:   "accountId",
+		Value: "1",
+	}}
+
+	suite.controller.GetAccount(suite.context)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
 func (suite *AccountControllerTestSuite) TestGetAccount_When_AccountId_IsMissing() {
 	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"accountId is missing in path params","status_code":400}`
 	req := httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/", nil)
@@ -279,6 +333,102 @@ func (suite *AccountControllerTestSuite) TestGetAccount_When_AccountService_Retu
 	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
 }
 
+func (suite *AccountControllerTestSuite) newUpdateRequest(body string, ifMatch string) {
+	req := httptest.NewRequest(http.MethodPatch, "/api/credit-card-api/v1/accounts/1", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	suite.context.Request = req
+	suite.context.Params = gin.Params{gin.Param{
+		Key:   "accountId",
+		Value: "1",
+	}}
+}
+
+func (suite *AccountControllerTestSuite) TestUpdateAccount_Success() {
+	fullName := "Maria da Silva"
+	email := ""
+	payload := models.UpdateAccountRequest{FullName: &fullName, Email: &email}
+	accountResponse := &domain.Account{
+		Id:             accountId,
+		DocumentNumber: documentNumber,
+		Profile:        domain.AccountProfile{FullName: fullName},
+		Version:        4,
+		UpdatedAt:      time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	expectedResponseBody := `{"account_id":1,"document_number":"0123456789","full_name":"Maria da Silva","version":4,"updated_at":"2026-10-01T12:00:00Z"}`
+	suite.newUpdateRequest(`{"full_name":"Maria da Silva","email":""}`, `"3"`)
+	suite.mockAccountService.EXPECT().UpdateAccount(suite.context, accountId, int32(3), payload).Return(accountResponse, nil)
+
+	suite.controller.UpdateAccount(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal(`"4"`, suite.recorder.Header().Get("ETag"))
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *AccountControllerTestSuite) TestUpdateAccount_Without_If_Match_Returns_Precondition_Required() {
+	for _, ifMatch := range []string{"", "*"} {
+		suite.SetupTest()
+		expectedResponseBody := `{"error_code":"ERR_CC_PRECONDITION_REQUIRED","error_message":"If-Match header with the account ETag is required.","status_code":428}`
+		suite.newUpdateRequest(`{"full_name":"Maria da Silva"}`, ifMatch)
+
+		suite.controller.UpdateAccount(suite.context)
+
+		suite.Equal(http.StatusPreconditionRequired, suite.recorder.Code, ifMatch)
+		suite.Equal(expectedResponseBody, suite.recorder.Body.String(), ifMatch)
+	}
+}
+
+func (suite *AccountControllerTestSuite) TestUpdateAccount_With_Weak_Or_Unknown_ETag_Returns_Precondition_Failed() {
+	for _, ifMatch := range []string{`W/"3"`, "3", `"abc"`} {
+		suite.SetupTest()
+		suite.newUpdateRequest(`{"full_name":"Maria da Silva"}`, ifMatch)
+
+		suite.controller.UpdateAccount(suite.context)
+
+		suite.Equal(http.StatusPreconditionFailed, suite.recorder.Code, ifMatch)
+	}
+}
+
+func (suite *AccountControllerTestSuite) TestUpdateAccount_When_Version_Is_Stale_Returns_Precondition_Failed() {
+	fullName := "Maria da Silva"
+	expectedResponseBody := `{"error_code":"ERR_CC_PRECONDITION_FAILED","error_message":"account was modified since the version in If-Match, fetch it again.","status_code":412}`
+	suite.newUpdateRequest(`{"full_name":"Maria da Silva"}`, `"2"`)
+	suite.mockAccountService.EXPECT().UpdateAccount(suite.context, accountId, int32(2), models.UpdateAccountRequest{FullName: &fullName}).
+		Return(nil, domain.ErrAccountVersionMismatch)
+
+	suite.controller.UpdateAccount(suite.context)
+
+	suite.Equal(http.StatusPreconditionFailed, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *AccountControllerTestSuite) TestUpdateAccount_Lists_Every_Invalid_Field_As_Problem() {
+	suite.newUpdateRequest(`{"email":"maria","phone":"(11) 98765-4321","birth_date":"2999-01-01","address":{"line1":"Av. Paulista, 1000","country":"XX"}}`, `"1"`)
+	suite.context.Request.Header.Set("Accept", "application/problem+json")
+
+	suite.controller.UpdateAccount(suite.context)
+
+	var problem models.Problem
+	suite.Require().NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &problem))
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	fields := make([]string, 0, len(problem.Errors))
+	for _, fieldError := range problem.Errors {
+		fields = append(fields, fieldError.Field+" "+fieldError.Rule)
+	}
+	suite.Equal([]string{
+		"email email",
+		"phone e164",
+		"address.city required",
+		"address.state required",
+		"address.postal_code required",
+		"address.country iso3166_1_alpha2",
+		"birth_date past_date",
+	}, fields)
+}
+
 func (suite *AccountControllerTestSuite) TearDownTest() {
 	suite.mockController.Finish()
 }
@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ApiKeyController struct {
+	apiKeyService services.ApiKeyService
+}
+
+func NewApiKeyController(apiKeyService services.ApiKeyService) *ApiKeyController {
+	return &ApiKeyController{apiKeyService: apiKeyService}
+}
+
+// IssueApiKey godoc
+// @Summary      Issue an api key
+// @Description  Issue an api key with the given scopes. The plain key is only returned here.
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param CreateApiKeyRequest body models.CreateApiKeyRequest true "Request Body"
+// @Success      201  {object}  models.IssuedApiKeyResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/api-keys [post]
+func (ac *ApiKeyController) IssueApiKey(ctx *gin.Context) {
+	var payload models.CreateApiKeyRequest
+	err := ctx.ShouldBindJSON(&payload)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		return
+	}
+
+	validationErr := payload.Validate()
+	if validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on request payload error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+
+	issued, issueErr := ac.apiKeyService.IssueKey(ctx, payload)
+	if issueErr != nil {
+		ac.respondWithError(ctx, issueErr)
+		return
+	}
+	ctx.JSON(http.StatusCreated, mapToIssuedApiKeyResponse(*issued))
+}
+
+// ListApiKeys godoc
+// @Summary      List api keys
+// @Description  List all api keys, including revoked ones. Only the key prefix is returned.
+// @Tags         API Keys
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {array}   models.ApiKeyResponse
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/api-keys [get]
+func (ac *ApiKeyController) ListApiKeys(ctx *gin.Context) {
+	apiKeys, err := ac.apiKeyService.ListKeys(ctx)
+	if err != nil {
+		ac.respondWithError(ctx, err)
+		return
+	}
+
+	response := make([]models.ApiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		response = append(response, mapToApiKeyResponse(apiKey))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RotateApiKey godoc
+// @Summary      Rotate an api key
+// @Description  Revoke an active api key and issue a new one with the same name and scopes
+// @Tags         API Keys
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param apiKeyId path string true "apiKeyId"
+// @Success      201  {object}  models.IssuedApiKeyResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/api-keys/{apiKeyId}/rotate [post]
+func (ac *ApiKeyController) RotateApiKey(ctx *gin.Context) {
+	id, ok := ac.apiKeyIdFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	issued, err := ac.apiKeyService.RotateKey(ctx, id)
+	if err != nil {
+		ac.respondWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, mapToIssuedApiKeyResponse(*issued))
+}
+
+// RevokeApiKey godoc
+// @Summary      Revoke an api key
+// @Description  Revoke an active api key, requests using it are rejected from now on
+// @Tags         API Keys
+// @Security     ApiKeyAuth
+// @Param apiKeyId path string true "apiKeyId"
+// @Success      204
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/api-keys/{apiKeyId} [delete]
+func (ac *ApiKeyController) RevokeApiKey(ctx *gin.Context) {
+	id, ok := ac.apiKeyIdFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	err := ac.apiKeyService.RevokeKey(ctx, id)
+	if err != nil {
+		ac.respondWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ac *ApiKeyController) apiKeyIdFromPath(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param(constants.ApiKeyIdPathParam), 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.ApiKeyIdMissingErrMsg))
+		return 0, false
+	}
+	return id, true
+}
+
+func (ac *ApiKeyController) respondWithError(ctx *gin.Context, err error) {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		appErr = domain.ErrInternal
+	}
+
+	status := http.StatusInternalServerError
+	switch appErr.Code {
+	case constants.ApiKeyNotFoundErrCode:
+		status = http.StatusNotFound
+	}
+
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
+
+func mapToApiKeyResponse(apiKey domain.ApiKey) models.ApiKeyResponse {
+	return models.ApiKeyResponse{
+		ApiKeyId:    apiKey.Id,
+		Name:        apiKey.Name,
+		Prefix:      apiKey.Prefix,
+		Scopes:      apiKey.Scopes,
+		RotatedFrom: apiKey.RotatedFrom,
+		CreatedAt:   apiKey.CreatedAt,
+		RevokedAt:   apiKey.RevokedAt,
+	}
+}
+
+func mapToIssuedApiKeyResponse(issued domain.IssuedApiKey) models.IssuedApiKeyResponse {
+	return models.IssuedApiKeyResponse{
+		ApiKeyResponse: mapToApiKeyResponse(issued.ApiKey),
+		Key:            issued.Key,
+	}
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ApiKeyControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockApiKeyService *mocks.MockApiKeyService
+	controller        *ApiKeyController
+}
+
+func TestApiKeyControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyControllerTestSuite))
+}
+
+func (suite *ApiKeyControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockApiKeyService = mocks.NewMockApiKeyService(suite.mockController)
+	suite.controller = NewApiKeyController(suite.mockApiKeyService)
+}
+
+func (suite *ApiKeyControllerTestSuite) TestIssueApiKey_Success_Returns_Key() {
+	payload := models.CreateApiKeyRequest{Name: "billing-service", Scopes: []string{"accounts:read"}}
+	issued := &domain.IssuedApiKey{
+		ApiKey: domain.ApiKey{
+			Id:        1,
+			Name:      payload.Name,
+			Prefix:    "cck_01234567",
+			Scopes:    payload.Scopes,
+			CreatedAt: time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
+		},
+		Key: "cck_0123456789",
+	}
+	expectedResponseBody := `{"api_key_id":1,"name":"billing-service","prefix":"cck_01234567","scopes":["accounts:read"],"created_at":"2026-01-01T10:00:00Z","key":"cck_0123456789"}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/api-keys", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+	suite.mockApiKeyService.EXPECT().IssueKey(suite.context, payload).Return(issued, nil).Times(1)
+
+	suite.controller.IssueApiKey(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.JSONEq(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *ApiKeyControllerTestSuite) TestIssueApiKey_When_Scope_Is_Unknown() {
+	bodyBytes, _ := json.Marshal(models.CreateApiKeyRequest{Name: "billing-service", Scopes: []string{"accounts:delete"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/api-keys", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+
+	suite.controller.IssueApiKey(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ApiKeyControllerTestSuite) TestRevokeApiKey_When_NotFound() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/api/credit-card-api/v1/api-keys/404", nil)
+	suite.context.Params = gin.Params{{Key: "apiKeyId", Value: "404"}}
+	suite.mockApiKeyService.EXPECT().RevokeKey(suite.context, int64(404)).Return(domain.ErrApiKeyNotFound).Times(1)
+
+	suite.controller.RevokeApiKey(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *ApiKeyControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
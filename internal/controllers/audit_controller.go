@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	auditService services.AuditService
+}
+
+func NewAuditController(auditService services.AuditService) *AuditController {
+	return &AuditController{auditService: auditService}
+}
+
+// ListAuditLogs godoc
+// @Summary      List audit log entries
+// @Description  List audit entries in insertion order. Page with after_id set to the next_after_id of the previous page.
+// @Tags         Audit
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param resource_type query string false "resource type, e.g. account, transaction, webhook_subscription"
+// @Param resource_id query string false "resource id"
+// @Param actor query string false "actor"
+// @Param from query string false "inclusive lower bound, RFC 3339"
+// @Param to query string false "exclusive upper bound, RFC 3339"
+// @Param after_id query int false "return entries after this audit id"
+// @Param limit query int false "page size, at most 500"
+// @Success      200  {object}  models.ListAuditLogsResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/audit-logs [get]
+func (ac *AuditController) ListAuditLogs(ctx *gin.Context) {
+	var query models.ListAuditLogsQuery
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to binding query params error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidQueryParamErrMsg))
+		return
+	}
+
+	validationErr := query.Validate()
+	if validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on query params error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+
+	filter := domain.AuditFilter{
+		ResourceType: query.ResourceType,
+		ResourceId:   query.ResourceId,
+		Actor:        query.Actor,
+		From:         query.From,
+		To:           query.To,
+		AfterId:      query.AfterId,
+		Limit:        query.Limit,
+	}
+	entries, entriesErr := ac.auditService.ListEntries(ctx, filter)
+	if entriesErr != nil {
+		ac.respondWithError(ctx, entriesErr)
+		return
+	}
+
+	response := models.ListAuditLogsResponse{Entries: make([]models.AuditEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, mapToAuditEntryResponse(entry))
+	}
+	if len(entries) > 0 {
+		response.NextAfterId = entries[len(entries)-1].Id
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// VerifyAuditLogs godoc
+// @Summary      Verify the audit log hash chain
+// @Description  Recompute the hash chain of the whole audit log and report the first entry that does not match.
+// @Tags         Audit
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  models.AuditVerificationResponse
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/audit-logs/verify [get]
+func (ac *AuditController) VerifyAuditLogs(ctx *gin.Context) {
+	verification, err := ac.auditService.VerifyChain(ctx)
+	if err != nil {
+		ac.respondWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, models.AuditVerificationResponse{
+		Valid:          verification.Valid,
+		EntriesChecked: verification.EntriesChecked,
+		FirstInvalidId: verification.FirstInvalidId,
+		LastHash:       verification.LastHash,
+	})
+}
+
+func (ac *AuditController) respondWithError(ctx *gin.Context, err error) {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		appErr = domain.ErrInternal
+	}
+
+	status := http.StatusInternalServerError
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
+
+func mapToAuditEntryResponse(entry domain.AuditEntry) models.AuditEntryResponse {
+	return models.AuditEntryResponse{
+		AuditId:      entry.Id,
+		Actor:        entry.Actor,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceId:   entry.ResourceId,
+		Before:       entry.Before,
+		After:        entry.After,
+		Diff:         entry.Diff,
+		RequestId:    entry.RequestId,
+		SourceIp:     entry.SourceIp,
+		CreatedAt:    entry.CreatedAt,
+		PrevHash:     entry.PrevHash,
+		Hash:         entry.Hash,
+	}
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AuditControllerTestSuite struct {
+	suite.Suite
+	context          *gin.Context
+	recorder         *httptest.ResponseRecorder
+	mockController   *gomock.Controller
+	mockAuditService *mocks.MockAuditService
+	controller       *AuditController
+}
+
+func TestAuditControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditControllerTestSuite))
+}
+
+func (suite *AuditControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockAuditService = mocks.NewMockAuditService(suite.mockController)
+	suite.controller = NewAuditController(suite.mockAuditService)
+}
+
+func (suite *AuditControllerTestSuite) TestListAuditLogs_Success() {
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.AuditFilter{ResourceType: "account", ResourceId: "1", From: &from, Limit: 10}
+	entries := []domain.AuditEntry{{
+		Id:           7,
+		Actor:        "anonymous",
+		Action:       domain.AuditActionAccountCreate,
+		ResourceType: domain.AuditResourceAccount,
+		ResourceId:   "1",
+		After:        []byte(`{"account_id":1}`),
+		Diff:         []byte(`{"account_id":{"from":null,"to":1}}`),
+		CreatedAt:    from,
+		PrevHash:     "prev",
+		Hash:         "hash",
+	}}
+	expectedResponseBody := `{"entries":[{"audit_id":7,"actor":"anonymous","action":"account.create","resource_type":"account","resource_id":"1","before":null,"after":{"account_id":1},"diff":{"account_id":{"from":null,"to":1}},"request_id":"","source_ip":"","created_at":"2026-01-01T00:00:00Z","prev_hash":"prev","hash":"hash"}],"next_after_id":7}`
+
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/audit-logs?resource_type=account&resource_id=1&from=2026-01-01T00:00:00Z&limit=10", nil)
+	suite.mockAuditService.EXPECT().ListEntries(suite.context, filter).Return(entries, nil).Times(1)
+
+	suite.controller.ListAuditLogs(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *AuditControllerTestSuite) TestListAuditLogs_When_Limit_Too_Large() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/audit-logs?limit=1000", nil)
+
+	suite.controller.ListAuditLogs(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *AuditControllerTestSuite) TestVerifyAuditLogs_Reports_Broken_Chain() {
+	expectedResponseBody := `{"valid":false,"entries_checked":4,"first_invalid_id":4,"last_hash":"hash"}`
+
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/audit-logs/verify", nil)
+	suite.mockAuditService.EXPECT().VerifyChain(suite.context).
+		Return(&domain.AuditVerification{Valid: false, EntriesChecked: 4, FirstInvalidId: 4, LastHash: "hash"}, nil).Times(1)
+
+	suite.controller.VerifyAuditLogs(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *AuditControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
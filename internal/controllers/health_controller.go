@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	healthService services.HealthService
+}
+
+func NewHealthController(healthService services.HealthService) *HealthController {
+	return &HealthController{healthService: healthService}
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is running. It does not check dependencies.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  models.HealthResponse
+// @Router       /healthz [get]
+func (hc *HealthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, mapToHealthResponse(hc.healthService.Liveness(ctx)))
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Pings the database, verifies the expected migration version is applied and the operation types are seeded.
+// @Description  Fails while the server is shutting down. Every check reports its status and latency.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  models.HealthResponse
+// @Failure      503  {object}  models.HealthResponse
+// @Router       /readyz [get]
+func (hc *HealthController) Readiness(ctx *gin.Context) {
+	report := hc.healthService.Readiness(ctx)
+
+	status := http.StatusOK
+	if report.Status != domain.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, mapToHealthResponse(report))
+}
+
+func mapToHealthResponse(report domain.HealthReport) models.HealthResponse {
+	response := models.HealthResponse{Status: report.Status}
+	for _, check := range report.Checks {
+		response.Checks = append(response.Checks, models.HealthCheckResponse{
+			Name:      check.Name,
+			Status:    check.Status,
+			LatencyMs: float64(check.Latency) / float64(time.Millisecond),
+			Error:     check.Error,
+		})
+	}
+	return response
+}
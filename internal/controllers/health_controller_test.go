@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type HealthControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockHealthService *mocks.MockHealthService
+	controller        *HealthController
+}
+
+func TestHealthControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthControllerTestSuite))
+}
+
+func (suite *HealthControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockHealthService = mocks.NewMockHealthService(suite.mockController)
+	suite.controller = NewHealthController(suite.mockHealthService)
+}
+
+func (suite *HealthControllerTestSuite) TestLiveness() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	suite.mockHealthService.EXPECT().Liveness(suite.context).Return(domain.HealthReport{Status: domain.HealthStatusUp})
+
+	suite.controller.Liveness(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"status":"up"}`, suite.recorder.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TestReadiness_When_Up() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	suite.mockHealthService.EXPECT().Readiness(suite.context).Return(domain.HealthReport{
+		Status: domain.HealthStatusUp,
+		Checks: []domain.HealthCheck{{Name: domain.HealthCheckDatabase, Status: domain.HealthStatusUp, Latency: 1500 * time.Microsecond}},
+	})
+
+	suite.controller.Readiness(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`{"status":"up","checks":[{"name":"database","status":"up","latency_ms":1.5}]}`, suite.recorder.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TestReadiness_When_Down() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	suite.mockHealthService.EXPECT().Readiness(suite.context).Return(domain.HealthReport{
+		Status: domain.HealthStatusDown,
+		Checks: []domain.HealthCheck{{Name: domain.HealthCheckShutdown, Status: domain.HealthStatusDown, Error: "server is shutting down"}},
+	})
+
+	suite.controller.Readiness(suite.context)
+
+	suite.Equal(http.StatusServiceUnavailable, suite.recorder.Code)
+	suite.JSONEq(`{"status":"down","checks":[{"name":"shutdown","status":"down","latency_ms":0,"error":"server is shutting down"}]}`, suite.recorder.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/redact"
+	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/internal/statement"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	pdfExtension   = "pdf"
+	pdfContentType = "application/pdf"
+)
+
+type StatementController struct {
+	statementService services.StatementService
+}
+
+func NewStatementController(statementService services.StatementService) *StatementController {
+	return &StatementController{statementService: statementService}
+}
+
+// GetStatementPDF godoc
+// @Summary      Download an account statement
+// @Description  Download the statement of a closed billing cycle as PDF. Cycles are calendar months in UTC and the id of a statement is its month as YYYYMM, so 202609.pdf is the statement of September 2026.
+// @Description  It lists the transactions created within the cycle with their totals, the minimum payment and the due date. The holder name is masked without the pii:read scope.
+// @Tags         Accounts
+// @Produce      application/pdf
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param accountId path string true "accountId"
+// @Param statementFile path string true "statement id and extension, 202609.pdf"
+// @Success      200
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/accounts/{accountId}/statements/{statementFile} [get]
+func (sc *StatementController) GetStatementPDF(ctx *gin.Context) {
+	accountId, err := strconv.ParseInt(ctx.Param(constants.AccountIdPathParam), 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.AccountIdMissingErrMsg))
+		return
+	}
+	if !auth.CanAccessAccount(ctx.Request.Context(), accountId) {
+		sc.respondWithError(ctx, domain.ErrForbidden)
+		return
+	}
+	// Only the PDF format is served; any other file of a statement does not exist.
+	id, isPDF := strings.CutSuffix(ctx.Param(constants.StatementFilePathParam), "."+pdfExtension)
+	statementId, err := strconv.ParseInt(id, 10, 64)
+	if !isPDF || err != nil {
+		sc.respondWithError(ctx, domain.ErrStatementNotFound)
+		return
+	}
+
+	document, err := sc.statementService.GetStatement(ctx, accountId, statementId)
+	if err != nil {
+		sc.respondWithError(ctx, err)
+		return
+	}
+	if !auth.CanReadPII(ctx.Request.Context()) && document.HolderName != constants.EmptyString {
+		document.HolderName = redact.Redacted
+	}
+	// The document is rendered before the status is sent, so a failure still gets an error response.
+	var out bytes.Buffer
+	if err := statement.RenderPDF(&out, document); err != nil {
+		logging.FromContext(ctx).Errorf("failed to render statement %d of accountId: %d, error: %s", statementId, accountId, err.Error())
+		sc.respondWithError(ctx, err)
+		return
+	}
+	ctx.Header(constants.ContentDispositionHeader, fmt.Sprintf(`attachment; filename="%s"`, document.FileName(pdfExtension)))
+	ctx.Data(http.StatusOK, pdfContentType, out.Bytes())
+}
+
+func (sc *StatementController) respondWithError(ctx *gin.Context, err error) {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		appErr = domain.ErrInternal
+	}
+
+	status := http.StatusInternalServerError
+	switch appErr.Code {
+	case constants.AccountNotFoundErrCode, constants.StatementNotFoundErrCode:
+		status = http.StatusNotFound
+	case constants.ForbiddenErrCode:
+		status = http.StatusForbidden
+	}
+
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/credit-card-api/internal/statement"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type StatementControllerTestSuite struct {
+	suite.Suite
+	context              *gin.Context
+	recorder             *httptest.ResponseRecorder
+	mockStatementService *mocks.MockStatementService
+	controller           *StatementController
+}
+
+func TestStatementControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(StatementControllerTestSuite))
+}
+
+func (suite *StatementControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Params = gin.Params{{Key: "accountId", Value: "1"}, {Key: "statementFile", Value: "202609.pdf"}}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/statements/202609.pdf", nil)
+	suite.mockStatementService = mocks.NewMockStatementService(gomock.NewController(suite.T()))
+	suite.controller = NewStatementController(suite.mockStatementService)
+}
+
+func (suite *StatementControllerTestSuite) document() *statement.Document {
+	return &statement.Document{
+		Id: 202609, AccountId: 1, HolderName: "Maria da Silva", Currency: "BRL",
+		PeriodStart: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), PeriodEnd: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		DueDate: time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC), GeneratedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (suite *StatementControllerTestSuite) TestGetStatementPDF() {
+	suite.mockStatementService.EXPECT().GetStatement(suite.context, int64(1), int64(202609)).Return(suite.document(), nil)
+
+	suite.controller.GetStatementPDF(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("application/pdf", suite.recorder.Header().Get("Content-Type"))
+	suite.Equal(`attachment; filename="account-1-statement-202609.pdf"`, suite.recorder.Header().Get("Content-Disposition"))
+	suite.True(bytes.HasPrefix(suite.recorder.Body.Bytes(), []byte("%PDF-")))
+	suite.Contains(suite.recorder.Body.String(), "Maria da Silva")
+}
+
+func (suite *StatementControllerTestSuite) TestGetStatementPDF_Masks_Holder_Name_Without_PII_Scope() {
+	suite.context.Request = suite.context.Request.WithContext(auth.WithPrincipal(suite.context.Request.Context(),
+		&domain.Principal{Subject: "api-key:1", Scopes: []string{domain.ScopeAccountsRead}}))
+	suite.mockStatementService.EXPECT().GetStatement(suite.context, int64(1), int64(202609)).Return(suite.document(), nil)
+
+	suite.controller.GetStatementPDF(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.NotContains(suite.recorder.Body.String(), "Maria da Silva")
+	suite.Contains(suite.recorder.Body.String(), "[REDACTED]")
+}
+
+func (suite *StatementControllerTestSuite) TestGetStatementPDF_Forbidden_For_Other_Account() {
+	ownedAccountId := int64(2)
+	suite.context.Request = suite.context.Request.WithContext(auth.WithPrincipal(suite.context.Request.Context(),
+		&domain.Principal{Subject: "user:alice", AccountId: &ownedAccountId, Scopes: []string{domain.ScopeAccountsRead}}))
+
+	suite.controller.GetStatementPDF(suite.context)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), "ERR_CC_FORBIDDEN")
+}
+
+func (suite *StatementControllerTestSuite) TestGetStatementPDF_Not_Found() {
+	suite.context.Params[1].Value = "202609.html"
+
+	suite.controller.GetStatementPDF(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	suite.JSONEq(`{"error_code":"ERR_CC_STATEMENT_NOT_FOUND","error_message":"statement of a closed cycle does not exist with provided id.","status_code":404}`, suite.recorder.Body.String())
+}
+
+func (suite *StatementControllerTestSuite) TestGetStatementPDF_Open_Cycle() {
+	suite.mockStatementService.EXPECT().GetStatement(suite.context, int64(1), int64(202609)).Return(nil, domain.ErrStatementNotFound)
+
+	suite.controller.GetStatementPDF(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
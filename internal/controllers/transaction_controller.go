@@ -4,21 +4,35 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/credit-card-api/internal/auth"
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/i18n"
+	"github.com/credit-card-api/internal/logging"
 	"github.com/credit-card-api/internal/models"
 	"github.com/credit-card-api/internal/services"
 	"github.com/credit-card-api/pkg/constants"
 	"github.com/credit-card-api/pkg/utils"
 	"github.com/gin-gonic/gin"
-	logger "github.com/sirupsen/logrus"
+	ut "github.com/go-playground/universal-translator"
 )
 
+type BatchConfig struct {
+	// MaxItems bounds the items of a POST /transactions:batch request. Every item takes a token of the transactions
+	// rate limit, so it cannot exceed its burst.
+	MaxItems int `yaml:"max_items" validate:"gte=1,lte=10000"`
+}
+
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{MaxItems: 100}
+}
+
 type TransactionController struct {
 	transactionService services.TransactionService
+	batchConfig        BatchConfig
 }
 
-func NewTransactionController(transactionService services.TransactionService) *TransactionController {
-	return &TransactionController{transactionService: transactionService}
+func NewTransactionController(transactionService services.TransactionService, batchConfig BatchConfig) *TransactionController {
+	return &TransactionController{transactionService: transactionService, batchConfig: batchConfig}
 }
 
 // CreateTransaction godoc
@@ -27,25 +41,36 @@ func NewTransactionController(transactionService services.TransactionService) *T
 // @Tags         Transactions
 // @Accept       json
 // @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param CreateTransactionRequest body models.TransactionRequest true "Request Body"
 // @Success      201  {object} models.CreateTransactionResponse
 // @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
 // @Failure      422  {object}  models.UnprocessableEntityError
+// @Failure      413  {object}  models.PayloadTooLargeError
+// @Failure      429  {object}  models.TooManyRequestsError
 // @Failure      500  {object}  models.InternalServerError
 // @Router       /api/credit-card-api/v1/transactions [post]
 func (tc *TransactionController) CreateTransaction(ctx *gin.Context) {
 	var payload models.TransactionRequest
 	err := ctx.ShouldBindJSON(&payload)
 	if err != nil {
-		logger.Error("failed to binding a request payload error: ", err)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
 		return
 	}
 
 	validationErr := payload.Validate()
 	if validationErr != nil {
-		logger.Error("validation failure on request payload error:", validationErr)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, utils.NewCCBadRequestError(validationErr.Error()))
+		logging.FromContext(ctx).Error("validation failure on request payload error:", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+
+	if !auth.CanAccessAccount(ctx.Request.Context(), payload.AccountId) {
+		tc.respondWithError(ctx, domain.ErrForbidden)
 		return
 	}
 
@@ -57,6 +82,86 @@ func (tc *TransactionController) CreateTransaction(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, mapToCreateTransactionResponse(*transaction))
 }
 
+// CreateTransactionBatch godoc
+// @Summary      Create a batch of transactions
+// @Description  Create up to transaction_batch.max_items transactions, each item validated like POST /transactions and
+// @Description  processed in account order. In best_effort mode (the default) every valid item is created on its own;
+// @Description  in all_or_nothing mode one invalid or failing item leaves every item uncreated. Results follow the
+// @Description  order of the items.
+// @Tags         Transactions
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param BatchTransactionRequest body models.BatchTransactionRequest true "Request Body"
+// @Success      200  {object}  models.BatchTransactionResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      413  {object}  models.PayloadTooLargeError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/transactions:batch [post]
+func (tc *TransactionController) CreateTransactionBatch(ctx *gin.Context) {
+	var payload models.BatchTransactionRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		return
+	}
+	if validationErr := payload.Validate(tc.batchConfig.MaxItems); validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on request payload error:", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+	if payload.Mode == constants.EmptyString {
+		payload.Mode = models.BatchModeBestEffort
+	}
+	allOrNothing := payload.Mode == models.BatchModeAllOrNothing
+
+	// Items rejected here never reach the service, the others keep their index to merge the outcomes back.
+	outcomes := make([]domain.BatchItemOutcome, len(payload.Items))
+	var accepted []models.TransactionRequest
+	var acceptedIndexes []int
+	for i, item := range payload.Items {
+		if validationErr := item.Validate(); validationErr != nil {
+			outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemFailed, Err: validationErr}
+			continue
+		}
+		if !auth.CanAccessAccount(ctx.Request.Context(), item.AccountId) {
+			outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemFailed, Err: domain.ErrForbidden}
+			continue
+		}
+		accepted = append(accepted, item)
+		acceptedIndexes = append(acceptedIndexes, i)
+	}
+
+	if allOrNothing && len(accepted) < len(payload.Items) {
+		for _, i := range acceptedIndexes {
+			outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemSkipped}
+		}
+	} else if len(accepted) > 0 {
+		for j, outcome := range tc.transactionService.CreateTransactions(ctx, accepted, allOrNothing) {
+			outcomes[acceptedIndexes[j]] = outcome
+		}
+	}
+
+	translator := i18n.Translator(ctx.GetHeader(constants.AcceptLanguageHeader))
+	ctx.Header(constants.ContentLanguageHeader, i18n.LanguageTag(translator))
+	response := models.BatchTransactionResponse{Mode: payload.Mode, Results: make([]models.BatchTransactionResult, 0, len(outcomes))}
+	for i, outcome := range outcomes {
+		result := mapToBatchTransactionResult(i, outcome, translator)
+		switch result.Status {
+		case domain.BatchItemCreated:
+			response.Created++
+		case domain.BatchItemFailed:
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
 func (tc *TransactionController) respondWithError(ctx *gin.Context, err error) {
 	var appErr *domain.AppError
 	if !errors.As(err, &appErr) {
@@ -65,17 +170,48 @@ func (tc *TransactionController) respondWithError(ctx *gin.Context, err error) {
 
 	status := http.StatusInternalServerError
 	switch appErr.Code {
-	case constants.InvalidOperationTypeErrCode, constants.TransactionAccountNotFoundErrCode:
+	case constants.InvalidOperationTypeErrCode, constants.TransactionAccountNotFoundErrCode, constants.AccountBlockedErrCode:
 		status = http.StatusUnprocessableEntity
+	case constants.ForbiddenErrCode:
+		status = http.StatusForbidden
 	}
 
-	ctx.AbortWithStatusJSON(status, &models.CCError{
+	utils.AbortWithError(ctx, &models.CCError{
 		ErrorCode:    appErr.Code,
 		ErrorMessage: appErr.Message,
 		StatusCode:   status,
 	})
 }
 
+// mapToBatchTransactionResult reports the error of a failed item like the error response of POST /transactions would,
+// in the language of translator.
+func mapToBatchTransactionResult(index int, outcome domain.BatchItemOutcome, translator ut.Translator) models.BatchTransactionResult {
+	result := models.BatchTransactionResult{Index: index, Status: outcome.Status}
+	if outcome.Transaction != nil {
+		result.TransactionId = outcome.Transaction.Id
+	}
+	if outcome.Err == nil {
+		return result
+	}
+
+	var ccError *models.CCError
+	var fieldErrors models.ValidationErrors
+	var appErr *domain.AppError
+	switch {
+	case errors.As(outcome.Err, &fieldErrors):
+		ccError = utils.NewCCValidationError(fieldErrors)
+	case errors.As(outcome.Err, &appErr):
+		ccError = &models.CCError{ErrorCode: appErr.Code, ErrorMessage: appErr.Message}
+	default:
+		ccError = &models.CCError{ErrorCode: domain.ErrInternal.Code, ErrorMessage: domain.ErrInternal.Message}
+	}
+	ccError = ccError.Localized(translator)
+	result.ErrorCode = ccError.ErrorCode
+	result.ErrorMessage = ccError.ErrorMessage
+	result.Errors = ccError.Errors
+	return result
+}
+
 func mapToCreateTransactionResponse(transaction domain.Transaction) models.CreateTransactionResponse {
 	return models.CreateTransactionResponse{
 		TransactionId: transaction.Id,
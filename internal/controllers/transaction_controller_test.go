@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/credit-card-api/internal/auth"
 	"github.com/credit-card-api/internal/domain"
 	"github.com/credit-card-api/internal/models"
 	"github.com/credit-card-api/internal/services/mocks"
@@ -42,7 +43,7 @@ func (suite *TransactionControllerTestSuite) SetupTest() {
 	suite.context, _ = gin.CreateTestContext(suite.recorder)
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockTransactionService = mocks.NewMockTransactionService(suite.mockController)
-	suite.transactionController = NewTransactionController(suite.mockTransactionService)
+	suite.transactionController = NewTransactionController(suite.mockTransactionService, DefaultBatchConfig())
 	testAccountId = 1
 	testTxnId = 1
 }
@@ -74,6 +75,26 @@ func (suite *TransactionControllerTestSuite) TestCreateTransaction_Success() {
 	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
 }
 
+func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_EndUser_Does_Not_Own_Account() {
+	ownedAccountId := testAccountId + 1
+	payload := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          12345.67,
+	}
+	expectedResponseBody := `{"error_code":"ERR_CC_FORBIDDEN","error_message":"credentials are not allowed to perform this operation.","status_code":403}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req.WithContext(auth.WithPrincipal(req.Context(), &domain.Principal{Subject: "user:alice", AccountId: &ownedAccountId}))
+
+	suite.transactionController.CreateTransaction(suite.context)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
 func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Payload_Binding_Fails() {
 	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"invalid request body","status_code":400}`
 
@@ -165,6 +186,86 @@ func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Amount_I
 	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
 }
 
+func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Validation_Fails_With_Problem_Format() {
+	expectedResponseBody := `{"type":"/problems/bad-request","title":"Bad Request","status":400,` +
+		`"detail":"The 'AccountId' field is mandatory.","code":"ERR_CC_BAD_REQUEST","errors":[` +
+		`{"field":"account_id","rule":"required","message":"The 'AccountId' field is mandatory."},` +
+		`{"field":"operation_type_id","rule":"required","message":"The 'OperationTypeId' field is mandatory."},` +
+		`{"field":"amount","rule":"gt=0","message":"The 'Amount' field value must be greater than 0."}]}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", bytes.NewReader([]byte(`{"amount":-1}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, application/problem+json;q=0.9")
+	suite.context.Request = req
+
+	suite.transactionController.CreateTransaction(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal("application/problem+json", suite.recorder.Header().Get("Content-Type"))
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_Return_AccountBlockedError_With_Problem_Format() {
+	payload := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          10,
+	}
+	expectedResponseBody := `{"type":"/problems/account-blocked","title":"Unprocessable Entity","status":422,` +
+		`"detail":"account is blocked and does not accept transactions.","code":"ERR_CC_ACCOUNT_BLOCKED"}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/problem+json")
+	suite.context.Request = req
+
+	suite.mockTransactionService.EXPECT().CreateTransaction(suite.context, payload).Return(nil, domain.ErrAccountBlocked).Times(1)
+	suite.transactionController.CreateTransaction(suite.context)
+
+	suite.Equal(http.StatusUnprocessableEntity, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Validation_Fails_In_Portuguese() {
+	expectedResponseBody := `{"type":"/problems/bad-request","title":"Bad Request","status":400,` +
+		`"detail":"O valor do campo 'Amount' deve ser maior que 0.","code":"ERR_CC_BAD_REQUEST","errors":[` +
+		`{"field":"amount","rule":"gt=0","message":"O valor do campo 'Amount' deve ser maior que 0."}]}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", bytes.NewReader([]byte(`{"account_id":1,"operation_type_id":1,"amount":-1}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/problem+json")
+	req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+	suite.context.Request = req
+
+	suite.transactionController.CreateTransaction(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal("pt-BR", suite.recorder.Header().Get("Content-Language"))
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_Return_AccountBlockedError_In_Portuguese() {
+	payload := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          10,
+	}
+	expectedResponseBody := `{"error_code":"ERR_CC_ACCOUNT_BLOCKED","error_message":"a conta está bloqueada e não aceita transações.","status_code":422}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "pt-BR")
+	suite.context.Request = req
+
+	suite.mockTransactionService.EXPECT().CreateTransaction(suite.context, payload).Return(nil, domain.ErrAccountBlocked).Times(1)
+	suite.transactionController.CreateTransaction(suite.context)
+
+	suite.Equal(http.StatusUnprocessableEntity, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
 func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_Return_AccountNotFoundError() {
 	payload := models.TransactionRequest{
 		AccountId:       testAccountId,
@@ -191,6 +292,26 @@ func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_
 	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
 }
 
+func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_Return_AccountBlockedError() {
+	payload := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          10,
+	}
+	expectedResponseBody := `{"error_code":"ERR_CC_ACCOUNT_BLOCKED","error_message":"account is blocked and does not accept transactions.","status_code":422}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+
+	suite.mockTransactionService.EXPECT().CreateTransaction(suite.context, payload).Return(nil, domain.ErrAccountBlocked).Times(1)
+	suite.transactionController.CreateTransaction(suite.context)
+
+	suite.Equal(http.StatusUnprocessableEntity, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
 func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_Return_UnknownError() {
 	payload := models.TransactionRequest{
 		AccountId:       testAccountId,
@@ -213,6 +334,92 @@ func (suite *TransactionControllerTestSuite) TestCreateTransaction_When_Service_
 	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
 }
 
+func (suite *TransactionControllerTestSuite) newBatchRequest(body string) {
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/transactions:batch", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransactionBatch_Best_Effort_Reports_Every_Item() {
+	ownedAccountId := testAccountId
+	suite.newBatchRequest(`{"items":[` +
+		`{"account_id":1,"operation_type_id":1,"amount":10},` +
+		`{"account_id":1,"operation_type_id":1},` +
+		`{"account_id":2,"operation_type_id":1,"amount":5},` +
+		`{"account_id":1,"operation_type_id":1,"amount":7}]}`)
+	suite.context.Request = suite.context.Request.WithContext(auth.WithPrincipal(suite.context.Request.Context(),
+		&domain.Principal{Subject: "user:alice", AccountId: &ownedAccountId, Scopes: []string{domain.ScopeTransactionsWrite}}))
+	accepted := []models.TransactionRequest{
+		{AccountId: 1, OperationTypeId: 1, Amount: 10},
+		{AccountId: 1, OperationTypeId: 1, Amount: 7},
+	}
+	suite.mockTransactionService.EXPECT().CreateTransactions(suite.context, accepted, false).Return([]domain.BatchItemOutcome{
+		{Status: domain.BatchItemCreated, Transaction: &domain.Transaction{Id: 11}},
+		{Status: domain.BatchItemFailed, Err: domain.ErrAccountBlocked},
+	})
+	expectedResponseBody := `{"mode":"best_effort","created":1,"failed":3,"results":[` +
+		`{"index":0,"status":"created","transaction_id":11},` +
+		`{"index":1,"status":"failed","error_code":"ERR_CC_BAD_REQUEST","error_message":"The 'Amount' field is mandatory.","errors":[{"field":"amount","rule":"required","message":"The 'Amount' field is mandatory."}]},` +
+		`{"index":2,"status":"failed","error_code":"ERR_CC_FORBIDDEN","error_message":"credentials are not allowed to perform this operation."},` +
+		`{"index":3,"status":"failed","error_code":"ERR_CC_ACCOUNT_BLOCKED","error_message":"account is blocked and does not accept transactions."}]}`
+
+	suite.transactionController.CreateTransactionBatch(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransactionBatch_All_Or_Nothing_Skips_Every_Item_When_One_Is_Invalid() {
+	suite.newBatchRequest(`{"mode":"all_or_nothing","items":[{"account_id":1,"operation_type_id":1,"amount":10},{"account_id":1,"operation_type_id":1,"amount":-1}]}`)
+	suite.context.Request.Header.Set("Accept-Language", "pt-BR")
+	expectedResponseBody := `{"mode":"all_or_nothing","created":0,"failed":1,"results":[` +
+		`{"index":0,"status":"skipped"},` +
+		`{"index":1,"status":"failed","error_code":"ERR_CC_BAD_REQUEST","error_message":"O valor do campo 'Amount' deve ser maior que 0.","errors":[{"field":"amount","rule":"gt=0","message":"O valor do campo 'Amount' deve ser maior que 0."}]}]}`
+
+	suite.transactionController.CreateTransactionBatch(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("pt-BR", suite.recorder.Header().Get("Content-Language"))
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransactionBatch_All_Or_Nothing_Passes_Mode_To_Service() {
+	suite.newBatchRequest(`{"mode":"all_or_nothing","items":[{"account_id":1,"operation_type_id":1,"amount":10},{"account_id":2,"operation_type_id":9,"amount":5}]}`)
+	suite.mockTransactionService.EXPECT().CreateTransactions(suite.context, gomock.Len(2), true).Return([]domain.BatchItemOutcome{
+		{Status: domain.BatchItemRolledBack},
+		{Status: domain.BatchItemFailed, Err: domain.ErrInvalidOperationType},
+	})
+
+	suite.transactionController.CreateTransactionBatch(suite.context)
+
+	var response models.BatchTransactionResponse
+	suite.Require().NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal(0, response.Created)
+	suite.Equal(1, response.Failed)
+	suite.Equal(domain.BatchItemRolledBack, response.Results[0].Status)
+	suite.Equal("ERR_CC_INVALID_OPERATION_TYPE", response.Results[1].ErrorCode)
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransactionBatch_When_Too_Many_Items() {
+	suite.transactionController = NewTransactionController(suite.mockTransactionService, BatchConfig{MaxItems: 1})
+	suite.newBatchRequest(`{"items":[{"account_id":1,"operation_type_id":1,"amount":10},{"account_id":1,"operation_type_id":1,"amount":5}]}`)
+	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"The 'Items' field cannot have more than 1 items.","status_code":400}`
+
+	suite.transactionController.CreateTransactionBatch(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionControllerTestSuite) TestCreateTransactionBatch_When_Mode_Is_Unknown() {
+	suite.newBatchRequest(`{"mode":"sometimes","items":[{"account_id":1,"operation_type_id":1,"amount":10}]}`)
+
+	suite.transactionController.CreateTransactionBatch(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
 func (suite *TransactionControllerTestSuite) TearDownTest() {
 	suite.mockController.Finish()
 }
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/export"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Values of the X-Export-Status trailer.
+const (
+	exportStatusComplete = "complete"
+	exportStatusFailed   = "failed"
+)
+
+type ExportConfig struct {
+	// Currency is the ISO 4217 currency of the amounts, which OFX exports declare.
+	Currency string `yaml:"currency" validate:"iso4217"`
+}
+
+func DefaultExportConfig() ExportConfig {
+	return ExportConfig{Currency: "BRL"}
+}
+
+type TransactionExportController struct {
+	exportService services.TransactionExportService
+	config        ExportConfig
+}
+
+func NewTransactionExportController(exportService services.TransactionExportService, config ExportConfig) *TransactionExportController {
+	return &TransactionExportController{exportService: exportService, config: config}
+}
+
+// ExportTransactions godoc
+// @Summary      Export account transactions
+// @Description  Download the transactions of an account, oldest first, as CSV, OFX 2.1.1 or JSON Lines. Rows are streamed from the database as they are written.
+// @Description  The response ends with an X-Export-Status trailer: complete, or failed when the export stopped part way and the body is truncated.
+// @Tags         Transactions
+// @Produce      text/csv
+// @Produce      application/x-ofx
+// @Produce      application/x-ndjson
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param accountId path string true "accountId"
+// @Param format query string false "csv (default), ofx or jsonl"
+// @Param from query string false "inclusive lower bound, RFC 3339"
+// @Param to query string false "exclusive upper bound, RFC 3339"
+// @Success      200
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/accounts/{accountId}/transactions/export [get]
+func (ec *TransactionExportController) ExportTransactions(ctx *gin.Context) {
+	accountId, err := strconv.ParseInt(ctx.Param(constants.AccountIdPathParam), 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.AccountIdMissingErrMsg))
+		return
+	}
+	if !auth.CanAccessAccount(ctx.Request.Context(), accountId) {
+		ec.respondWithError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	var query models.ExportTransactionsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logging.FromContext(ctx).Error("failed to binding query params error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidQueryParamErrMsg))
+		return
+	}
+	if validationErr := query.Validate(); validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on query params error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+	format := query.Format
+	if format == constants.EmptyString {
+		format = export.FormatCSV
+	}
+
+	account, openErr := ec.exportService.OpenExport(ctx, accountId)
+	if openErr != nil {
+		ec.respondWithError(ctx, openErr)
+		return
+	}
+
+	// An open bound is the life of the account so far, which the OFX statement period needs.
+	now := time.Now().UTC()
+	statement := export.Statement{AccountId: accountId, Currency: ec.config.Currency, From: account.CreatedAt, To: now, GeneratedAt: now}
+	if query.From != nil {
+		statement.From = *query.From
+	}
+	if query.To != nil {
+		statement.To = *query.To
+	}
+	encoder, err := export.NewEncoder(format, ctx.Writer, statement)
+	if err != nil {
+		ec.respondWithError(ctx, err)
+		return
+	}
+
+	// A long history outlives the server write timeout.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	ctx.Header(constants.ContentTypeHeader, export.ContentType(format))
+	ctx.Header(constants.ContentDispositionHeader, fmt.Sprintf(`attachment; filename="%s"`, export.FileName(format, statement)))
+	ctx.Header(constants.TrailerHeader, constants.ExportStatusTrailer)
+	ctx.Status(http.StatusOK)
+
+	filter := domain.TransactionExportFilter{AccountId: accountId, From: query.From, To: query.To}
+	exportErr := encoder.Begin()
+	if exportErr == nil {
+		exportErr = ec.exportService.ExportTransactions(ctx, filter, encoder.Encode)
+	}
+	if exportErr == nil {
+		exportErr = encoder.End()
+	}
+	// The status is already sent, so a failure can only be told by the trailer.
+	status := exportStatusComplete
+	if exportErr != nil {
+		logging.FromContext(ctx).Errorf("transaction export of accountId: %d stopped, error: %s", accountId, exportErr.Error())
+		status = exportStatusFailed
+	}
+	ctx.Writer.Header().Set(constants.ExportStatusTrailer, status)
+}
+
+func (ec *TransactionExportController) respondWithError(ctx *gin.Context, err error) {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		appErr = domain.ErrInternal
+	}
+
+	status := http.StatusInternalServerError
+	switch appErr.Code {
+	case constants.AccountNotFoundErrCode:
+		status = http.StatusNotFound
+	case constants.ForbiddenErrCode:
+		status = http.StatusForbidden
+	}
+
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
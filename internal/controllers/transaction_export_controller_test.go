@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TransactionExportControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockExportService *mocks.MockTransactionExportService
+	controller        *TransactionExportController
+}
+
+func TestTransactionExportControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionExportControllerTestSuite))
+}
+
+func (suite *TransactionExportControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Params = gin.Params{gin.Param{Key: "accountId", Value: "1"}}
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockExportService = mocks.NewMockTransactionExportService(suite.mockController)
+	suite.controller = NewTransactionExportController(suite.mockExportService, DefaultExportConfig())
+}
+
+func (suite *TransactionExportControllerTestSuite) TestExportTransactions_CSV() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/export?from=2026-09-01T00:00:00Z", nil)
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockExportService.EXPECT().OpenExport(suite.context, int64(1)).Return(&domain.Account{Id: 1}, nil)
+	suite.mockExportService.EXPECT().ExportTransactions(suite.context, domain.TransactionExportFilter{AccountId: 1, From: &from}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+			return fn(domain.ExportedTransaction{
+				Transaction:          domain.Transaction{Id: 3, AccountId: 1, OperationTypeId: 1, Amount: -50, Balance: -50, CreatedAt: from.Add(time.Hour)},
+				OperationDescription: "Normal Purchase",
+			})
+		})
+
+	suite.controller.ExportTransactions(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("text/csv; charset=utf-8", suite.recorder.Header().Get("Content-Type"))
+	suite.Equal(`attachment; filename="account-1-transactions.csv"`, suite.recorder.Header().Get("Content-Disposition"))
+	suite.Equal("transaction_id,created_at,operation_type_id,operation,amount,balance\n3,2026-09-01T01:00:00Z,1,Normal Purchase,-50.00,-50.00\n", suite.recorder.Body.String())
+	suite.Equal("complete", suite.recorder.Header().Get("X-Export-Status"))
+}
+
+func (suite *TransactionExportControllerTestSuite) TestExportTransactions_Failure_After_Start_Is_Told_By_Trailer() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/export?format=jsonl", nil)
+	suite.mockExportService.EXPECT().OpenExport(suite.context, int64(1)).Return(&domain.Account{Id: 1}, nil)
+	suite.mockExportService.EXPECT().ExportTransactions(suite.context, domain.TransactionExportFilter{AccountId: 1}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+			if err := fn(domain.ExportedTransaction{Transaction: domain.Transaction{Id: 3, AccountId: 1}}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+	suite.controller.ExportTransactions(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("application/x-ndjson", suite.recorder.Header().Get("Content-Type"))
+	suite.Contains(suite.recorder.Body.String(), `"transaction_id":3`)
+	suite.Equal("failed", suite.recorder.Header().Get("X-Export-Status"))
+}
+
+func (suite *TransactionExportControllerTestSuite) TestExportTransactions_Unknown_Account() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/export?format=ofx", nil)
+	suite.mockExportService.EXPECT().OpenExport(suite.context, int64(1)).Return(nil, domain.ErrAccountNotFound)
+
+	suite.controller.ExportTransactions(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	suite.JSONEq(`{"error_code":"ERR_CC_ACCOUNT_NOT_FOUND","error_message":"account does not exists with provided id.","status_code":404}`, suite.recorder.Body.String())
+}
+
+func (suite *TransactionExportControllerTestSuite) TestExportTransactions_Invalid_Format() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/export?format=xlsx", nil)
+
+	suite.controller.ExportTransactions(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), "The 'Format' field must be one of [csv ofx jsonl].")
+}
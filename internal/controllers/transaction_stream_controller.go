@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+type StreamConfig struct {
+	PollInterval      time.Duration `yaml:"poll_interval" validate:"gt=0"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" validate:"gt=0"`
+}
+
+func DefaultStreamConfig() StreamConfig {
+	return StreamConfig{
+		PollInterval:      time.Second,
+		HeartbeatInterval: 15 * time.Second,
+	}
+}
+
+type TransactionStreamController struct {
+	streamService services.TransactionStreamService
+	config        StreamConfig
+	// shutdown is closed when a shutdown signal arrives, open streams end so the drain does not wait for them.
+	shutdown <-chan struct{}
+}
+
+func NewTransactionStreamController(streamService services.TransactionStreamService, config StreamConfig, shutdown <-chan struct{}) *TransactionStreamController {
+	return &TransactionStreamController{streamService: streamService, config: config, shutdown: shutdown}
+}
+
+// StreamTransactions godoc
+// @Summary      Stream account transactions
+// @Description  Server-Sent Events stream of transaction.created and transaction.balance_updated events of an account.
+// @Description  Each event id is the outbox event id; reconnect with the Last-Event-ID header (or last_event_id query param) to resume without gaps.
+// @Tags         Transactions
+// @Produce      text/event-stream
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param accountId path string true "accountId"
+// @Param Last-Event-ID header string false "resume after this event id"
+// @Param last_event_id query string false "resume after this event id, for clients that cannot set headers"
+// @Success      200
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/accounts/{accountId}/transactions/stream [get]
+func (sc *TransactionStreamController) StreamTransactions(ctx *gin.Context) {
+	accountId, err := strconv.ParseInt(ctx.Param(constants.AccountIdPathParam), 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.AccountIdMissingErrMsg))
+		return
+	}
+	if !auth.CanAccessAccount(ctx.Request.Context(), accountId) {
+		sc.respondWithError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	lastEventId, resumed, err := lastEventIdFromRequest(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read last event id error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidLastEventIdErrMsg))
+		return
+	}
+
+	latestEventId, streamErr := sc.streamService.OpenStream(ctx, accountId)
+	if streamErr != nil {
+		sc.respondWithError(ctx, streamErr)
+		return
+	}
+	if !resumed {
+		lastEventId = latestEventId
+	}
+
+	// The stream outlives the server write timeout by design, heartbeats detect dead connections instead.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	ctx.Header("Content-Type", "text/event-stream;charset=utf-8")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	pollTicker := time.NewTicker(sc.config.PollInterval)
+	defer pollTicker.Stop()
+	heartbeatTicker := time.NewTicker(sc.config.HeartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	requestCtx := ctx.Request.Context()
+	for {
+		select {
+		case <-requestCtx.Done():
+			logging.FromContext(ctx).Infof("transaction stream of accountId: %d closed at event id: %d", accountId, lastEventId)
+			return
+		case <-sc.shutdown:
+			// Clients reconnect with Last-Event-ID and resume on another instance.
+			logging.FromContext(ctx).Infof("transaction stream of accountId: %d ended by shutdown at event id: %d", accountId, lastEventId)
+			return
+		case <-heartbeatTicker.C:
+			if _, writeErr := ctx.Writer.WriteString(":heartbeat\n\n"); writeErr != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		case <-pollTicker.C:
+			events, eventsErr := sc.streamService.EventsAfter(requestCtx, accountId, lastEventId)
+			if eventsErr != nil {
+				// The client keeps its position, the next poll retries from the same event id.
+				logging.FromContext(ctx).Errorf("error while poll transaction stream of accountId: %d, error: %s", accountId, eventsErr.Error())
+				continue
+			}
+			for _, event := range events {
+				ctx.Render(-1, sse.Event{
+					Id:    strconv.FormatInt(event.Id, 10),
+					Event: event.EventType,
+					Data:  json.RawMessage(event.Payload),
+				})
+				lastEventId = event.Id
+			}
+			if len(events) > 0 {
+				ctx.Writer.Flush()
+			}
+		}
+	}
+}
+
+func lastEventIdFromRequest(ctx *gin.Context) (int64, bool, error) {
+	value := ctx.GetHeader(constants.LastEventIdHeader)
+	if value == constants.EmptyString {
+		value = ctx.Query(constants.LastEventIdQueryParam)
+	}
+	if value == constants.EmptyString {
+		return 0, false, nil
+	}
+
+	lastEventId, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || lastEventId < 0 {
+		return 0, false, errors.New(constants.InvalidLastEventIdErrMsg)
+	}
+	return lastEventId, true, nil
+}
+
+func (sc *TransactionStreamController) respondWithError(ctx *gin.Context, err error) {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		appErr = domain.ErrInternal
+	}
+
+	status := http.StatusInternalServerError
+	switch appErr.Code {
+	case constants.AccountNotFoundErrCode:
+		status = http.StatusNotFound
+	case constants.ForbiddenErrCode:
+		status = http.StatusForbidden
+	}
+
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TransactionStreamControllerTestSuite struct {
+	suite.Suite
+	context           *gin.Context
+	recorder          *httptest.ResponseRecorder
+	mockController    *gomock.Controller
+	mockStreamService *mocks.MockTransactionStreamService
+	controller        *TransactionStreamController
+	shutdown          chan struct{}
+}
+
+func TestTransactionStreamControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionStreamControllerTestSuite))
+}
+
+func (suite *TransactionStreamControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.context.Params = gin.Params{gin.Param{Key: "accountId", Value: "1"}}
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockStreamService = mocks.NewMockTransactionStreamService(suite.mockController)
+	suite.shutdown = make(chan struct{})
+	suite.controller = NewTransactionStreamController(suite.mockStreamService, StreamConfig{
+		PollInterval:      time.Millisecond,
+		HeartbeatInterval: time.Hour,
+	}, suite.shutdown)
+}
+
+func (suite *TransactionStreamControllerTestSuite) TestStreamTransactions_Resumes_From_LastEventId() {
+	requestCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/stream", nil).WithContext(requestCtx)
+	req.Header.Set("Last-Event-ID", "5")
+	suite.context.Request = req
+
+	events := []domain.OutboxEvent{
+		{Id: 6, EventType: domain.EventTransactionCreated, Payload: []byte(`{"transaction_id":3,"balance":-50}`)},
+		{Id: 8, EventType: domain.EventTransactionBalanceUpdated, Payload: []byte(`{"transaction_id":3,"balance":0}`)},
+	}
+	suite.mockStreamService.EXPECT().OpenStream(suite.context, int64(1)).Return(int64(20), nil)
+	suite.mockStreamService.EXPECT().EventsAfter(requestCtx, int64(1), int64(5)).Return(events, nil)
+	suite.mockStreamService.EXPECT().EventsAfter(requestCtx, int64(1), int64(8)).
+		DoAndReturn(func(context.Context, int64, int64) ([]domain.OutboxEvent, error) {
+			cancel()
+			return nil, nil
+		})
+
+	suite.controller.StreamTransactions(suite.context)
+
+	expectedBody := "id:6\nevent:transaction.created\ndata:{\"transaction_id\":3,\"balance\":-50}\n\n" +
+		"id:8\nevent:transaction.balance_updated\ndata:{\"transaction_id\":3,\"balance\":0}\n\n"
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("text/event-stream;charset=utf-8", suite.recorder.Result().Header.Get("Content-Type"))
+	suite.Equal(expectedBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionStreamControllerTestSuite) TestStreamTransactions_Starts_After_Latest_Event_Without_LastEventId() {
+	requestCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/stream", nil).WithContext(requestCtx)
+
+	suite.mockStreamService.EXPECT().OpenStream(suite.context, int64(1)).Return(int64(20), nil)
+	suite.mockStreamService.EXPECT().EventsAfter(requestCtx, int64(1), int64(20)).
+		DoAndReturn(func(context.Context, int64, int64) ([]domain.OutboxEvent, error) {
+			cancel()
+			return nil, nil
+		})
+
+	suite.controller.StreamTransactions(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Empty(suite.recorder.Body.String())
+}
+
+func (suite *TransactionStreamControllerTestSuite) TestStreamTransactions_Sends_Heartbeat() {
+	suite.controller.config = StreamConfig{PollInterval: time.Hour, HeartbeatInterval: time.Millisecond}
+	requestCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/stream", nil).WithContext(requestCtx)
+
+	suite.mockStreamService.EXPECT().OpenStream(suite.context, int64(1)).Return(int64(0), nil)
+
+	suite.controller.StreamTransactions(suite.context)
+
+	suite.Contains(suite.recorder.Body.String(), ":heartbeat\n\n")
+}
+
+func (suite *TransactionStreamControllerTestSuite) TestStreamTransactions_Ends_On_Shutdown() {
+	suite.controller.config = StreamConfig{PollInterval: time.Hour, HeartbeatInterval: time.Hour}
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/stream", nil)
+
+	suite.mockStreamService.EXPECT().OpenStream(suite.context, int64(1)).Return(int64(0), nil)
+	close(suite.shutdown)
+
+	suite.controller.StreamTransactions(suite.context)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Empty(suite.recorder.Body.String())
+}
+
+func (suite *TransactionStreamControllerTestSuite) TestStreamTransactions_When_LastEventId_IsInvalid() {
+	req := httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/stream?last_event_id=abc", nil)
+	suite.context.Request = req
+	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"Last-Event-ID must be a numeric event id","status_code":400}`
+
+	suite.controller.StreamTransactions(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionStreamControllerTestSuite) TestStreamTransactions_When_Account_NotFound() {
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/accounts/1/transactions/stream", nil)
+	expectedResponseBody := `{"error_code":"ERR_CC_ACCOUNT_NOT_FOUND","error_message":"account does not exists with provided id.","status_code":404}`
+
+	suite.mockStreamService.EXPECT().OpenStream(suite.context, int64(1)).Return(int64(0), domain.ErrAccountNotFound)
+
+	suite.controller.StreamTransactions(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *TransactionStreamControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookController(webhookService services.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+// CreateWebhook godoc
+// @Summary      Create a webhook subscription
+// @Description  Register an endpoint that receives signed event notifications. The signing secret is only returned here.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param CreateWebhookRequest body models.CreateWebhookRequest true "Request Body"
+// @Success      201  {object}  models.CreateWebhookResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/webhooks [post]
+func (wc *WebhookController) CreateWebhook(ctx *gin.Context) {
+	var payload models.CreateWebhookRequest
+	err := ctx.ShouldBindJSON(&payload)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		return
+	}
+
+	validationErr := payload.Validate()
+	if validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on request payload error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+
+	subscription, subscriptionErr := wc.webhookService.CreateSubscription(ctx, payload)
+	if subscriptionErr != nil {
+		wc.respondWithError(ctx, subscriptionErr)
+		return
+	}
+	ctx.JSON(http.StatusCreated, models.CreateWebhookResponse{
+		WebhookResponse: mapToWebhookResponse(*subscription),
+		Secret:          subscription.Secret,
+	})
+}
+
+// ListWebhooks godoc
+// @Summary      List webhook subscriptions
+// @Description  List all webhook subscriptions
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {array}   models.WebhookResponse
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/webhooks [get]
+func (wc *WebhookController) ListWebhooks(ctx *gin.Context) {
+	subscriptions, err := wc.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		wc.respondWithError(ctx, err)
+		return
+	}
+
+	response := make([]models.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, mapToWebhookResponse(subscription))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetWebhook godoc
+// @Summary      Get a webhook subscription
+// @Description  Get a webhook subscription by webhookId
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+// @Success      200  {object}  models.WebhookResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/webhooks/{webhookId} [get]
+func (wc *WebhookController) GetWebhook(ctx *gin.Context) {
+	id, ok := wc.webhookIdFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	subscription, err := wc.webhookService.GetSubscription(ctx, id)
+	if err != nil {
+		wc.respondWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, mapToWebhookResponse(*subscription))
+}
+
+// UpdateWebhook godoc
+// @Summary      Update a webhook subscription
+// @Description  Change the url, event types or active flag of a webhook subscription
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+// @Param UpdateWebhookRequest body models.UpdateWebhookRequest true "Request Body"
+// @Success      200  {object}  models.WebhookResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/webhooks/{webhookId} [patch]
+func (wc *WebhookController) UpdateWebhook(ctx *gin.Context) {
+	id, ok := wc.webhookIdFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.UpdateWebhookRequest
+	err := ctx.ShouldBindJSON(&payload)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to binding a request payload error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidRequestBodyErrMsg))
+		return
+	}
+
+	validationErr := payload.Validate()
+	if validationErr != nil {
+		logging.FromContext(ctx).Error("validation failure on request payload error: ", validationErr)
+		utils.AbortWithError(ctx, utils.NewCCValidationError(validationErr))
+		return
+	}
+
+	subscription, subscriptionErr := wc.webhookService.UpdateSubscription(ctx, id, payload)
+	if subscriptionErr != nil {
+		wc.respondWithError(ctx, subscriptionErr)
+		return
+	}
+	ctx.JSON(http.StatusOK, mapToWebhookResponse(*subscription))
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook subscription
+// @Description  Delete a webhook subscription and its pending deliveries
+// @Tags         Webhooks
+// @Security     ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+// @Success      204
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/webhooks/{webhookId} [delete]
+func (wc *WebhookController) DeleteWebhook(ctx *gin.Context) {
+	id, ok := wc.webhookIdFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	err := wc.webhookService.DeleteSubscription(ctx, id)
+	if err != nil {
+		wc.respondWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  List the latest deliveries of a webhook subscription, optionally filtered by status (pending, delivered, dead)
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+// @Param status query string false "delivery status" Enums(pending, delivered, dead)
+// @Success      200  {array}   models.WebhookDeliveryResponse
+// @Failure      400  {object}  models.BadRequestError
+// @Failure      401  {object}  models.UnauthorizedError
+// @Failure      403  {object}  models.ForbiddenError
+// @Failure      404  {object}  models.NotFoundError
+// @Failure      429  {object}  models.TooManyRequestsError
+// @Failure      500  {object}  models.InternalServerError
+// @Router       /api/credit-card-api/v1/webhooks/{webhookId}/deliveries [get]
+func (wc *WebhookController) ListWebhookDeliveries(ctx *gin.Context) {
+	id, ok := wc.webhookIdFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	status := ctx.Query("status")
+	switch status {
+	case constants.EmptyString, domain.DeliveryStatusPending, domain.DeliveryStatusDelivered, domain.DeliveryStatusDead:
+	default:
+		logging.FromContext(ctx).Errorf("unsupported delivery status filter: %s", status)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.InvalidQueryParamErrMsg))
+		return
+	}
+
+	deliveries, err := wc.webhookService.ListDeliveries(ctx, id, status)
+	if err != nil {
+		wc.respondWithError(ctx, err)
+		return
+	}
+
+	response := make([]models.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, mapToWebhookDeliveryResponse(delivery))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (wc *WebhookController) webhookIdFromPath(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param(constants.WebhookIdPathParam), 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to read path param error: ", err)
+		utils.AbortWithError(ctx, utils.NewCCBadRequestError(constants.WebhookIdMissingErrMsg))
+		return 0, false
+	}
+	return id, true
+}
+
+func (wc *WebhookController) respondWithError(ctx *gin.Context, err error) {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		appErr = domain.ErrInternal
+	}
+
+	status := http.StatusInternalServerError
+	switch appErr.Code {
+	case constants.WebhookNotFoundErrCode:
+		status = http.StatusNotFound
+	}
+
+	utils.AbortWithError(ctx, &models.CCError{
+		ErrorCode:    appErr.Code,
+		ErrorMessage: appErr.Message,
+		StatusCode:   status,
+	})
+}
+
+func mapToWebhookResponse(subscription domain.WebhookSubscription) models.WebhookResponse {
+	return models.WebhookResponse{
+		WebhookId:  subscription.Id,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func mapToWebhookDeliveryResponse(delivery domain.WebhookDelivery) models.WebhookDeliveryResponse {
+	return models.WebhookDeliveryResponse{
+		DeliveryId:     delivery.Id,
+		EventId:        delivery.EventId,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
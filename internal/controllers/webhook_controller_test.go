@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookControllerTestSuite struct {
+	suite.Suite
+	context            *gin.Context
+	recorder           *httptest.ResponseRecorder
+	mockController     *gomock.Controller
+	mockWebhookService *mocks.MockWebhookService
+	controller         *WebhookController
+}
+
+func TestWebhookControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookControllerTestSuite))
+}
+
+func (suite *WebhookControllerTestSuite) SetupTest() {
+	suite.recorder = httptest.NewRecorder()
+	suite.context, _ = gin.CreateTestContext(suite.recorder)
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockWebhookService = mocks.NewMockWebhookService(suite.mockController)
+	suite.controller = NewWebhookController(suite.mockWebhookService)
+}
+
+func (suite *WebhookControllerTestSuite) TestCreateWebhook_Success_Returns_Secret() {
+	payload := models.CreateWebhookRequest{
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{"transaction.created"},
+	}
+	createdAt := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	subscription := &domain.WebhookSubscription{
+		Id:         1,
+		Url:        payload.Url,
+		Secret:     "whsec_abc",
+		EventTypes: payload.EventTypes,
+		Active:     true,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+	expectedResponseBody := `{"webhook_id":1,"url":"https://example.com/hooks","event_types":["transaction.created"],"active":true,"created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","secret":"whsec_abc"}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/webhooks", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+	suite.mockWebhookService.EXPECT().CreateSubscription(suite.context, payload).Return(subscription, nil)
+
+	suite.controller.CreateWebhook(suite.context)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestCreateWebhook_When_EventType_IsUnknown() {
+	payload := models.CreateWebhookRequest{
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{"account.deleted"},
+	}
+	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"The 'EventTypes[0]' field must be one of [account.created account.blocked transaction.created transaction.balance_updated].","status_code":400}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/webhooks", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+
+	suite.controller.CreateWebhook(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestCreateWebhook_When_Url_IsInvalid() {
+	payload := models.CreateWebhookRequest{
+		Url:        "not a url",
+		EventTypes: []string{"transaction.created"},
+	}
+	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"The 'Url' field must be a valid URL.","status_code":400}`
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1/webhooks", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	suite.context.Request = req
+
+	suite.controller.CreateWebhook(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestGetWebhook_When_NotFound() {
+	expectedResponseBody := `{"error_code":"ERR_CC_WEBHOOK_NOT_FOUND","error_message":"webhook subscription does not exist with provided id.","status_code":404}`
+
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/webhooks/9", nil)
+	suite.context.Params = gin.Params{gin.Param{Key: "webhookId", Value: "9"}}
+	suite.mockWebhookService.EXPECT().GetSubscription(suite.context, int64(9)).Return(nil, domain.ErrWebhookNotFound)
+
+	suite.controller.GetWebhook(suite.context)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TestDeleteWebhook_Success() {
+	suite.context.Request = httptest.NewRequest(http.MethodDelete, "/api/credit-card-api/v1/webhooks/1", nil)
+	suite.context.Params = gin.Params{gin.Param{Key: "webhookId", Value: "1"}}
+	suite.mockWebhookService.EXPECT().DeleteSubscription(suite.context, int64(1)).Return(nil)
+
+	suite.controller.DeleteWebhook(suite.context)
+	suite.context.Writer.WriteHeaderNow()
+
+	suite.Equal(http.StatusNoContent, suite.recorder.Code)
+}
+
+func (suite *WebhookControllerTestSuite) TestListWebhookDeliveries_When_Status_IsUnsupported() {
+	expectedResponseBody := `{"error_code":"ERR_CC_BAD_REQUEST","error_message":"invalid query params","status_code":400}`
+
+	suite.context.Request = httptest.NewRequest(http.MethodGet, "/api/credit-card-api/v1/webhooks/1/deliveries?status=lost", nil)
+	suite.context.Params = gin.Params{gin.Param{Key: "webhookId", Value: "1"}}
+
+	suite.controller.ListWebhookDeliveries(suite.context)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Equal(expectedResponseBody, suite.recorder.Body.String())
+}
+
+func (suite *WebhookControllerTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
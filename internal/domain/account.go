@@ -6,8 +6,40 @@ type Account struct {
 	Id             int64
 	DocumentNumber string
 	CreatedAt      time.Time
+	// BlockedAt is set once the account is blocked, a blocked account accepts no new transactions.
+	BlockedAt *time.Time
+	Profile   AccountProfile
+	// Version grows with every change of the account, it is the ETag that guards profile updates.
+	Version   int32
+	UpdatedAt time.Time
+}
+
+// AccountProfile is the personal data of the account holder, every field is optional.
+type AccountProfile struct {
+	FullName  string
+	Email     string
+	Phone     string
+	Address   *PostalAddress
+	BirthDate *time.Time
+}
+
+type PostalAddress struct {
+	Line1      string
+	Line2      string
+	City       string
+	State      string
+	PostalCode string
+	// Country is an ISO 3166-1 alpha-2 code.
+	Country string
 }
 
 type CreateAccountParam struct {
 	DocumentNumber string
 }
+
+// UpdateAccountProfileParam replaces the profile of the account, provided it is still at Version.
+type UpdateAccountProfileParam struct {
+	Id      int64
+	Version int32
+	Profile AccountProfile
+}
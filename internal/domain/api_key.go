@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	ScopeAccountsRead      = "accounts:read"
+	ScopeAccountsWrite     = "accounts:write"
+	ScopeTransactionsWrite = "transactions:write"
+	// ScopePiiRead returns sensitive response fields, such as document numbers, unmasked.
+	ScopePiiRead = "pii:read"
+	// ScopeAdmin grants every other scope, plus webhook, audit and API key management.
+	ScopeAdmin = "admin"
+)
+
+var Scopes = []string{ScopeAccountsRead, ScopeAccountsWrite, ScopeTransactionsWrite, ScopePiiRead, ScopeAdmin}
+
+type ApiKey struct {
+	Id          int64
+	Name        string
+	Prefix      string
+	Scopes      []string
+	RotatedFrom *int64
+	CreatedAt   time.Time
+	RevokedAt   *time.Time
+}
+
+type CreateApiKeyParam struct {
+	Name        string
+	Prefix      string
+	Hash        string
+	Scopes      []string
+	RotatedFrom *int64
+}
+
+// IssuedApiKey carries the plain key, which is never stored and only returned once.
+type IssuedApiKey struct {
+	ApiKey
+	Key string
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller in audit entries, e.g. "api-key:3" or "user:<sub>".
+	Subject string
+	Scopes  []string
+	// AccountId is the only account an end user may act on, nil for service api keys.
+	AccountId *int64
+}
+
+func NewApiKeyPrincipal(apiKey ApiKey) *Principal {
+	return &Principal{Subject: fmt.Sprintf("api-key:%d", apiKey.Id), Scopes: apiKey.Scopes}
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope)
+}
+
+func (p *Principal) CanAccessAccount(accountId int64) bool {
+	return p.AccountId == nil || *p.AccountId == accountId
+}
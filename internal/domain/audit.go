@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditActionAccountCreate            = "account.create"
+	AuditActionAccountBlock             = "account.block"
+	AuditActionAccountUpdate            = "account.update"
+	AuditActionTransactionCreate        = "transaction.create"
+	AuditActionTransactionBalanceUpdate = "transaction.balance_update"
+	AuditActionWebhookCreate            = "webhook.create"
+	AuditActionWebhookUpdate            = "webhook.update"
+	AuditActionWebhookDelete            = "webhook.delete"
+	AuditActionApiKeyIssue              = "api_key.issue"
+	AuditActionApiKeyRotate             = "api_key.rotate"
+	AuditActionApiKeyRevoke             = "api_key.revoke"
+	AuditActionHttpRequest              = "http.request"
+
+	AuditResourceAccount             = "account"
+	AuditResourceTransaction         = "transaction"
+	AuditResourceWebhookSubscription = "webhook_subscription"
+	AuditResourceApiKey              = "api_key"
+	AuditResourceHttpRoute           = "http_route"
+)
+
+// AuditRecord is what a service hook reports; who, from where and when is taken from the context.
+type AuditRecord struct {
+	Action       string
+	ResourceType string
+	ResourceId   string
+	Before       any
+	After        any
+}
+
+type AuditEntry struct {
+	Id           int64
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceId   string
+	Before       json.RawMessage
+	After        json.RawMessage
+	Diff         json.RawMessage
+	RequestId    string
+	SourceIp     string
+	CreatedAt    time.Time
+	PrevHash     string
+	Hash         string
+}
+
+type AuditFilter struct {
+	ResourceType string
+	ResourceId   string
+	Actor        string
+	From         *time.Time
+	To           *time.Time
+	AfterId      int64
+	Limit        int32
+}
+
+type AuditVerification struct {
+	Valid          bool
+	EntriesChecked int64
+	FirstInvalidId int64
+	LastHash       string
+}
@@ -12,6 +12,16 @@ var (
 	ErrAccountNotFound            = &AppError{Code: constants.AccountNotFoundErrCode, Message: "account does not exists with provided id."}
 	ErrInvalidOperationType       = &AppError{Code: constants.InvalidOperationTypeErrCode, Message: "operation type ID provided is not supported by the system."}
 	ErrTransactionAccountNotFound = &AppError{Code: constants.TransactionAccountNotFoundErrCode, Message: "account does not exist with provided id."}
+	ErrAccountBlocked             = &AppError{Code: constants.AccountBlockedErrCode, Message: "account is blocked and does not accept transactions."}
+	ErrWebhookNotFound            = &AppError{Code: constants.WebhookNotFoundErrCode, Message: "webhook subscription does not exist with provided id."}
+	ErrApiKeyNotFound             = &AppError{Code: constants.ApiKeyNotFoundErrCode, Message: "active api key does not exist with provided id."}
+	ErrStatementNotFound          = &AppError{Code: constants.StatementNotFoundErrCode, Message: "statement of a closed cycle does not exist with provided id."}
+	ErrUnauthorized               = &AppError{Code: constants.UnauthorizedErrCode, Message: "valid credentials are required."}
+	ErrForbidden                  = &AppError{Code: constants.ForbiddenErrCode, Message: "credentials are not allowed to perform this operation."}
+	ErrRateLimited                = &AppError{Code: constants.RateLimitedErrCode, Message: "too many requests, retry later."}
+	ErrRequestTooLarge            = &AppError{Code: constants.RequestTooLargeErrCode, Message: "request body is too large."}
+	ErrAccountVersionMismatch     = &AppError{Code: constants.PreconditionFailedErrCode, Message: "account was modified since the version in If-Match, fetch it again."}
+	ErrPreconditionRequired       = &AppError{Code: constants.PreconditionRequiredErrCode, Message: "If-Match header with the account ETag is required."}
 	ErrInternal                   = &AppError{Code: constants.InternalServerErrCode, Message: "an unexpected error occurred."}
 )
 
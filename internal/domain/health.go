@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// ExpectedSchemaVersion is the oldest schema_migrations version this build runs against.
+// Bump it together with every change to db/migrations.
+const ExpectedSchemaVersion int64 = 13
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+const (
+	HealthCheckShutdown       = "shutdown"
+	HealthCheckDatabase       = "database"
+	HealthCheckSchemaVersion  = "schema_version"
+	HealthCheckOperationTypes = "operation_types"
+)
+
+type SchemaVersion struct {
+	Version int64
+	Dirty   bool
+}
+
+type HealthCheck struct {
+	Name    string
+	Status  string
+	Latency time.Duration
+	Error   string
+}
+
+// HealthReport is up only when every check is up.
+type HealthReport struct {
+	Status string
+	Checks []HealthCheck
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// ImportCheckpoint is the progress of a clearing file import. Line is the last line handled, posted or rejected;
+// a resumed import starts after it.
+type ImportCheckpoint struct {
+	FileHash  string
+	FileName  string
+	Line      int64
+	Posted    int64
+	Rejected  int64
+	UpdatedAt time.Time
+}
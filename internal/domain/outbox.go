@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+const (
+	EventAccountCreated            = "account.created"
+	EventAccountBlocked            = "account.blocked"
+	EventTransactionCreated        = "transaction.created"
+	EventTransactionBalanceUpdated = "transaction.balance_updated"
+
+	AggregateAccount     = "account"
+	AggregateTransaction = "transaction"
+)
+
+var EventTypes = []string{
+	EventAccountCreated,
+	EventAccountBlocked,
+	EventTransactionCreated,
+	EventTransactionBalanceUpdated,
+}
+
+// TransactionEventTypes are the events published on an account's transaction stream.
+var TransactionEventTypes = []string{
+	EventTransactionCreated,
+	EventTransactionBalanceUpdated,
+}
+
+type OutboxEvent struct {
+	Id            int64
+	EventType     string
+	AggregateType string
+	AggregateId   int64
+	AccountId     int64
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+type CreateOutboxEventParam struct {
+	EventType     string
+	AggregateType string
+	AggregateId   int64
+	AccountId     int64
+	Payload       any
+}
+
+type AccountCreatedPayload struct {
+	AccountId int64 `json:"account_id"`
+	// DocumentNumber is masked when the event is stored, only its last four digits leave the service.
+	DocumentNumber string    `json:"document_number" redact:"last4"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type AccountBlockedPayload struct {
+	AccountId int64     `json:"account_id"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+type TransactionCreatedPayload struct {
+	TransactionId   int64     `json:"transaction_id"`
+	AccountId       int64     `json:"account_id"`
+	OperationTypeId int64     `json:"operation_type_id"`
+	Amount          float64   `json:"amount"`
+	Balance         float64   `json:"balance"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type TransactionBalanceUpdatedPayload struct {
+	TransactionId   int64   `json:"transaction_id"`
+	AccountId       int64   `json:"account_id"`
+	PreviousBalance float64 `json:"previous_balance"`
+	Balance         float64 `json:"balance"`
+}
@@ -18,14 +18,54 @@ type Transaction struct {
 	CreatedAt       time.Time
 }
 
+// TransactionExportFilter selects the transactions of an account created within [From, To); a nil bound is open.
+type TransactionExportFilter struct {
+	AccountId int64
+	From      *time.Time
+	To        *time.Time
+}
+
+// ExportedTransaction is a transaction with the description of its operation type.
+type ExportedTransaction struct {
+	Transaction
+	OperationDescription string
+}
+
+// BalanceCorrection is a balance rewritten by a recompute.
+type BalanceCorrection struct {
+	TransactionId   int64
+	AccountId       int64
+	PreviousBalance float64
+	Balance         float64
+}
+
+// Statuses of the items of a transaction batch.
+const (
+	BatchItemCreated = "created"
+	BatchItemFailed  = "failed"
+	// BatchItemRolledBack is an item of an all-or-nothing batch that was undone because another item failed.
+	BatchItemRolledBack = "rolled_back"
+	// BatchItemSkipped is an item of an all-or-nothing batch that was not attempted because another item failed.
+	BatchItemSkipped = "skipped"
+)
+
+// BatchItemOutcome is what became of one item of a batch: the created transaction, or the error it failed with.
+type BatchItemOutcome struct {
+	Status      string
+	Transaction *Transaction
+	Err         error
+}
+
 type TransactionType struct {
 	Id         int64
 	IsNegative bool
+	// Description is the one seeded in operation_types.
+	Description string
 }
 
 var ValidOperations = map[int64]TransactionType{
-	1: {Id: 1, IsNegative: true},  // Normal Purchase
-	2: {Id: 2, IsNegative: true},  // Purchase with Installments
-	3: {Id: 3, IsNegative: true},  // Withdrawal
-	4: {Id: 4, IsNegative: false}, // Credit Voucher
+	1: {Id: 1, IsNegative: true, Description: "Normal Purchase"},
+	2: {Id: 2, IsNegative: true, Description: "Purchase with installments"},
+	3: {Id: 3, IsNegative: true, Description: "Withdrawal"},
+	4: {Id: 4, IsNegative: false, Description: "Credit Voucher"},
 }
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+type WebhookSubscription struct {
+	Id         int64
+	Url        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type CreateWebhookSubscriptionParam struct {
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+type UpdateWebhookSubscriptionParam struct {
+	Id         int64
+	Url        string
+	EventTypes []string
+	Active     bool
+}
+
+type WebhookDelivery struct {
+	Id             int64
+	EventId        int64
+	SubscriptionId int64
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastStatusCode int32
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// PendingDelivery is a claimed delivery joined with the event it carries and the endpoint it targets.
+type PendingDelivery struct {
+	Id             int64
+	Attempts       int32
+	EventId        int64
+	EventType      string
+	Payload        []byte
+	EventCreatedAt time.Time
+	SubscriptionId int64
+	Url            string
+	Secret         string
+}
+
+type DeliveryFailureParam struct {
+	Id             int64
+	Status         string
+	LastStatusCode int32
+	LastError      string
+	NextAttemptAt  time.Time
+}
@@ -0,0 +1,150 @@
+// Package encryption seals personal data at rest with AES-GCM keys read from a local keyring file, and
+// derives blind indexes so sealed values can still be compared for equality.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const keySize = 32
+
+var ErrUnknownKey = errors.New("encryption key is not in the keyring")
+
+// Config points at the keyring file.
+type Config struct {
+	KeyringFile string `yaml:"keyring_file" env:"KEYRING_FILE" validate:"required"`
+}
+
+// keyringFile is the JSON document of a keyring. Keys are base64 encoded 256-bit keys.
+//
+//	{"primary_key_id": "2026-10", "keys": {"2026-09": "...", "2026-10": "..."}, "index_key": "..."}
+type keyringFile struct {
+	PrimaryKeyId string            `json:"primary_key_id"`
+	Keys         map[string]string `json:"keys"`
+	IndexKey     string            `json:"index_key"`
+}
+
+// Keyring encrypts with its primary key and decrypts with any key it holds, so a rotation only adds a
+// key and changes the primary one. The index key is never rotated: blind indexes would stop matching.
+type Keyring struct {
+	primaryKeyId string
+	aeads        map[string]cipher.AEAD
+	indexKey     []byte
+}
+
+// LoadKeyring reads a keyring file.
+func LoadKeyring(path string) (*Keyring, error) {
+	document, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeyring(document)
+}
+
+// ParseKeyring decodes a keyring document and checks every key.
+func ParseKeyring(document []byte) (*Keyring, error) {
+	var file keyringFile
+	if err := json.Unmarshal(document, &file); err != nil {
+		return nil, fmt.Errorf("invalid keyring: %w", err)
+	}
+	keys := make(map[string][]byte, len(file.Keys))
+	for keyId, encoded := range file.Keys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyring key %q: %w", keyId, err)
+		}
+		keys[keyId] = key
+	}
+	indexKey, err := decodeKey(file.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keyring index_key: %w", err)
+	}
+	return NewKeyring(file.PrimaryKeyId, keys, indexKey)
+}
+
+// NewKeyring builds a keyring from raw 256-bit keys.
+func NewKeyring(primaryKeyId string, keys map[string][]byte, indexKey []byte) (*Keyring, error) {
+	if _, ok := keys[primaryKeyId]; !ok {
+		return nil, fmt.Errorf("primary key %q: %w", primaryKeyId, ErrUnknownKey)
+	}
+	if len(indexKey) != keySize {
+		return nil, fmt.Errorf("index key must be %d bytes", keySize)
+	}
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for keyId, key := range keys {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key %q must be %d bytes", keyId, keySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		aeads[keyId] = aead
+	}
+	return &Keyring{primaryKeyId: primaryKeyId, aeads: aeads, indexKey: indexKey}, nil
+}
+
+// PrimaryKeyId is the id of the key new values are encrypted with.
+func (k *Keyring) PrimaryKeyId() string {
+	return k.primaryKeyId
+}
+
+// Encrypt seals plaintext with the primary key. The ciphertext is the random nonce followed by the sealed
+// value; the key id is bound to it as additional data so a row cannot be decrypted under another id.
+func (k *Keyring) Encrypt(plaintext string) (keyId string, ciphertext []byte, err error) {
+	aead := k.aeads[k.primaryKeyId]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return k.primaryKeyId, aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.primaryKeyId)), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt with the key keyId.
+func (k *Keyring) Decrypt(keyId string, ciphertext []byte) (string, error) {
+	aead, ok := k.aeads[keyId]
+	if !ok {
+		return "", fmt.Errorf("key %q: %w", keyId, ErrUnknownKey)
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return "", errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(keyId))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex is a keyed HMAC-SHA256 of value: equal values give equal indexes, but an index cannot be
+// reversed or brute forced without the index key.
+func (k *Keyring) BlindIndex(value string) []byte {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,101 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodedKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, keySize))
+}
+
+func keyringDocument(primaryKeyId string) []byte {
+	return []byte(fmt.Sprintf(`{"primary_key_id":%q,"keys":{"2026-09":%q,"2026-10":%q},"index_key":%q}`,
+		primaryKeyId, encodedKey(1), encodedKey(2), encodedKey(9)))
+}
+
+func TestLoadKeyring_Encrypts_With_Primary_Key(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, os.WriteFile(path, keyringDocument("2026-10"), 0o600))
+
+	keyring, err := LoadKeyring(path)
+	require.NoError(t, err)
+
+	keyId, ciphertext, err := keyring.Encrypt("0123456789")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", keyId)
+	assert.NotContains(t, string(ciphertext), "0123456789")
+
+	plaintext, err := keyring.Decrypt(keyId, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", plaintext)
+}
+
+func TestKeyring_Decrypts_Values_Of_A_Rotated_Key(t *testing.T) {
+	previous, err := ParseKeyring(keyringDocument("2026-09"))
+	require.NoError(t, err)
+	rotated, err := ParseKeyring(keyringDocument("2026-10"))
+	require.NoError(t, err)
+
+	keyId, ciphertext, err := previous.Encrypt("0123456789")
+	require.NoError(t, err)
+
+	plaintext, err := rotated.Decrypt(keyId, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", plaintext)
+	assert.Equal(t, previous.BlindIndex("0123456789"), rotated.BlindIndex("0123456789"))
+}
+
+func TestKeyring_Rejects_Tampered_Or_Unknown_Ciphertexts(t *testing.T) {
+	keyring, err := ParseKeyring(keyringDocument("2026-10"))
+	require.NoError(t, err)
+	keyId, ciphertext, err := keyring.Encrypt("0123456789")
+	require.NoError(t, err)
+
+	_, otherKeyErr := keyring.Decrypt("2026-09", ciphertext)
+	_, unknownKeyErr := keyring.Decrypt("2024-01", ciphertext)
+	tampered := append([]byte{}, ciphertext...)
+	tampered[len(tampered)-1] ^= 1
+	_, tamperedErr := keyring.Decrypt(keyId, tampered)
+	_, shortErr := keyring.Decrypt(keyId, ciphertext[:4])
+
+	assert.Error(t, otherKeyErr)
+	assert.ErrorIs(t, unknownKeyErr, ErrUnknownKey)
+	assert.Error(t, tamperedErr)
+	assert.Error(t, shortErr)
+}
+
+func TestKeyring_BlindIndex_Is_Deterministic_And_Keyed(t *testing.T) {
+	keyring, err := ParseKeyring(keyringDocument("2026-10"))
+	require.NoError(t, err)
+	otherIndexKey, err := NewKeyring("2026-10", map[string][]byte{"2026-10": bytes.Repeat([]byte{2}, keySize)}, bytes.Repeat([]byte{8}, keySize))
+	require.NoError(t, err)
+
+	_, first, _ := keyring.Encrypt("0123456789")
+	_, second, _ := keyring.Encrypt("0123456789")
+
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, keyring.BlindIndex("0123456789"), keyring.BlindIndex("0123456789"))
+	assert.NotEqual(t, keyring.BlindIndex("0123456789"), keyring.BlindIndex("0123456780"))
+	assert.NotEqual(t, keyring.BlindIndex("0123456789"), otherIndexKey.BlindIndex("0123456789"))
+}
+
+func TestParseKeyring_Rejects_Invalid_Documents(t *testing.T) {
+	documents := []string{
+		`not json`,
+		fmt.Sprintf(`{"primary_key_id":"2026-11","keys":{"2026-10":%q},"index_key":%q}`, encodedKey(2), encodedKey(9)),
+		fmt.Sprintf(`{"primary_key_id":"2026-10","keys":{"2026-10":"c2hvcnQ="},"index_key":%q}`, encodedKey(9)),
+		fmt.Sprintf(`{"primary_key_id":"2026-10","keys":{"2026-10":%q}}`, encodedKey(2)),
+	}
+	for _, document := range documents {
+		_, err := ParseKeyring([]byte(document))
+		assert.Error(t, err, document)
+	}
+}
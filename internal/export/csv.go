@@ -0,0 +1,41 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+)
+
+var csvHeader = []string{"transaction_id", "created_at", "operation_type_id", "operation", "amount", "balance"}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{writer: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Begin() error {
+	return e.writer.Write(csvHeader)
+}
+
+// Encode leaves the row buffered; csv.Writer flushes as its buffer fills, and End flushes the rest.
+func (e *csvEncoder) Encode(transaction domain.ExportedTransaction) error {
+	return e.writer.Write([]string{
+		strconv.FormatInt(transaction.Id, 10),
+		transaction.CreatedAt.UTC().Format(time.RFC3339),
+		strconv.FormatInt(transaction.OperationTypeId, 10),
+		transaction.OperationDescription,
+		strconv.FormatFloat(transaction.Amount, 'f', 2, 64),
+		strconv.FormatFloat(transaction.Balance, 'f', 2, 64),
+	})
+}
+
+func (e *csvEncoder) End() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
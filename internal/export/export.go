@@ -0,0 +1,69 @@
+// Package export encodes the transaction history of an account in the formats customers and accountants import
+// elsewhere: CSV, OFX and JSON Lines. Encoders write each transaction as it comes, so an export of any length is
+// streamed without being held in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+)
+
+// Formats of an export.
+const (
+	FormatCSV   = "csv"
+	FormatOFX   = "ofx"
+	FormatJSONL = "jsonl"
+)
+
+var Formats = []string{FormatCSV, FormatOFX, FormatJSONL}
+
+// Statement describes what is exported. From and To bound the period; OFX needs both, so the caller fills them in
+// when the request leaves them open.
+type Statement struct {
+	AccountId   int64
+	Currency    string
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+}
+
+// Encoder writes an export: Begin once, Encode for every transaction oldest first, then End.
+type Encoder interface {
+	Begin() error
+	Encode(transaction domain.ExportedTransaction) error
+	End() error
+}
+
+// NewEncoder returns the encoder of format writing to w.
+func NewEncoder(format string, w io.Writer, statement Statement) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVEncoder(w), nil
+	case FormatOFX:
+		return newOFXEncoder(w, statement), nil
+	case FormatJSONL:
+		return newJSONLEncoder(w), nil
+	}
+	return nil, fmt.Errorf("unknown export format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// ContentType is the media type of format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatOFX:
+		return "application/x-ofx"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// FileName is the name an export of statement in format is downloaded as.
+func FileName(format string, statement Statement) string {
+	return fmt.Sprintf("account-%d-transactions.%s", statement.AccountId, format)
+}
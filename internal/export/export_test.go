@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files with the current output: go test ./internal/export -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var (
+	periodStart = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd   = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+)
+
+var exportedTransactions = []domain.ExportedTransaction{
+	{
+		Transaction:          domain.Transaction{Id: 1, AccountId: 7, OperationTypeId: 1, Amount: -50, Balance: 0, CreatedAt: time.Date(2026, 9, 3, 14, 5, 0, 0, time.UTC)},
+		OperationDescription: "Normal Purchase",
+	},
+	{
+		Transaction:          domain.Transaction{Id: 2, AccountId: 7, OperationTypeId: 4, Amount: 60, Balance: 10, CreatedAt: time.Date(2026, 9, 10, 9, 30, 0, 0, time.FixedZone("BRT", -3*60*60))},
+		OperationDescription: "Credit Voucher",
+	},
+	{
+		Transaction:          domain.Transaction{Id: 3, AccountId: 7, OperationTypeId: 3, Amount: -12.5, Balance: -12.5, CreatedAt: time.Date(2026, 9, 21, 18, 0, 0, 0, time.UTC)},
+		OperationDescription: "Withdrawal & fees, \"ATM\" at the airport terminal",
+	},
+}
+
+func TestEncoders_Golden(t *testing.T) {
+	statement := Statement{AccountId: 7, Currency: "BRL", From: periodStart, To: periodEnd, GeneratedAt: periodEnd.Add(time.Hour)}
+
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			encoder, err := NewEncoder(format, &out, statement)
+			require.NoError(t, err)
+
+			require.NoError(t, encoder.Begin())
+			for _, transaction := range exportedTransactions {
+				require.NoError(t, encoder.Encode(transaction))
+			}
+			require.NoError(t, encoder.End())
+
+			golden := filepath.Join("testdata", "transactions."+format)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, out.Bytes(), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+// TestOFXEncoder_Well_Formed parses the golden OFX document, which importers read as XML.
+func TestOFXEncoder_Well_Formed(t *testing.T) {
+	document, err := os.ReadFile(filepath.Join("testdata", "transactions.ofx"))
+	require.NoError(t, err)
+
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestOFXEncoder_Empty_Export_Is_A_Complete_Document(t *testing.T) {
+	var out bytes.Buffer
+	encoder, err := NewEncoder(FormatOFX, &out, Statement{AccountId: 7, Currency: "BRL", From: periodStart, To: periodEnd, GeneratedAt: periodEnd})
+	require.NoError(t, err)
+
+	require.NoError(t, encoder.Begin())
+	require.NoError(t, encoder.End())
+
+	assert.Contains(t, out.String(), "<BANKTRANLIST>\n<DTSTART>20260901000000.000[0:GMT]</DTSTART>\n<DTEND>20261001000000.000[0:GMT]</DTEND>\n</BANKTRANLIST>\n")
+	assert.Contains(t, out.String(), "<LEDGERBAL><BALAMT>0.00</BALAMT>")
+}
+
+func TestNewEncoder_Unknown_Format(t *testing.T) {
+	_, err := NewEncoder("xlsx", &bytes.Buffer{}, Statement{})
+
+	assert.EqualError(t, err, `unknown export format "xlsx", expected one of csv, ofx, jsonl`)
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+)
+
+// jsonlTransaction is a line of a JSON Lines export.
+type jsonlTransaction struct {
+	TransactionId   int64     `json:"transaction_id"`
+	AccountId       int64     `json:"account_id"`
+	OperationTypeId int64     `json:"operation_type_id"`
+	Operation       string    `json:"operation"`
+	Amount          float64   `json:"amount"`
+	Balance         float64   `json:"balance"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type jsonlEncoder struct {
+	encoder *json.Encoder
+}
+
+func newJSONLEncoder(w io.Writer) *jsonlEncoder {
+	return &jsonlEncoder{encoder: json.NewEncoder(w)}
+}
+
+func (e *jsonlEncoder) Begin() error {
+	return nil
+}
+
+// Encode writes one object per line, json.Encoder ends every value with a newline.
+func (e *jsonlEncoder) Encode(transaction domain.ExportedTransaction) error {
+	return e.encoder.Encode(jsonlTransaction{
+		TransactionId:   transaction.Id,
+		AccountId:       transaction.AccountId,
+		OperationTypeId: transaction.OperationTypeId,
+		Operation:       transaction.OperationDescription,
+		Amount:          transaction.Amount,
+		Balance:         transaction.Balance,
+		CreatedAt:       transaction.CreatedAt.UTC(),
+	})
+}
+
+func (e *jsonlEncoder) End() error {
+	return nil
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+)
+
+// ofxHeader opens an OFX 2.1.1 document, the XML version of the format that personal-finance tools import.
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="211" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+// ofxNameLength is the longest NAME of a statement transaction the specification allows.
+const ofxNameLength = 32
+
+// ofxEncoder writes a credit card statement response. Its ledger balance is the sum of the balances of the
+// exported transactions, which is what is still open of them.
+type ofxEncoder struct {
+	w         io.Writer
+	statement Statement
+	balance   float64
+	err       error
+}
+
+func newOFXEncoder(w io.Writer, statement Statement) *ofxEncoder {
+	return &ofxEncoder{w: w, statement: statement}
+}
+
+func (e *ofxEncoder) Begin() error {
+	e.printf("%s<OFX>\n", ofxHeader)
+	e.printf("<SIGNONMSGSRSV1><SONRS>\n")
+	e.printf("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	e.printf("<DTSERVER>%s</DTSERVER>\n", ofxTime(e.statement.GeneratedAt))
+	e.printf("<LANGUAGE>ENG</LANGUAGE>\n")
+	e.printf("</SONRS></SIGNONMSGSRSV1>\n")
+	e.printf("<CREDITCARDMSGSRSV1><CCSTMTTRNRS>\n")
+	e.printf("<TRNUID>0</TRNUID>\n")
+	e.printf("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	e.printf("<CCSTMTRS>\n")
+	e.printf("<CURDEF>%s</CURDEF>\n", e.statement.Currency)
+	e.printf("<CCACCTFROM><ACCTID>%d</ACCTID></CCACCTFROM>\n", e.statement.AccountId)
+	e.printf("<BANKTRANLIST>\n")
+	e.printf("<DTSTART>%s</DTSTART>\n", ofxTime(e.statement.From))
+	e.printf("<DTEND>%s</DTEND>\n", ofxTime(e.statement.To))
+	return e.err
+}
+
+// Encode writes a purchase or withdrawal as a DEBIT of its negative amount and a credit voucher as a CREDIT.
+// FITID is the transaction id, which lets importers skip transactions they already have.
+func (e *ofxEncoder) Encode(transaction domain.ExportedTransaction) error {
+	transactionType := "CREDIT"
+	if transaction.Amount < 0 {
+		transactionType = "DEBIT"
+	}
+	e.balance += transaction.Balance
+	e.printf("<STMTTRN>\n")
+	e.printf("<TRNTYPE>%s</TRNTYPE>\n", transactionType)
+	e.printf("<DTPOSTED>%s</DTPOSTED>\n", ofxTime(transaction.CreatedAt))
+	e.printf("<TRNAMT>%s</TRNAMT>\n", ofxAmount(transaction.Amount))
+	e.printf("<FITID>%d</FITID>\n", transaction.Id)
+	e.printf("<NAME>%s</NAME>\n", ofxText(transaction.OperationDescription, ofxNameLength))
+	e.printf("</STMTTRN>\n")
+	return e.err
+}
+
+func (e *ofxEncoder) End() error {
+	e.printf("</BANKTRANLIST>\n")
+	e.printf("<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", ofxAmount(e.balance), ofxTime(e.statement.GeneratedAt))
+	e.printf("</CCSTMTRS>\n")
+	e.printf("</CCSTMTTRNRS></CREDITCARDMSGSRSV1>\n")
+	e.printf("</OFX>\n")
+	return e.err
+}
+
+// printf keeps the first write error, so a document is written without checking every line.
+func (e *ofxEncoder) printf(format string, args ...any) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+// ofxTime is the OFX datetime, YYYYMMDDHHMMSS.XXX with the time zone in brackets, always in UTC.
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func ofxAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// ofxText escapes value for XML after truncating it to length characters.
+func ofxText(value string, length int) string {
+	if runes := []rune(value); len(runes) > length {
+		value = string(runes[:length])
+	}
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import "github.com/credit-card-api/pkg/constants"
+
+// Message keys of the bad request errors, which share ERR_CC_BAD_REQUEST.
+const (
+	invalidRequestBodyKey = "invalid_request_body"
+	accountIdMissingKey   = "account_id_missing"
+	webhookIdMissingKey   = "webhook_id_missing"
+	apiKeyIdMissingKey    = "api_key_id_missing"
+	invalidQueryParamKey  = "invalid_query_params"
+	invalidLastEventIdKey = "invalid_last_event_id"
+
+	// validationKeyPrefix prefixes the validation tags; {0} is the field and {1} the parameter of the tag.
+	validationKeyPrefix  = "validation."
+	invalidValidationKey = validationKeyPrefix + "invalid"
+)
+
+// badRequestKeys finds the key of a bad request message.
+var badRequestKeys = map[string]string{
+	constants.InvalidRequestBodyErrMsg: invalidRequestBodyKey,
+	constants.AccountIdMissingErrMsg:   accountIdMissingKey,
+	constants.WebhookIdMissingErrMsg:   webhookIdMissingKey,
+	constants.ApiKeyIdMissingErrMsg:    apiKeyIdMissingKey,
+	constants.InvalidQueryParamErrMsg:  invalidQueryParamKey,
+	constants.InvalidLastEventIdErrMsg: invalidLastEventIdKey,
+}
+
+// catalog holds the bundle of every supported locale, keyed by error code, bad request key and validation tag.
+var catalog = map[string]map[string]string{
+	"en": {
+		constants.AccountAlreadyExistErrCode:        "account already exists.",
+		constants.AccountNotFoundErrCode:            "account does not exists with provided id.",
+		constants.InvalidOperationTypeErrCode:       "operation type ID provided is not supported by the system.",
+		constants.TransactionAccountNotFoundErrCode: "account does not exist with provided id.",
+		constants.AccountBlockedErrCode:             "account is blocked and does not accept transactions.",
+		constants.WebhookNotFoundErrCode:            "webhook subscription does not exist with provided id.",
+		constants.ApiKeyNotFoundErrCode:             "active api key does not exist with provided id.",
+		constants.StatementNotFoundErrCode:          "statement of a closed cycle does not exist with provided id.",
+		constants.UnauthorizedErrCode:               "valid credentials are required.",
+		constants.ForbiddenErrCode:                  "credentials are not allowed to perform this operation.",
+		constants.RateLimitedErrCode:                "too many requests, retry later.",
+		constants.RequestTooLargeErrCode:            "request body is too large.",
+		constants.PreconditionFailedErrCode:         "account was modified since the version in If-Match, fetch it again.",
+		constants.PreconditionRequiredErrCode:       "If-Match header with the account ETag is required.",
+		constants.InternalServerErrCode:             "an unexpected error occurred.",
+
+		invalidRequestBodyKey: constants.InvalidRequestBodyErrMsg,
+		accountIdMissingKey:   constants.AccountIdMissingErrMsg,
+		webhookIdMissingKey:   constants.WebhookIdMissingErrMsg,
+		apiKeyIdMissingKey:    constants.ApiKeyIdMissingErrMsg,
+		invalidQueryParamKey:  constants.InvalidQueryParamErrMsg,
+		invalidLastEventIdKey: constants.InvalidLastEventIdErrMsg,
+
+		validationKeyPrefix + constants.RequiredTag: "The '{0}' field is mandatory.",
+		validationKeyPrefix + constants.MaxTag:      "The '{0}' field cannot exceed {1} digits.",
+		validationKeyPrefix + constants.NumericTag:  "The '{0}' field will only accept numeric value.",
+		validationKeyPrefix + constants.GTTag:       "The '{0}' field value must be greater than {1}.",
+		validationKeyPrefix + constants.URLTag:      "The '{0}' field must be a valid URL.",
+		validationKeyPrefix + constants.MinTag:      "The '{0}' field must contain at least {1} item(s).",
+		validationKeyPrefix + constants.GTETag:      "The '{0}' field value must be greater than or equal to {1}.",
+		validationKeyPrefix + constants.LTETag:      "The '{0}' field value must be less than or equal to {1}.",
+		validationKeyPrefix + constants.OneOfTag:    "The '{0}' field must be one of [{1}].",
+		validationKeyPrefix + constants.EmailTag:    "The '{0}' field must be a valid email address.",
+		validationKeyPrefix + constants.E164Tag:     "The '{0}' field must be a phone number in E.164 format, such as +5511987654321.",
+		validationKeyPrefix + constants.DatetimeTag: "The '{0}' field must be a date in the {1} format.",
+		validationKeyPrefix + constants.CountryTag:  "The '{0}' field must be an ISO 3166-1 alpha-2 country code.",
+		validationKeyPrefix + constants.PastDateTag: "The '{0}' field must be a date in the past.",
+		validationKeyPrefix + constants.MaxItemsTag: "The '{0}' field cannot have more than {1} items.",
+		invalidValidationKey:                        "The '{0}' field is invalid.",
+	},
+	"pt_BR": {
+		constants.AccountAlreadyExistErrCode:        "a conta já existe.",
+		constants.AccountNotFoundErrCode:            "não existe conta com o id informado.",
+		constants.InvalidOperationTypeErrCode:       "o tipo de operação informado não é suportado pelo sistema.",
+		constants.TransactionAccountNotFoundErrCode: "não existe conta com o id informado.",
+		constants.AccountBlockedErrCode:             "a conta está bloqueada e não aceita transações.",
+		constants.WebhookNotFoundErrCode:            "não existe assinatura de webhook com o id informado.",
+		constants.ApiKeyNotFoundErrCode:             "não existe chave de api ativa com o id informado.",
+		constants.StatementNotFoundErrCode:          "não existe fatura de ciclo fechado com o id informado.",
+		constants.UnauthorizedErrCode:               "credenciais válidas são obrigatórias.",
+		constants.ForbiddenErrCode:                  "as credenciais não permitem realizar esta operação.",
+		constants.RateLimitedErrCode:                "muitas requisições, tente novamente mais tarde.",
+		constants.RequestTooLargeErrCode:            "o corpo da requisição é grande demais.",
+		constants.PreconditionFailedErrCode:         "a conta foi alterada desde a versão do If-Match, consulte-a novamente.",
+		constants.PreconditionRequiredErrCode:       "o cabeçalho If-Match com o ETag da conta é obrigatório.",
+		constants.InternalServerErrCode:             "ocorreu um erro inesperado.",
+
+		invalidRequestBodyKey: "corpo da requisição inválido",
+		accountIdMissingKey:   "accountId não foi informado nos parâmetros do caminho",
+		webhookIdMissingKey:   "webhookId não foi informado nos parâmetros do caminho",
+		apiKeyIdMissingKey:    "apiKeyId não foi informado nos parâmetros do caminho",
+		invalidQueryParamKey:  "parâmetros de consulta inválidos",
+		invalidLastEventIdKey: "Last-Event-ID deve ser um id de evento numérico",
+
+		validationKeyPrefix + constants.RequiredTag: "O campo '{0}' é obrigatório.",
+		validationKeyPrefix + constants.MaxTag:      "O campo '{0}' não pode exceder {1} dígitos.",
+		validationKeyPrefix + constants.NumericTag:  "O campo '{0}' aceita apenas valores numéricos.",
+		validationKeyPrefix + constants.GTTag:       "O valor do campo '{0}' deve ser maior que {1}.",
+		validationKeyPrefix + constants.URLTag:      "O campo '{0}' deve ser uma URL válida.",
+		validationKeyPrefix + constants.MinTag:      "O campo '{0}' deve conter pelo menos {1} item(ns).",
+		validationKeyPrefix + constants.GTETag:      "O valor do campo '{0}' deve ser maior ou igual a {1}.",
+		validationKeyPrefix + constants.LTETag:      "O valor do campo '{0}' deve ser menor ou igual a {1}.",
+		validationKeyPrefix + constants.OneOfTag:    "O campo '{0}' deve ser um de [{1}].",
+		validationKeyPrefix + constants.EmailTag:    "O campo '{0}' deve ser um endereço de e-mail válido.",
+		validationKeyPrefix + constants.E164Tag:     "O campo '{0}' deve ser um telefone no formato E.164, como +5511987654321.",
+		validationKeyPrefix + constants.DatetimeTag: "O campo '{0}' deve ser uma data no formato {1}.",
+		validationKeyPrefix + constants.CountryTag:  "O campo '{0}' deve ser um código de país ISO 3166-1 alfa-2.",
+		validationKeyPrefix + constants.PastDateTag: "O campo '{0}' deve ser uma data no passado.",
+		validationKeyPrefix + constants.MaxItemsTag: "O campo '{0}' não pode ter mais de {1} itens.",
+		invalidValidationKey:                        "O campo '{0}' é inválido.",
+	},
+}
@@ -0,0 +1,116 @@
+// Package i18n translates the error messages of the API into the language a client asks for with
+// Accept-Language. en and pt-BR are bundled, en is the fallback.
+package i18n
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+)
+
+var universal = newUniversalTranslator()
+
+// Default translates into English, for messages produced outside a request.
+var Default = universal.GetFallback()
+
+func newUniversalTranslator() *ut.UniversalTranslator {
+	universal := ut.New(en.New(), en.New(), pt_BR.New())
+	for locale, messages := range catalog {
+		translator, _ := universal.GetTranslator(locale)
+		for key, text := range messages {
+			if err := translator.Add(key, text, false); err != nil {
+				panic("i18n: " + locale + " " + key + ": " + err.Error())
+			}
+		}
+	}
+	return universal
+}
+
+// Translator returns the translator of the preferred supported language of an Accept-Language header: an exact
+// match first, then the same language in another region, so "pt" and "pt-PT" get pt-BR.
+func Translator(acceptLanguage string) ut.Translator {
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		locale := strings.ReplaceAll(tag, "-", "_")
+		if translator, found := universal.GetTranslator(locale); found {
+			return translator
+		}
+		language, _, _ := strings.Cut(locale, "_")
+		for supported := range catalog {
+			if strings.EqualFold(language, strings.Split(supported, "_")[0]) {
+				translator, _ := universal.GetTranslator(supported)
+				return translator
+			}
+		}
+	}
+	return Default
+}
+
+// LanguageTag is the BCP 47 tag of the language of translator, for the Content-Language header.
+func LanguageTag(translator ut.Translator) string {
+	return strings.ReplaceAll(translator.Locale(), "_", "-")
+}
+
+// Message translates the message of an error code. Only the catalog message of the code is translated, any other
+// message is returned as is.
+func Message(translator ut.Translator, code string, message string) string {
+	key := code
+	if code == constants.BadRequestErrCode {
+		var found bool
+		if key, found = badRequestKeys[message]; !found {
+			return message
+		}
+	}
+	if english, err := Default.T(key); err != nil || english != message {
+		return message
+	}
+	if text, err := translator.T(key); err == nil {
+		return text
+	}
+	return message
+}
+
+// FieldMessage is the message of a field failing a validation tag with param.
+func FieldMessage(translator ut.Translator, tag string, field string, param string) string {
+	if text, err := translator.T(validationKeyPrefix+tag, field, param); err == nil {
+		return text
+	}
+	text, _ := translator.T(invalidValidationKey, field, param)
+	return text
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header by decreasing quality, in their
+// original order on ties, without those of quality zero.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var languages []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if tag = strings.TrimSpace(tag); tag != "" && tag != "*" && quality > 0 {
+			languages = append(languages, weighted{tag: tag, quality: quality})
+		}
+	}
+	slices.SortStableFunc(languages, func(a, b weighted) int { return cmp.Compare(b.quality, a.quality) })
+
+	tags := make([]string, 0, len(languages))
+	for _, language := range languages {
+		tags = append(tags, language.tag)
+	}
+	return tags
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Bundles_Have_The_Same_Keys(t *testing.T) {
+	for locale, messages := range catalog {
+		for key := range catalog["en"] {
+			assert.Contains(t, messages, key, locale)
+		}
+		assert.Len(t, messages, len(catalog["en"]), locale)
+	}
+}
+
+func TestCatalog_English_Matches_Domain_Errors(t *testing.T) {
+	appErrors := []*domain.AppError{
+		domain.ErrAccountAlreadyExist, domain.ErrAccountNotFound, domain.ErrInvalidOperationType,
+		domain.ErrTransactionAccountNotFound, domain.ErrAccountBlocked, domain.ErrWebhookNotFound,
+		domain.ErrApiKeyNotFound, domain.ErrStatementNotFound, domain.ErrUnauthorized, domain.ErrForbidden, domain.ErrRateLimited, domain.ErrRequestTooLarge,
+		domain.ErrAccountVersionMismatch, domain.ErrPreconditionRequired, domain.ErrInternal,
+	}
+
+	for _, appErr := range appErrors {
+		assert.Equal(t, appErr.Message, catalog["en"][appErr.Code], appErr.Code)
+	}
+}
+
+func TestTranslator_Picks_Preferred_Supported_Language(t *testing.T) {
+	tests := map[string]string{
+		"":                                "en",
+		"pt-BR":                           "pt_BR",
+		"pt":                              "pt_BR",
+		"pt-PT;q=0.8, en-US":              "en",
+		"fr-FR, pt-br;q=0.5, en;q=0.4":    "pt_BR",
+		"de, fr;q=0.9":                    "en",
+		"pt-BR;q=0, en-GB;q=0.1":          "en",
+		"en;q=0.2, pt-BR;q=0.9, *;q=0.95": "pt_BR",
+	}
+
+	for header, locale := range tests {
+		assert.Equal(t, locale, Translator(header).Locale(), header)
+	}
+}
+
+func TestMessage_Translates_Catalog_Messages_Only(t *testing.T) {
+	ptBR := Translator("pt-BR")
+
+	assert.Equal(t, "a conta está bloqueada e não aceita transações.", Message(ptBR, domain.ErrAccountBlocked.Code, domain.ErrAccountBlocked.Message))
+	assert.Equal(t, "corpo da requisição inválido", Message(ptBR, constants.BadRequestErrCode, constants.InvalidRequestBodyErrMsg))
+	assert.Equal(t, "custom failure", Message(ptBR, constants.InternalServerErrCode, "custom failure"))
+	assert.Equal(t, "unknown", Message(ptBR, "ERR_CC_UNKNOWN", "unknown"))
+}
+
+func TestFieldMessage(t *testing.T) {
+	ptBR := Translator("pt-BR")
+
+	assert.Equal(t, "The 'Amount' field value must be greater than 0.", FieldMessage(Default, constants.GTTag, "Amount", "0"))
+	assert.Equal(t, "O campo 'DocumentNumber' não pode exceder 12 dígitos.", FieldMessage(ptBR, constants.MaxTag, "DocumentNumber", "12"))
+	assert.Equal(t, "O campo 'Url' é inválido.", FieldMessage(ptBR, "hostname", "Url", ""))
+}
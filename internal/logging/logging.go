@@ -0,0 +1,62 @@
+// Package logging carries a request-scoped logger in the context, so every line logged while serving a
+// request can be correlated by its request id.
+package logging
+
+import (
+	"context"
+	"sync"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	RequestIdField = "request_id"
+	MethodField    = "method"
+	RouteField     = "route"
+	AccountIdField = "account_id"
+	PrincipalField = "principal"
+	TraceIdField   = "trace_id"
+)
+
+type loggerKey struct{}
+
+// scope is shared by every context derived from the request, so fields added by inner middlewares
+// (the principal, the account) also show up in lines logged by outer ones.
+type scope struct {
+	mu        sync.RWMutex
+	entry     *logger.Entry
+	requestId string
+}
+
+// WithRequest starts the logging scope of a request.
+func WithRequest(ctx context.Context, requestId string, fields logger.Fields) context.Context {
+	entry := logger.WithField(RequestIdField, requestId).WithFields(fields)
+	return context.WithValue(ctx, loggerKey{}, &scope{entry: entry, requestId: requestId})
+}
+
+// FromContext returns the logger of the request carried by ctx, or the global logger outside a request.
+func FromContext(ctx context.Context) *logger.Entry {
+	if s, ok := ctx.Value(loggerKey{}).(*scope); ok {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.entry
+	}
+	return logger.NewEntry(logger.StandardLogger())
+}
+
+// AddField attaches a field to every following line of the request. Outside a request it does nothing.
+func AddField(ctx context.Context, key string, value any) {
+	if s, ok := ctx.Value(loggerKey{}).(*scope); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.entry = s.entry.WithField(key, value)
+	}
+}
+
+// RequestId returns the id of the request carried by ctx, empty outside a request.
+func RequestId(ctx context.Context) string {
+	if s, ok := ctx.Value(loggerKey{}).(*scope); ok {
+		return s.requestId
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/gin-gonic/gin"
+	logger "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const maxRequestIdLength = 128
+
+// Middleware accepts the caller's X-Request-ID, or generates one, echoes it in the response and
+// starts the request logging scope. It logs one line per completed request.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		startedAt := time.Now()
+		requestId := ctx.GetHeader(constants.RequestIdHeader)
+		if !isValidRequestId(requestId) {
+			requestId = newRequestId()
+		}
+		ctx.Header(constants.RequestIdHeader, requestId)
+
+		fields := logger.Fields{
+			MethodField: ctx.Request.Method,
+			RouteField:  ctx.FullPath(),
+		}
+		if accountId := ctx.Param(constants.AccountIdPathParam); accountId != "" {
+			fields[AccountIdField] = accountId
+		}
+		if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.HasTraceID() {
+			fields[TraceIdField] = spanContext.TraceID().String()
+		}
+		ctx.Request = ctx.Request.WithContext(WithRequest(ctx.Request.Context(), requestId, fields))
+
+		ctx.Next()
+
+		FromContext(ctx.Request.Context()).WithFields(logger.Fields{
+			"status":     ctx.Writer.Status(),
+			"latency_ms": time.Since(startedAt).Milliseconds(),
+			"client_ip":  ctx.ClientIP(),
+		}).Info("request completed")
+	}
+}
+
+// isValidRequestId accepts ids made of letters, digits and "-_.:", so a caller cannot inject log lines.
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return false
+	}
+	for _, r := range requestId {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestId() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/gin-gonic/gin"
+	logger "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/accounts/:accountId", func(ctx *gin.Context) {
+		AddField(ctx.Request.Context(), PrincipalField, "api-key:1")
+		FromContext(ctx.Request.Context()).Info("handled")
+		ctx.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestMiddleware_Echoes_Request_Id(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/7", nil)
+	req.Header.Set(constants.RequestIdHeader, "req-1")
+	recorder := httptest.NewRecorder()
+	newRouter().ServeHTTP(recorder, req)
+
+	assert.Equal(t, "req-1", recorder.Header().Get(constants.RequestIdHeader))
+	entries := hook.AllEntries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "handled", entries[0].Message)
+	assert.Equal(t, logger.Fields{
+		RequestIdField: "req-1",
+		MethodField:    http.MethodGet,
+		RouteField:     "/accounts/:accountId",
+		AccountIdField: "7",
+		PrincipalField: "api-key:1",
+	}, entries[0].Data)
+	assert.Equal(t, "request completed", entries[1].Message)
+	assert.Equal(t, "api-key:1", entries[1].Data[PrincipalField])
+	assert.Equal(t, http.StatusOK, entries[1].Data["status"])
+}
+
+func TestMiddleware_Generates_Request_Id(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	for _, requestId := range []string{"", "forged\nlevel=error", string(make([]byte, maxRequestIdLength+1))} {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/7", nil)
+		req.Header.Set(constants.RequestIdHeader, requestId)
+		recorder := httptest.NewRecorder()
+		newRouter().ServeHTTP(recorder, req)
+
+		generated := recorder.Header().Get(constants.RequestIdHeader)
+		assert.Len(t, generated, 32)
+		assert.NotEqual(t, requestId, generated)
+		assert.Equal(t, generated, hook.LastEntry().Data[RequestIdField])
+	}
+}
+
+func TestFromContext_Outside_Request(t *testing.T) {
+	assert.NotNil(t, FromContext(t.Context()))
+	assert.Empty(t, RequestId(t.Context()))
+	AddField(t.Context(), PrincipalField, "ignored")
+}
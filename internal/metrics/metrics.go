@@ -0,0 +1,36 @@
+// Package metrics records operational and business measurements behind the Recorder interface,
+// so instrumented code does not depend on the exporter and tests can assert on what was recorded.
+package metrics
+
+//go:generate mockgen -source=metrics.go -destination=mocks/mock_metrics.go -package=mocks
+
+import "time"
+
+// Outcomes of a single credit voucher discharge step.
+const (
+	// DischargeSettled means the voucher paid the purchase balance off entirely.
+	DischargeSettled = "settled"
+	// DischargePartial means the voucher ran out and left part of the purchase balance open.
+	DischargePartial = "partial"
+)
+
+type Recorder interface {
+	// ObserveHTTPRequest records a served request. route is the route template, not the raw path.
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+	// ObserveQuery records the execution of a named sqlc query.
+	ObserveQuery(name string, duration time.Duration, err error)
+	AccountCreated()
+	TransactionCreated(operationTypeId int64)
+	VoucherDischargeStep(outcome string)
+	TransactionRejected(errorCode string)
+}
+
+// Noop discards every measurement.
+type Noop struct{}
+
+func (Noop) ObserveHTTPRequest(string, string, int, time.Duration) {}
+func (Noop) ObserveQuery(string, time.Duration, error)             {}
+func (Noop) AccountCreated()                                       {}
+func (Noop) TransactionCreated(int64)                              {}
+func (Noop) VoucherDischargeStep(string)                           {}
+func (Noop) TransactionRejected(string)                            {}
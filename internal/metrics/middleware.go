@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that matched no route, so scanners cannot blow up label cardinality.
+const unmatchedRoute = "unmatched"
+
+// Middleware records every request by route template and status code.
+func Middleware(recorder Recorder) gin.HandlerFunc {
+	return middleware(recorder, time.Now)
+}
+
+func middleware(recorder Recorder, now func() time.Time) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		startedAt := now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		recorder.ObserveHTTPRequest(ctx.Request.Method, route, ctx.Writer.Status(), now().Sub(startedAt))
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/metrics/mocks"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMiddleware_Labels_By_Route_Template(t *testing.T) {
+	recorder := mocks.NewMockRecorder(gomock.NewController(t))
+	clock := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		clock = clock.Add(5 * time.Millisecond)
+		return clock
+	}
+	router := gin.New()
+	router.Use(middleware(recorder, now))
+	router.GET("/accounts/:accountId", func(ctx *gin.Context) { ctx.Status(http.StatusNotFound) })
+
+	recorder.EXPECT().ObserveHTTPRequest(http.MethodGet, "/accounts/:accountId", http.StatusNotFound, 5*time.Millisecond)
+	recorder.EXPECT().ObserveHTTPRequest(http.MethodGet, unmatchedRoute, http.StatusNotFound, 5*time.Millisecond)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/42", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wp-admin.php", nil))
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metrics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=metrics.go -destination=mocks/mock_metrics.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecorder is a mock of Recorder interface.
+type MockRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockRecorderMockRecorder is the mock recorder for MockRecorder.
+type MockRecorderMockRecorder struct {
+	mock *MockRecorder
+}
+
+// NewMockRecorder creates a new mock instance.
+func NewMockRecorder(ctrl *gomock.Controller) *MockRecorder {
+	mock := &MockRecorder{ctrl: ctrl}
+	mock.recorder = &MockRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecorder) EXPECT() *MockRecorderMockRecorder {
+	return m.recorder
+}
+
+// AccountCreated mocks base method.
+func (m *MockRecorder) AccountCreated() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AccountCreated")
+}
+
+// AccountCreated indicates an expected call of AccountCreated.
+func (mr *MockRecorderMockRecorder) AccountCreated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountCreated", reflect.TypeOf((*MockRecorder)(nil).AccountCreated))
+}
+
+// ObserveHTTPRequest mocks base method.
+func (m *MockRecorder) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveHTTPRequest", method, route, status, duration)
+}
+
+// ObserveHTTPRequest indicates an expected call of ObserveHTTPRequest.
+func (mr *MockRecorderMockRecorder) ObserveHTTPRequest(method, route, status, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveHTTPRequest", reflect.TypeOf((*MockRecorder)(nil).ObserveHTTPRequest), method, route, status, duration)
+}
+
+// ObserveQuery mocks base method.
+func (m *MockRecorder) ObserveQuery(name string, duration time.Duration, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveQuery", name, duration, err)
+}
+
+// ObserveQuery indicates an expected call of ObserveQuery.
+func (mr *MockRecorderMockRecorder) ObserveQuery(name, duration, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveQuery", reflect.TypeOf((*MockRecorder)(nil).ObserveQuery), name, duration, err)
+}
+
+// TransactionCreated mocks base method.
+func (m *MockRecorder) TransactionCreated(operationTypeId int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TransactionCreated", operationTypeId)
+}
+
+// TransactionCreated indicates an expected call of TransactionCreated.
+func (mr *MockRecorderMockRecorder) TransactionCreated(operationTypeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionCreated", reflect.TypeOf((*MockRecorder)(nil).TransactionCreated), operationTypeId)
+}
+
+// TransactionRejected mocks base method.
+func (m *MockRecorder) TransactionRejected(errorCode string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TransactionRejected", errorCode)
+}
+
+// TransactionRejected indicates an expected call of TransactionRejected.
+func (mr *MockRecorderMockRecorder) TransactionRejected(errorCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionRejected", reflect.TypeOf((*MockRecorder)(nil).TransactionRejected), errorCode)
+}
+
+// VoucherDischargeStep mocks base method.
+func (m *MockRecorder) VoucherDischargeStep(outcome string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "VoucherDischargeStep", outcome)
+}
+
+// VoucherDischargeStep indicates an expected call of VoucherDischargeStep.
+func (mr *MockRecorderMockRecorder) VoucherDischargeStep(outcome any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoucherDischargeStep", reflect.TypeOf((*MockRecorder)(nil).VoucherDischargeStep), outcome)
+}
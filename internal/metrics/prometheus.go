@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "credit_card_api"
+
+// Prometheus is the Recorder exported on /metrics.
+type Prometheus struct {
+	gatherer              prometheus.Gatherer
+	httpRequests          *prometheus.CounterVec
+	httpDuration          *prometheus.HistogramVec
+	queryDuration         *prometheus.HistogramVec
+	accountsCreated       prometheus.Counter
+	transactionsCreated   *prometheus.CounterVec
+	voucherDischargeSteps *prometheus.CounterVec
+	transactionsRejected  *prometheus.CounterVec
+}
+
+// NewPrometheus registers the collectors on registry, which is also what Handler serves.
+func NewPrometheus(registry *prometheus.Registry) *Prometheus {
+	p := &Prometheus{
+		gatherer: registry,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Latency of sqlc queries, by query name and outcome.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"query", "outcome"}),
+		accountsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "accounts_created_total",
+			Help:      "Accounts created.",
+		}),
+		transactionsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_created_total",
+			Help:      "Transactions created, by operation type id.",
+		}, []string{"operation_type"}),
+		voucherDischargeSteps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voucher_discharge_steps_total",
+			Help:      "Purchase balances discharged by credit vouchers, by outcome (settled or partial).",
+		}, []string{"outcome"}),
+		transactionsRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_rejected_total",
+			Help:      "Transactions rejected, by error code.",
+		}, []string{"error_code"}),
+	}
+	registry.MustRegister(
+		p.httpRequests,
+		p.httpDuration,
+		p.queryDuration,
+		p.accountsCreated,
+		p.transactionsCreated,
+		p.voucherDischargeSteps,
+		p.transactionsRejected,
+	)
+	return p
+}
+
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.gatherer, promhttp.HandlerOpts{})
+}
+
+func (p *Prometheus) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	p.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	p.httpDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) ObserveQuery(name string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	p.queryDuration.WithLabelValues(name, outcome).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) AccountCreated() {
+	p.accountsCreated.Inc()
+}
+
+func (p *Prometheus) TransactionCreated(operationTypeId int64) {
+	p.transactionsCreated.WithLabelValues(strconv.FormatInt(operationTypeId, 10)).Inc()
+}
+
+func (p *Prometheus) VoucherDischargeStep(outcome string) {
+	p.voucherDischargeSteps.WithLabelValues(outcome).Inc()
+}
+
+func (p *Prometheus) TransactionRejected(errorCode string) {
+	p.transactionsRejected.WithLabelValues(errorCode).Inc()
+}
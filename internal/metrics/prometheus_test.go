@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheus_Records_Labelled_Measurements(t *testing.T) {
+	recorder := NewPrometheus(prometheus.NewRegistry())
+
+	recorder.ObserveHTTPRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", http.StatusCreated, 20*time.Millisecond)
+	recorder.ObserveHTTPRequest(http.MethodPost, "/api/credit-card-api/v1/transactions", http.StatusCreated, 30*time.Millisecond)
+	recorder.ObserveQuery("CreateTransaction", time.Millisecond, nil)
+	recorder.ObserveQuery("CreateTransaction", time.Millisecond, errors.New("deadlock detected"))
+	recorder.AccountCreated()
+	recorder.TransactionCreated(4)
+	recorder.VoucherDischargeStep(DischargeSettled)
+	recorder.TransactionRejected("ERR_CC_INVALID_OPERATION_TYPE")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(recorder.httpRequests.WithLabelValues(http.MethodPost, "/api/credit-card-api/v1/transactions", "201")))
+	assert.Equal(t, 2, testutil.CollectAndCount(recorder.queryDuration))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.accountsCreated))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.transactionsCreated.WithLabelValues("4")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.voucherDischargeSteps.WithLabelValues(DischargeSettled)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.transactionsRejected.WithLabelValues("ERR_CC_INVALID_OPERATION_TYPE")))
+}
+
+func TestPrometheus_Handler_Serves_Registry(t *testing.T) {
+	recorder := NewPrometheus(prometheus.NewRegistry())
+	recorder.AccountCreated()
+	response := httptest.NewRecorder()
+
+	recorder.Handler().ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, strings.Contains(response.Body.String(), "credit_card_api_accounts_created_total 1"))
+}
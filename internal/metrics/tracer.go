@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name      string
+	startedAt time.Time
+}
+
+// QueryTracer is a pgx.QueryTracer timing the queries generated by sqlc, which start with "-- name: <Name> :<kind>".
+// Set on the pool config it sees queries inside database transactions too. Other statements are not recorded.
+type QueryTracer struct {
+	recorder Recorder
+	now      func() time.Time
+}
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+func NewQueryTracer(recorder Recorder) *QueryTracer {
+	return &QueryTracer{recorder: recorder, now: time.Now}
+}
+
+func (qt *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name, ok := QueryName(data.SQL)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: name, startedAt: qt.now()})
+}
+
+func (qt *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	qt.recorder.ObserveQuery(start.name, qt.now().Sub(start.startedAt), data.Err)
+}
+
+// QueryName extracts the query name from the header comment sqlc puts in front of every query.
+func QueryName(sql string) (string, bool) {
+	header, ok := strings.CutPrefix(sql, "-- name: ")
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(header, " ")
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/metrics/mocks"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueryName(t *testing.T) {
+	name, ok := QueryName("-- name: GetAccountByID :one\nSELECT account_id FROM accounts WHERE account_id = $1")
+	assert.True(t, ok)
+	assert.Equal(t, "GetAccountByID", name)
+
+	_, ok = QueryName("begin")
+	assert.False(t, ok)
+}
+
+func TestQueryTracer_Observes_Named_Queries_Only(t *testing.T) {
+	recorder := mocks.NewMockRecorder(gomock.NewController(t))
+	tracer := NewQueryTracer(recorder)
+	clock := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	tracer.now = func() time.Time {
+		clock = clock.Add(2 * time.Millisecond)
+		return clock
+	}
+	queryErr := errors.New("unique violation")
+
+	recorder.EXPECT().ObserveQuery("CreateAccount", 2*time.Millisecond, queryErr)
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "-- name: CreateAccount :one\nINSERT INTO accounts"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: queryErr})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "commit"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+}
@@ -0,0 +1,193 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// backfillBatchSize is how many rows a backfill reads at a time.
+const backfillBatchSize = 500
+
+// Backfills are the backfills of the embedded migrations, by version. Their queries are written here rather than
+// generated: they read and write the columns a migration only holds until it is finished.
+func Backfills(keyring *encryption.Keyring) map[int64]Backfill {
+	return map[int64]Backfill{
+		6:  {Up: encryptDocumentNumbers(keyring), Down: decryptDocumentNumbers(keyring)},
+		11: {Up: encryptProfiles(keyring), Down: decryptProfiles(keyring)},
+	}
+}
+
+// encryptDocumentNumbers seals plain_document_number into document_number, with its key id and blind index.
+func encryptDocumentNumbers(keyring *encryption.Keyring) func(ctx context.Context, tx pgx.Tx) error {
+	return func(ctx context.Context, tx pgx.Tx) error {
+		return forEachAccount(ctx, tx, `SELECT account_id, plain_document_number FROM accounts
+			WHERE account_id > $1 ORDER BY account_id LIMIT $2`,
+			func(row pgx.CollectableRow) (accountId int64, documentNumber string, err error) {
+				err = row.Scan(&accountId, &documentNumber)
+				return accountId, documentNumber, err
+			},
+			func(accountId int64, documentNumber string) error {
+				keyId, ciphertext, err := keyring.Encrypt(documentNumber)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(ctx, `UPDATE accounts SET document_number = $2, document_key_id = $3, document_number_index = $4
+					WHERE account_id = $1`, accountId, ciphertext, keyId, keyring.BlindIndex(documentNumber))
+				return err
+			})
+	}
+}
+
+// decryptDocumentNumbers opens document_number back into plain_document_number.
+func decryptDocumentNumbers(keyring *encryption.Keyring) func(ctx context.Context, tx pgx.Tx) error {
+	type sealed struct {
+		keyId      string
+		ciphertext []byte
+	}
+	return func(ctx context.Context, tx pgx.Tx) error {
+		return forEachAccount(ctx, tx, `SELECT account_id, document_key_id, document_number FROM accounts
+			WHERE account_id > $1 ORDER BY account_id LIMIT $2`,
+			func(row pgx.CollectableRow) (accountId int64, value sealed, err error) {
+				err = row.Scan(&accountId, &value.keyId, &value.ciphertext)
+				return accountId, value, err
+			},
+			func(accountId int64, value sealed) error {
+				documentNumber, err := keyring.Decrypt(value.keyId, value.ciphertext)
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(ctx, `UPDATE accounts SET plain_document_number = $2 WHERE account_id = $1`, accountId, documentNumber)
+				return err
+			})
+	}
+}
+
+// plainProfile is the profile as the columns of migration 8 hold it.
+type plainProfile struct {
+	fullName, email, phone                         pgtype.Text
+	line1, line2, city, state, postalCode, country pgtype.Text
+	birthDate                                      pgtype.Date
+}
+
+// encryptProfiles seals the plain profile columns into profile and profile_key_id, accounts without a profile keep
+// them NULL.
+func encryptProfiles(keyring *encryption.Keyring) func(ctx context.Context, tx pgx.Tx) error {
+	return func(ctx context.Context, tx pgx.Tx) error {
+		return forEachAccount(ctx, tx, `SELECT account_id, full_name, email, phone, address_line1, address_line2,
+				address_city, address_state, address_postal_code, address_country, birth_date FROM accounts
+			WHERE account_id > $1 ORDER BY account_id LIMIT $2`,
+			func(row pgx.CollectableRow) (accountId int64, value plainProfile, err error) {
+				err = row.Scan(&accountId, &value.fullName, &value.email, &value.phone, &value.line1, &value.line2,
+					&value.city, &value.state, &value.postalCode, &value.country, &value.birthDate)
+				return accountId, value, err
+			},
+			func(accountId int64, value plainProfile) error {
+				profile := domain.AccountProfile{FullName: value.fullName.String, Email: value.email.String, Phone: value.phone.String}
+				if value.line1.Valid {
+					profile.Address = &domain.PostalAddress{
+						Line1:      value.line1.String,
+						Line2:      value.line2.String,
+						City:       value.city.String,
+						State:      value.state.String,
+						PostalCode: value.postalCode.String,
+						Country:    value.country.String,
+					}
+				}
+				if value.birthDate.Valid {
+					birthDate := value.birthDate.Time
+					profile.BirthDate = &birthDate
+				}
+				keyId, ciphertext, err := repository.SealProfile(keyring, profile)
+				if err != nil || ciphertext == nil {
+					return err
+				}
+				_, err = tx.Exec(ctx, `UPDATE accounts SET profile = $2, profile_key_id = $3 WHERE account_id = $1`, accountId, ciphertext, keyId)
+				return err
+			})
+	}
+}
+
+// decryptProfiles opens profile back into the plain profile columns.
+func decryptProfiles(keyring *encryption.Keyring) func(ctx context.Context, tx pgx.Tx) error {
+	type sealed struct {
+		keyId      string
+		ciphertext []byte
+	}
+	return func(ctx context.Context, tx pgx.Tx) error {
+		return forEachAccount(ctx, tx, `SELECT account_id, profile_key_id, profile FROM accounts
+			WHERE account_id > $1 AND profile IS NOT NULL ORDER BY account_id LIMIT $2`,
+			func(row pgx.CollectableRow) (accountId int64, value sealed, err error) {
+				err = row.Scan(&accountId, &value.keyId, &value.ciphertext)
+				return accountId, value, err
+			},
+			func(accountId int64, value sealed) error {
+				profile, err := repository.OpenProfile(keyring, value.keyId, value.ciphertext)
+				if err != nil {
+					return err
+				}
+				plain := plainProfile{
+					fullName: optionalText(profile.FullName),
+					email:    optionalText(profile.Email),
+					phone:    optionalText(profile.Phone),
+				}
+				if address := profile.Address; address != nil {
+					plain.line1, plain.line2 = optionalText(address.Line1), optionalText(address.Line2)
+					plain.city, plain.state = optionalText(address.City), optionalText(address.State)
+					plain.postalCode, plain.country = optionalText(address.PostalCode), optionalText(address.Country)
+				}
+				if profile.BirthDate != nil {
+					plain.birthDate = pgtype.Date{Time: *profile.BirthDate, Valid: true}
+				}
+				_, err = tx.Exec(ctx, `UPDATE accounts SET full_name = $2, email = $3, phone = $4, address_line1 = $5,
+						address_line2 = $6, address_city = $7, address_state = $8, address_postal_code = $9,
+						address_country = $10, birth_date = $11
+					WHERE account_id = $1`, accountId, plain.fullName, plain.email, plain.phone, plain.line1, plain.line2,
+					plain.city, plain.state, plain.postalCode, plain.country, plain.birthDate)
+				return err
+			})
+	}
+}
+
+// optionalText stores an empty profile field as NULL.
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+// forEachAccount calls fn for every row of query, read in batches keyed on account_id: query takes the last id
+// seen and the batch size, and scan returns the id of a row with its value.
+func forEachAccount[T any](ctx context.Context, tx pgx.Tx, query string, scan func(row pgx.CollectableRow) (int64, T, error), fn func(accountId int64, value T) error) error {
+	type row struct {
+		accountId int64
+		value     T
+	}
+	var afterId int64
+	for {
+		rows, err := tx.Query(ctx, query, afterId, backfillBatchSize)
+		if err != nil {
+			return err
+		}
+		// The batch is read to the end before fn runs, the connection cannot update while rows are open.
+		batch, err := pgx.CollectRows(rows, func(collectable pgx.CollectableRow) (row, error) {
+			accountId, value, err := scan(collectable)
+			return row{accountId: accountId, value: value}, err
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range batch {
+			if err := fn(r.accountId, r.value); err != nil {
+				return fmt.Errorf("account %d: %w", r.accountId, err)
+			}
+		}
+		if len(batch) < backfillBatchSize {
+			return nil
+		}
+		afterId = batch[len(batch)-1].accountId
+	}
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create writes the up and down files of the next migration in dir, the db/migrations source directory,
+// and returns their paths.
+func Create(dir string, name string) ([]string, error) {
+	if !migrationName.MatchString(name) {
+		return nil, errors.New("migration name may only hold lower case letters, digits and underscores")
+	}
+	existing, err := Load(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+	version := Latest(existing) + 1
+
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%06d_%s.%s.sql", version, name, direction))
+		content := fmt.Sprintf("-- %s migration %d: %s\n", direction, version, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,161 @@
+// Package migrations applies the versioned schema migrations embedded in the binary. The current version
+// is the highest row of schema_migrations. Each migration runs in one transaction with its version update,
+// so the runner never leaves a dirty version behind; one set by hand or by other tooling stops it.
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// BackfillMarker splits a migration file in two around the Backfill of its version, for data that only Go can
+// compute, such as values sealed with the keyring: columns are added before it and constrained after it.
+const BackfillMarker = "-- migrate:backfill"
+
+// Migration is a schema change and the statements that undo it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+	// UpBackfill is set when the up file holds BackfillMarker: Up is what comes before it and UpAfterBackfill
+	// what comes after. DownBackfill and DownAfterBackfill are the same for the down file.
+	UpBackfill        bool
+	UpAfterBackfill   string
+	DownBackfill      bool
+	DownAfterBackfill string
+}
+
+// Status is a migration as seen from the database.
+type Status struct {
+	Migration
+	Applied bool
+	Dirty   bool
+}
+
+// Load reads every migration of fsys, sorted by version. Each version needs an up and a down file, and
+// versions must follow each other from 1.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s is not named NNNNNN_name.up.sql or NNNNNN_name.down.sql", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, match[2])
+		}
+		before, after, backfill := strings.Cut(string(content), BackfillMarker)
+		if strings.Contains(after, BackfillMarker) {
+			return nil, fmt.Errorf("migration %s holds more than one %q", entry.Name(), BackfillMarker)
+		}
+		if match[3] == "up" {
+			migration.Up, migration.UpAfterBackfill, migration.UpBackfill = before, after, backfill
+		} else {
+			migration.Down, migration.DownAfterBackfill, migration.DownBackfill = before, after, backfill
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, migration := range migrations {
+		if migration.Version != int64(i+1) {
+			return nil, fmt.Errorf("migration versions must follow each other from 1, found %d at position %d", migration.Version, i+1)
+		}
+	}
+	return migrations, nil
+}
+
+// Latest is the version of the last migration, 0 without migrations.
+func Latest(migrations []Migration) int64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// planUp returns the migrations to apply, at most steps of them (all when steps is 0).
+func planUp(migrations []Migration, version int64, dirty bool, steps int) ([]Migration, error) {
+	if err := checkVersion(migrations, version, dirty); err != nil {
+		return nil, err
+	}
+	pending := migrations[version:]
+	if steps > 0 && steps < len(pending) {
+		pending = pending[:steps]
+	}
+	return pending, nil
+}
+
+// planDown returns the migrations to roll back, newest first, at most steps of them (all when steps is 0).
+func planDown(migrations []Migration, version int64, dirty bool, steps int) ([]Migration, error) {
+	if err := checkVersion(migrations, version, dirty); err != nil {
+		return nil, err
+	}
+	var rollback []Migration
+	for i := version - 1; i >= 0 && (steps == 0 || len(rollback) < steps); i-- {
+		rollback = append(rollback, migrations[i])
+	}
+	return rollback, nil
+}
+
+// checkBackfills fails when a migration to run needs a backfill that backfills does not have.
+func checkBackfills(migrations []Migration, backfills map[int64]Backfill, up bool) error {
+	for _, migration := range migrations {
+		backfill := backfills[migration.Version]
+		if up && migration.UpBackfill && backfill.Up == nil || !up && migration.DownBackfill && backfill.Down == nil {
+			return fmt.Errorf("migration %d_%s needs a backfill this build does not provide", migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func checkVersion(migrations []Migration, version int64, dirty bool) error {
+	if version > Latest(migrations) {
+		return fmt.Errorf("database is at version %d, this build only knows up to %d", version, Latest(migrations))
+	}
+	if dirty {
+		return fmt.Errorf("version %d is dirty, repair the schema by hand and clear schema_migrations.dirty", version)
+	}
+	return nil
+}
+
+func statuses(migrations []Migration, version int64, dirty bool) []Status {
+	result := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		result = append(result, Status{
+			Migration: migration,
+			Applied:   migration.Version < version || (migration.Version == version && !dirty),
+			Dirty:     migration.Version == version && dirty,
+		})
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package migrations
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/credit-card-api/db"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func threeMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "init", Up: "CREATE", Down: "DROP"},
+		{Version: 2, Name: "add", Up: "ALTER", Down: "ALTER"},
+		{Version: 3, Name: "index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+	}
+}
+
+func TestEmbedded_Migrations_Match_Expected_Schema_Version(t *testing.T) {
+	embedded, err := Load(db.Migrations())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExpectedSchemaVersion, Latest(embedded))
+}
+
+// The baseline table of README.md maps the schemas the former init_schema.sql created to these versions.
+func TestEmbedded_Migrations_Replay_The_Former_Init_Schema(t *testing.T) {
+	embedded, err := Load(db.Migrations())
+	require.NoError(t, err)
+	created := regexp.MustCompile(`CREATE (?:TABLE|INDEX|TRIGGER) (\w+)`)
+
+	expected := map[int64][]string{
+		1: {"accounts", "operation_types", "transactions"},
+		2: {"outbox_events", "idx_outbox_events_undispatched", "webhook_subscriptions", "webhook_deliveries", "idx_webhook_deliveries_pending"},
+		3: {"idx_outbox_events_account"},
+		4: {"audit_log", "idx_audit_log_resource", "audit_log_append_only", "audit_log_no_truncate"},
+		5: {"api_keys"},
+	}
+	for version, objects := range expected {
+		var names []string
+		for _, match := range created.FindAllStringSubmatch(embedded[version-1].Up, -1) {
+			names = append(names, match[1])
+		}
+		assert.Equal(t, objects, names, "migration %d", version)
+	}
+}
+
+func TestLoad_Sorts_And_Pairs_Files(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"000002_add.up.sql":    file("ALTER"),
+		"000001_init.down.sql": file("DROP"),
+		"000002_add.down.sql":  file("ALTER BACK"),
+		"000001_init.up.sql":   file("CREATE"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "init", Up: "CREATE", Down: "DROP"},
+		{Version: 2, Name: "add", Up: "ALTER", Down: "ALTER BACK"},
+	}, migrations)
+}
+
+func TestLoad_Splits_Files_Around_Backfill_Marker(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"000001_seal.up.sql":   file("ADD COLUMN\n-- migrate:backfill\nSET NOT NULL"),
+		"000001_seal.down.sql": file("DROP COLUMN"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{{
+		Version: 1, Name: "seal",
+		Up: "ADD COLUMN\n", UpBackfill: true, UpAfterBackfill: "\nSET NOT NULL",
+		Down: "DROP COLUMN",
+	}}, migrations)
+}
+
+func TestEmbedded_Backfills_Cover_Split_Migrations(t *testing.T) {
+	embedded, err := Load(db.Migrations())
+	require.NoError(t, err)
+	keyring, err := encryption.NewKeyring("k", map[string][]byte{"k": bytes.Repeat([]byte{1}, 32)}, bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+
+	assert.NoError(t, checkBackfills(embedded, Backfills(keyring), true))
+	assert.NoError(t, checkBackfills(embedded, Backfills(keyring), false))
+	assert.EqualError(t, checkBackfills(embedded, nil, true), "migration 6_encrypt_document_numbers needs a backfill this build does not provide")
+}
+
+func TestLoad_Rejects_Invalid_Sets(t *testing.T) {
+	sets := map[string]fstest.MapFS{
+		"bad name":      {"init.sql": file("CREATE")},
+		"missing down":  {"000001_init.up.sql": file("CREATE")},
+		"gap":           {"000001_init.up.sql": file("CREATE"), "000001_init.down.sql": file("DROP"), "000003_add.up.sql": file("ALTER"), "000003_add.down.sql": file("ALTER")},
+		"renamed twice": {"000001_init.up.sql": file("CREATE"), "000001_other.down.sql": file("DROP")},
+		"two markers":   {"000001_init.up.sql": file("A\n-- migrate:backfill\nB\n-- migrate:backfill\nC"), "000001_init.down.sql": file("DROP")},
+	}
+	for name, set := range sets {
+		_, err := Load(set)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPlanUp(t *testing.T) {
+	migrations := threeMigrations()
+
+	all, allErr := planUp(migrations, 0, false, 0)
+	next, nextErr := planUp(migrations, 1, false, 1)
+	none, noneErr := planUp(migrations, 3, false, 0)
+	_, dirtyErr := planUp(migrations, 2, true, 0)
+	_, newerErr := planUp(migrations, 4, false, 0)
+
+	assert.NoError(t, allErr)
+	assert.Equal(t, migrations, all)
+	assert.NoError(t, nextErr)
+	assert.Equal(t, migrations[1:2], next)
+	assert.NoError(t, noneErr)
+	assert.Empty(t, none)
+	assert.EqualError(t, dirtyErr, "version 2 is dirty, repair the schema by hand and clear schema_migrations.dirty")
+	assert.EqualError(t, newerErr, "database is at version 4, this build only knows up to 3")
+}
+
+func TestPlanDown(t *testing.T) {
+	migrations := threeMigrations()
+
+	last, lastErr := planDown(migrations, 3, false, 1)
+	all, allErr := planDown(migrations, 2, false, 0)
+	none, noneErr := planDown(migrations, 0, false, 1)
+	_, dirtyErr := planDown(migrations, 3, true, 1)
+
+	assert.NoError(t, lastErr)
+	assert.Equal(t, []Migration{migrations[2]}, last)
+	assert.NoError(t, allErr)
+	assert.Equal(t, []Migration{migrations[1], migrations[0]}, all)
+	assert.NoError(t, noneErr)
+	assert.Empty(t, none)
+	assert.Error(t, dirtyErr)
+}
+
+func TestStatuses(t *testing.T) {
+	result := statuses(threeMigrations(), 2, true)
+
+	assert.Equal(t, []bool{true, false, false}, []bool{result[0].Applied, result[1].Applied, result[2].Applied})
+	assert.Equal(t, []bool{false, true, false}, []bool{result[0].Dirty, result[1].Dirty, result[2].Dirty})
+}
+
+func TestCreate_Writes_Next_Version(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000001_init.up.sql"), []byte("CREATE"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000001_init.down.sql"), []byte("DROP"), 0o600))
+
+	paths, err := Create(dir, "add_holder_name")
+	_, nameErr := Create(dir, "Add Holder")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "000002_add_holder_name.up.sql"), filepath.Join(dir, "000002_add_holder_name.down.sql")}, paths)
+	migrations, loadErr := Load(os.DirFS(dir))
+	require.NoError(t, loadErr)
+	assert.Equal(t, int64(2), Latest(migrations))
+	assert.Error(t, nameErr)
+}
@@ -0,0 +1,195 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	logger "github.com/sirupsen/logrus"
+)
+
+// lockKey identifies the advisory lock that keeps concurrent replicas from migrating at the same time.
+const lockKey int64 = 0x6d6967726174696f
+
+// Backfill fills the columns a migration adds, inside the transaction of the migration. Up runs for the up file
+// and Down for the down file, between the halves split by BackfillMarker.
+type Backfill struct {
+	Up   func(ctx context.Context, tx pgx.Tx) error
+	Down func(ctx context.Context, tx pgx.Tx) error
+}
+
+type Runner struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	backfills  map[int64]Backfill
+}
+
+// NewRunner runs migrations on pool; backfills holds the Backfill of every migration split by BackfillMarker,
+// by version.
+func NewRunner(pool *pgxpool.Pool, migrations []Migration, backfills map[int64]Backfill) *Runner {
+	return &Runner{pool: pool, migrations: migrations, backfills: backfills}
+}
+
+// Up applies at most steps pending migrations, every one of them when steps is 0, and returns them.
+func (r *Runner) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := r.locked(ctx, func(conn *pgxpool.Conn, version int64, dirty bool) error {
+		pending, err := planUp(r.migrations, version, dirty, steps)
+		if err != nil {
+			return err
+		}
+		if err := checkBackfills(pending, r.backfills, true); err != nil {
+			return err
+		}
+		for _, migration := range pending {
+			logger.Infof("applying migration %d_%s", migration.Version, migration.Name)
+			if err := r.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back at most steps migrations, newest first, and returns them. A steps of 0 rolls back
+// every migration.
+func (r *Runner) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var rolledBack []Migration
+	err := r.locked(ctx, func(conn *pgxpool.Conn, version int64, dirty bool) error {
+		rollback, err := planDown(r.migrations, version, dirty, steps)
+		if err != nil {
+			return err
+		}
+		if err := checkBackfills(rollback, r.backfills, false); err != nil {
+			return err
+		}
+		for _, migration := range rollback {
+			logger.Infof("rolling back migration %d_%s", migration.Version, migration.Name)
+			if err := r.rollback(ctx, conn, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Force records version as the clean schema version without running anything, for a database whose schema
+// was created or repaired outside the runner. A version of 0 marks the database as having no migration.
+func (r *Runner) Force(ctx context.Context, version int64) error {
+	if version < 0 || version > Latest(r.migrations) {
+		return fmt.Errorf("version %d is unknown, this build knows 0 to %d", version, Latest(r.migrations))
+	}
+	return r.locked(ctx, func(conn *pgxpool.Conn, _ int64, _ bool) error {
+		return inTransaction(ctx, conn, func(tx pgx.Tx) error {
+			queries := sqlc.New(tx)
+			if err := queries.DeleteSchemaVersionsFrom(ctx, version+1); err != nil {
+				return err
+			}
+			if version == 0 {
+				return nil
+			}
+			return queries.SetSchemaVersion(ctx, sqlc.SetSchemaVersionParams{Version: version})
+		})
+	})
+}
+
+// Status lists every known migration and whether the database has it.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	var result []Status
+	err := r.locked(ctx, func(_ *pgxpool.Conn, version int64, dirty bool) error {
+		result = statuses(r.migrations, version, dirty)
+		return nil
+	})
+	return result, err
+}
+
+// locked runs fn on a single connection holding the migration lock, with the current schema version.
+func (r *Runner) locked(ctx context.Context, fn func(conn *pgxpool.Conn, version int64, dirty bool) error) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	queries := sqlc.New(conn)
+	if err := queries.AcquireMigrationLock(ctx, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if err := queries.ReleaseMigrationLock(context.WithoutCancel(ctx), lockKey); err != nil {
+			// The lock is a session lock: closing the connection is the only other way to release it.
+			logger.Errorf("error while release migration lock: %s", err.Error())
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	if err := queries.CreateSchemaMigrationsTable(ctx); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	current, err := queries.GetSchemaVersion(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	return fn(conn, current.Version, current.Dirty)
+}
+
+func (r *Runner) apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return inTransaction(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if migration.UpBackfill {
+			if err := r.backfills[migration.Version].Up(ctx, tx); err != nil {
+				return fmt.Errorf("backfill of migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := tx.Exec(ctx, migration.UpAfterBackfill); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return sqlc.New(tx).SetSchemaVersion(ctx, sqlc.SetSchemaVersionParams{Version: migration.Version})
+	})
+}
+
+func (r *Runner) rollback(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return inTransaction(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return fmt.Errorf("rollback of migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if migration.DownBackfill {
+			if err := r.backfills[migration.Version].Down(ctx, tx); err != nil {
+				return fmt.Errorf("backfill of rollback of migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := tx.Exec(ctx, migration.DownAfterBackfill); err != nil {
+				return fmt.Errorf("rollback of migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		queries := sqlc.New(tx)
+		if err := queries.DeleteSchemaVersionsFrom(ctx, migration.Version); err != nil {
+			return err
+		}
+		if migration.Version == 1 {
+			return nil
+		}
+		// Databases created before the runner only hold a row for their latest version.
+		return queries.SetSchemaVersion(ctx, sqlc.SetSchemaVersionParams{Version: migration.Version - 1})
+	})
+}
+
+func inTransaction(ctx context.Context, conn *pgxpool.Conn, fn func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -2,48 +2,101 @@ package models
 
 import (
 	"errors"
-	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/credit-card-api/internal/i18n"
 	"github.com/credit-card-api/pkg/constants"
 	"github.com/go-playground/validator/v10"
 )
 
 type CreateAccountRequest struct {
-	DocumentNumber string `json:"document_number" validate:"required,max=12,numeric" example:"0987654321"` // only allow numeric value
+	DocumentNumber string `json:"document_number" validate:"required,max=12,numeric" example:"0987654321" redact:"last4"` // only allow numeric value
 }
 
+// Fields tagged with redact are masked in the response unless the caller holds the pii:read scope.
 type CreateAccountResponse struct {
 	AccountId      int64  `json:"account_id" example:"1"`
-	DocumentNumber string `json:"document_number" example:"0987654321"`
+	DocumentNumber string `json:"document_number" example:"0987654321" redact:"last4"`
 }
 
 type GetAccountResponse struct {
-	AccountId      int64  `json:"account_id" example:"1"`
-	DocumentNumber string `json:"document_number" example:"0987654321"`
+	AccountId      int64          `json:"account_id" example:"1"`
+	DocumentNumber string         `json:"document_number" example:"0987654321" redact:"last4"`
+	BlockedAt      *time.Time     `json:"blocked_at,omitempty" example:"2026-10-01T12:00:00Z"`
+	FullName       string         `json:"full_name,omitempty" example:"Maria da Silva" redact:"full"`
+	Email          string         `json:"email,omitempty" example:"maria@example.com" redact:"full"`
+	Phone          string         `json:"phone,omitempty" example:"+5511987654321" redact:"last4"`
+	Address        *PostalAddress `json:"address,omitempty"`
+	BirthDate      string         `json:"birth_date,omitempty" example:"1990-05-17" redact:"full"`
+	Version        int32          `json:"version" example:"3"`
+	UpdatedAt      time.Time      `json:"updated_at" example:"2026-10-01T12:00:00Z"`
+}
+
+// UpdateAccountRequest changes the holder profile: a missing or null field is kept, an empty string clears it and
+// address replaces the whole stored address.
+type UpdateAccountRequest struct {
+	FullName  *string        `json:"full_name" validate:"omitzero,max=200" example:"Maria da Silva"`
+	Email     *string        `json:"email" validate:"omitzero,email,max=254" example:"maria@example.com"`
+	Phone     *string        `json:"phone" validate:"omitzero,e164" example:"+5511987654321"`
+	Address   *PostalAddress `json:"address"`
+	BirthDate *string        `json:"birth_date" validate:"omitzero,datetime=2006-01-02,past_date" example:"1990-05-17"`
+}
+
+type PostalAddress struct {
+	Line1      string `json:"line1" validate:"required,max=200" example:"Av. Paulista, 1000" redact:"full"`
+	Line2      string `json:"line2,omitempty" validate:"max=200" example:"Apto 42" redact:"full"`
+	City       string `json:"city" validate:"required,max=100" example:"São Paulo"`
+	State      string `json:"state" validate:"required,max=100" example:"SP"`
+	PostalCode string `json:"postal_code" validate:"required,max=20" example:"01310-100" redact:"full"`
+	Country    string `json:"country" validate:"required,iso3166_1_alpha2" example:"BR"`
 }
 
 func (request CreateAccountRequest) Validate() error {
-	err := validator.New().Struct(&request)
-	return translateError(err)
+	return translateError(validate.Struct(&request))
+}
+
+func (request UpdateAccountRequest) Validate() error {
+	return translateError(validate.Struct(&request))
+}
+
+// validate reports fields by their JSON name, or their query parameter name for query structs.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	// past_date accepts a date string of the datetime tag before today, so a birth date cannot be in the future.
+	_ = v.RegisterValidation(constants.PastDateTag, func(fl validator.FieldLevel) bool {
+		date, err := time.Parse(time.DateOnly, fl.Field().String())
+		return err == nil && date.Before(time.Now().UTC().Truncate(24*time.Hour))
+	})
+	return v
 }
 
+// translateError returns every invalid field as ValidationErrors, other errors unchanged.
 func translateError(err error) error {
 	var ve validator.ValidationErrors
-	if errors.As(err, &ve) {
-		fe := ve[0]
-		field := fe.Field()
-		tag := fe.Tag()
-		param := fe.Param()
-		switch tag {
-		case constants.RequiredTag:
-			return errors.New(fmt.Sprintf("The '%s' field is mandatory.", field))
-		case constants.MaxTag:
-			return errors.New(fmt.Sprintf("The '%s' field cannot exceed %s digits.", field, param))
-		case constants.NumericTag:
-			return errors.New(fmt.Sprintf("The '%s' field will only accept numeric value.", field))
-		case constants.GTTag:
-			return errors.New(fmt.Sprintf("The '%s' field value must be greater than %s.", field, param))
+	if !errors.As(err, &ve) {
+		return err
+	}
+	fieldErrors := make(ValidationErrors, 0, len(ve))
+	for _, fe := range ve {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
 		}
+		_, path, _ := strings.Cut(fe.Namespace(), ".")
+		fieldError := FieldError{Field: path, Rule: rule, name: fe.StructField(), tag: fe.Tag(), param: fe.Param()}
+		fieldErrors = append(fieldErrors, fieldError.Localized(i18n.Default))
 	}
-	return err
+	return fieldErrors
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type CreateApiKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100" example:"billing-service"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=accounts:read accounts:write transactions:write pii:read admin" example:"accounts:read"`
+}
+
+type ApiKeyResponse struct {
+	ApiKeyId    int64      `json:"api_key_id" example:"1"`
+	Name        string     `json:"name" example:"billing-service"`
+	Prefix      string     `json:"prefix" example:"cck_5f2b8e0c"`
+	Scopes      []string   `json:"scopes" example:"accounts:read"`
+	RotatedFrom *int64     `json:"rotated_from,omitempty" example:"1"`
+	CreatedAt   time.Time  `json:"created_at" example:"2026-01-01T10:00:00Z"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IssuedApiKeyResponse is the only response that carries the plain api key.
+type IssuedApiKeyResponse struct {
+	ApiKeyResponse
+	Key string `json:"key" example:"cck_5f2b8e0c9a7d4e3f5f2b8e0c9a7d4e3f5f2b8e0c9a7d4e3f5f2b8e0c9a7d4e3f"`
+}
+
+func (request CreateApiKeyRequest) Validate() error {
+	return translateError(validate.Struct(&request))
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ListAuditLogsQuery struct {
+	ResourceType string     `form:"resource_type" validate:"omitempty,max=50"`
+	ResourceId   string     `form:"resource_id" validate:"omitempty,max=255"`
+	Actor        string     `form:"actor" validate:"omitempty,max=255"`
+	From         *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To           *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	AfterId      int64      `form:"after_id" validate:"gte=0"`
+	Limit        int32      `form:"limit" validate:"gte=0,lte=500"`
+}
+
+type AuditEntryResponse struct {
+	AuditId      int64           `json:"audit_id" example:"1"`
+	Actor        string          `json:"actor" example:"anonymous"`
+	Action       string          `json:"action" example:"account.create"`
+	ResourceType string          `json:"resource_type" example:"account"`
+	ResourceId   string          `json:"resource_id" example:"1"`
+	Before       json.RawMessage `json:"before" swaggertype:"object"`
+	After        json.RawMessage `json:"after" swaggertype:"object"`
+	Diff         json.RawMessage `json:"diff" swaggertype:"object"`
+	RequestId    string          `json:"request_id" example:"5f2b8e0c9a7d4e3f5f2b8e0c9a7d4e3f"`
+	SourceIp     string          `json:"source_ip" example:"10.0.0.1"`
+	CreatedAt    time.Time       `json:"created_at" example:"2026-01-01T10:00:00Z"`
+	PrevHash     string          `json:"prev_hash" example:"0000000000000000000000000000000000000000000000000000000000000000"`
+	Hash         string          `json:"hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+type ListAuditLogsResponse struct {
+	Entries     []AuditEntryResponse `json:"entries"`
+	NextAfterId int64                `json:"next_after_id,omitempty" example:"100"`
+}
+
+type AuditVerificationResponse struct {
+	Valid          bool   `json:"valid" example:"true"`
+	EntriesChecked int64  `json:"entries_checked" example:"1024"`
+	FirstInvalidId int64  `json:"first_invalid_id,omitempty" example:"0"`
+	LastHash       string `json:"last_hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+func (query ListAuditLogsQuery) Validate() error {
+	return translateError(validate.Struct(&query))
+}
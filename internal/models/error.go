@@ -1,7 +1,76 @@
 package models
 
+import (
+	"strings"
+
+	"github.com/credit-card-api/internal/i18n"
+	ut "github.com/go-playground/universal-translator"
+)
+
 type CCError struct {
 	ErrorCode    string `json:"error_code"`
 	ErrorMessage string `json:"error_message"`
 	StatusCode   int    `json:"status_code"`
+	// Errors lists every invalid field of a rejected request, only the problem format carries them.
+	Errors []FieldError `json:"-"`
+}
+
+// Localized returns a copy of the error with its messages in the language of translator.
+func (e *CCError) Localized(translator ut.Translator) *CCError {
+	localized := *e
+	localized.ErrorMessage = i18n.Message(translator, e.ErrorCode, e.ErrorMessage)
+	if len(e.Errors) > 0 {
+		localized.Errors = make([]FieldError, 0, len(e.Errors))
+		for _, fieldError := range e.Errors {
+			localized.Errors = append(localized.Errors, fieldError.Localized(translator))
+		}
+		localized.ErrorMessage = localized.Errors[0].Message
+	}
+	return &localized
+}
+
+// FieldError is a request field that failed validation, by its JSON path.
+type FieldError struct {
+	Field   string `json:"field" example:"document_number"`
+	Rule    string `json:"rule" example:"max=12"`
+	Message string `json:"message" example:"The 'DocumentNumber' field cannot exceed 12 digits."`
+
+	// name is the Go name of the field, the one messages show; tag and param are the failed validation.
+	name  string
+	tag   string
+	param string
+}
+
+// Localized returns the field error with its message in the language of translator.
+func (fe FieldError) Localized(translator ut.Translator) FieldError {
+	fe.Message = i18n.FieldMessage(translator, fe.tag, fe.name, fe.param)
+	return fe
+}
+
+// ValidationErrors are all the invalid fields of a request. Error returns the message of the first one, the
+// error_message of the legacy format.
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string {
+	if len(ve) == 0 {
+		return ""
+	}
+	return ve[0].Message
+}
+
+// Problem is an RFC 7807 application/problem+json error, sent to clients that accept it.
+type Problem struct {
+	Type     string       `json:"type" example:"/problems/bad-request"`
+	Title    string       `json:"title" example:"Bad Request"`
+	Status   int          `json:"status" example:"400"`
+	Detail   string       `json:"detail" example:"The 'DocumentNumber' field cannot exceed 12 digits."`
+	Instance string       `json:"instance,omitempty" example:"5f2b8e0c9a7d4e3f5f2b8e0c9a7d4e3f"`
+	Code     string       `json:"code" example:"ERR_CC_BAD_REQUEST"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// ProblemType is the type URI of an error code: ERR_CC_ACCOUNT_NOT_FOUND is /problems/account-not-found.
+func ProblemType(errorCode string) string {
+	slug := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(errorCode, "ERR_CC_"), "_", "-"))
+	return "/problems/" + slug
 }
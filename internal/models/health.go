@@ -0,0 +1,13 @@
+package models
+
+type HealthCheckResponse struct {
+	Name      string  `json:"name" example:"database"`
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty" example:""`
+}
+
+type HealthResponse struct {
+	Status string                `json:"status" example:"up"`
+	Checks []HealthCheckResponse `json:"checks,omitempty"`
+}
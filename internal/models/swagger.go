@@ -12,6 +12,12 @@ type ConflictError struct {
 	StatusCode   int64  `json:"status_code" example:"409"`
 }
 
+type ForbiddenError struct {
+	ErrorCode    string `json:"error_code" example:"ERR_CC_FORBIDDEN"`
+	ErrorMessage string `json:"error_message" example:"credentials are not allowed to perform this operation."`
+	StatusCode   int64  `json:"status_code" example:"403"`
+}
+
 type InternalServerError struct {
 	ErrorCode    string `json:"error_code" example:"ERR_CC_INTERNAL_SERVER_ERROR"`
 	ErrorMessage string `json:"error_message" example:"internal server error"`
@@ -24,6 +30,36 @@ type NotFoundError struct {
 	StatusCode   int    `json:"status_code" example:"404"`
 }
 
+type PreconditionFailedError struct {
+	ErrorCode    string `json:"error_code" example:"ERR_CC_PRECONDITION_FAILED"`
+	ErrorMessage string `json:"error_message" example:"account was modified since the version in If-Match, fetch it again."`
+	StatusCode   int64  `json:"status_code" example:"412"`
+}
+
+type PreconditionRequiredError struct {
+	ErrorCode    string `json:"error_code" example:"ERR_CC_PRECONDITION_REQUIRED"`
+	ErrorMessage string `json:"error_message" example:"If-Match header with the account ETag is required."`
+	StatusCode   int64  `json:"status_code" example:"428"`
+}
+
+type PayloadTooLargeError struct {
+	ErrorCode    string `json:"error_code" example:"ERR_CC_REQUEST_TOO_LARGE"`
+	ErrorMessage string `json:"error_message" example:"request body is too large."`
+	StatusCode   int64  `json:"status_code" example:"413"`
+}
+
+type TooManyRequestsError struct {
+	ErrorCode    string `json:"error_code" example:"ERR_CC_RATE_LIMITED"`
+	ErrorMessage string `json:"error_message" example:"too many requests, retry later."`
+	StatusCode   int64  `json:"status_code" example:"429"`
+}
+
+type UnauthorizedError struct {
+	ErrorCode    string `json:"error_code" example:"ERR_CC_UNAUTHORIZED"`
+	ErrorMessage string `json:"error_message" example:"valid credentials are required."`
+	StatusCode   int64  `json:"status_code" example:"401"`
+}
+
 type UnprocessableEntityError struct {
 	ErrorCode    string `json:"error_code" example:"ERR_CC_TRANSACTION_ACCOUNT_NOT_FOUND"`
 	ErrorMessage string `json:"error_message" example:"account does not exist with provided id."`
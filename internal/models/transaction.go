@@ -1,7 +1,11 @@
 package models
 
 import (
-	"github.com/go-playground/validator/v10"
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/i18n"
+	"github.com/credit-card-api/pkg/constants"
 )
 
 type TransactionRequest struct {
@@ -14,7 +18,63 @@ type CreateTransactionResponse struct {
 	TransactionId int64 `json:"transaction_id" example:"1"`
 }
 
+// Modes of a transaction batch.
+const (
+	// BatchModeBestEffort creates every valid item it can, each on its own.
+	BatchModeBestEffort = "best_effort"
+	// BatchModeAllOrNothing creates every item or none: one invalid or failing item rolls back the whole batch.
+	BatchModeAllOrNothing = "all_or_nothing"
+)
+
+// BatchTransactionRequest holds items that are each validated as a TransactionRequest of their own, so one invalid
+// item does not reject the batch.
+type BatchTransactionRequest struct {
+	Mode  string               `json:"mode" validate:"omitempty,oneof=best_effort all_or_nothing" example:"best_effort"`
+	Items []TransactionRequest `json:"items" validate:"required,min=1"`
+}
+
+type BatchTransactionResponse struct {
+	Mode    string                   `json:"mode" example:"best_effort"`
+	Created int                      `json:"created" example:"1"`
+	Failed  int                      `json:"failed" example:"1"`
+	Results []BatchTransactionResult `json:"results"`
+}
+
+// BatchTransactionResult is the outcome of the item at Index of the request.
+type BatchTransactionResult struct {
+	Index         int          `json:"index" example:"0"`
+	Status        string       `json:"status" example:"created" enums:"created,failed,rolled_back,skipped"`
+	TransactionId int64        `json:"transaction_id,omitempty" example:"1"`
+	ErrorCode     string       `json:"error_code,omitempty" example:"ERR_CC_ACCOUNT_BLOCKED"`
+	ErrorMessage  string       `json:"error_message,omitempty" example:"account is blocked and does not accept transactions."`
+	Errors        []FieldError `json:"errors,omitempty"`
+}
+
 func (request TransactionRequest) Validate() error {
-	err := validator.New().Struct(&request)
-	return translateError(err)
+	return translateError(validate.Struct(&request))
+}
+
+// Validate checks the envelope of the batch, which takes at most maxItems items. The items are left to their own
+// Validate.
+func (request BatchTransactionRequest) Validate(maxItems int) error {
+	if err := validate.Struct(&request); err != nil {
+		return translateError(err)
+	}
+	if len(request.Items) > maxItems {
+		param := strconv.Itoa(maxItems)
+		fieldError := FieldError{Field: "items", Rule: constants.MaxItemsTag + "=" + param, name: "Items", tag: constants.MaxItemsTag, param: param}
+		return ValidationErrors{fieldError.Localized(i18n.Default)}
+	}
+	return nil
+}
+
+// ExportTransactionsQuery selects the transactions of an export, created within [from, to).
+type ExportTransactionsQuery struct {
+	Format string     `form:"format" validate:"omitempty,oneof=csv ofx jsonl"`
+	From   *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (query ExportTransactionsQuery) Validate() error {
+	return translateError(validate.Struct(&query))
 }
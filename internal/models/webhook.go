@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+type CreateWebhookRequest struct {
+	Url        string   `json:"url" validate:"required,url,max=2048" example:"https://example.com/hooks/credit-card"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=account.created account.blocked transaction.created transaction.balance_updated" example:"transaction.created"`
+}
+
+type UpdateWebhookRequest struct {
+	Url        *string  `json:"url" validate:"omitempty,url,max=2048" example:"https://example.com/hooks/credit-card"`
+	EventTypes []string `json:"event_types" validate:"omitempty,min=1,dive,oneof=account.created account.blocked transaction.created transaction.balance_updated" example:"transaction.created"`
+	Active     *bool    `json:"active" example:"false"`
+}
+
+type WebhookResponse struct {
+	WebhookId  int64     `json:"webhook_id" example:"1"`
+	Url        string    `json:"url" example:"https://example.com/hooks/credit-card"`
+	EventTypes []string  `json:"event_types" example:"transaction.created"`
+	Active     bool      `json:"active" example:"true"`
+	CreatedAt  time.Time `json:"created_at" example:"2026-01-01T10:00:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2026-01-01T10:00:00Z"`
+}
+
+// CreateWebhookResponse is the only response that carries the signing secret.
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret" example:"whsec_5f2b8e0c9a7d4e3f"`
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryId     int64      `json:"delivery_id" example:"1"`
+	EventId        int64      `json:"event_id" example:"1"`
+	Status         string     `json:"status" example:"dead"`
+	Attempts       int32      `json:"attempts" example:"8"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" example:"2026-01-01T10:00:00Z"`
+	LastStatusCode int32      `json:"last_status_code,omitempty" example:"503"`
+	LastError      string     `json:"last_error,omitempty" example:"unexpected status code 503"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" example:"2026-01-01T10:00:00Z"`
+}
+
+func (request CreateWebhookRequest) Validate() error {
+	return translateError(validate.Struct(&request))
+}
+
+func (request UpdateWebhookRequest) Validate() error {
+	return translateError(validate.Struct(&request))
+}
@@ -0,0 +1,248 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/pkg/constants"
+	logger "github.com/sirupsen/logrus"
+)
+
+const maxErrorBodyBytes = 512
+
+type Config struct {
+	PollInterval   time.Duration `yaml:"poll_interval" validate:"gt=0"`
+	BatchSize      int32         `yaml:"batch_size" validate:"gt=0"`
+	MaxAttempts    int32         `yaml:"max_attempts" validate:"gt=0"`
+	BaseBackoff    time.Duration `yaml:"base_backoff" validate:"gt=0"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" validate:"gtefield=BaseBackoff"`
+	RequestTimeout time.Duration `yaml:"request_timeout" validate:"gt=0"`
+	// DeliveryLease is how long a claimed delivery stays invisible to other dispatchers.
+	// It must be longer than RequestTimeout, otherwise a slow endpoint gets duplicate calls.
+	DeliveryLease time.Duration `yaml:"delivery_lease" validate:"gtfield=RequestTimeout"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:   2 * time.Second,
+		BatchSize:      50,
+		MaxAttempts:    8,
+		BaseBackoff:    5 * time.Second,
+		MaxBackoff:     time.Hour,
+		RequestTimeout: 10 * time.Second,
+		DeliveryLease:  time.Minute,
+	}
+}
+
+// Envelope is the JSON body posted to webhook endpoints.
+type Envelope struct {
+	Id        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Dispatcher moves outbox events to webhook endpoints. Each cycle fans new events out
+// into one delivery per matching subscription, then attempts every delivery that is due.
+// Several replicas can run a dispatcher against the same database; rows are claimed
+// with SKIP LOCKED so each delivery is attempted by one of them at a time.
+type Dispatcher struct {
+	outboxRepository  repository.OutboxRepository
+	webhookRepository repository.WebhookRepository
+	transactor        repository.Transactor
+	httpClient        *http.Client
+	config            Config
+	now               func() time.Time
+	stop              chan struct{}
+	stopOnce          sync.Once
+	stopped           chan struct{}
+}
+
+func NewDispatcher(outboxRepository repository.OutboxRepository, webhookRepository repository.WebhookRepository, transactor repository.Transactor, config Config) *Dispatcher {
+	return &Dispatcher{
+		outboxRepository:  outboxRepository,
+		webhookRepository: webhookRepository,
+		transactor:        transactor,
+		httpClient:        &http.Client{Timeout: config.RequestTimeout},
+		config:            config,
+		now:               time.Now,
+		stop:              make(chan struct{}),
+		stopped:           make(chan struct{}),
+	}
+}
+
+// Run dispatches until Shutdown is called or ctx is cancelled. Cancelling ctx also aborts in-flight webhook calls.
+func (d *Dispatcher) Run(ctx context.Context) {
+	defer close(d.stopped)
+	logger.Info("outbox dispatcher started.")
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchOnce(ctx); err != nil {
+			logger.Errorf("outbox dispatch cycle failed: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("outbox dispatcher stopped.")
+			return
+		case <-d.stop:
+			logger.Info("outbox dispatcher drained.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown stops Run from claiming more deliveries and waits for the current ones to finish, or for ctx to end.
+// It must only be called once Run has been started.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+	select {
+	case <-d.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) stopping() bool {
+	select {
+	case <-d.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *Dispatcher) DispatchOnce(ctx context.Context) error {
+	if err := d.fanOut(ctx); err != nil {
+		return fmt.Errorf("fan out events: %w", err)
+	}
+	if err := d.deliverDue(ctx); err != nil {
+		return fmt.Errorf("deliver webhooks: %w", err)
+	}
+	return nil
+}
+
+func (d *Dispatcher) fanOut(ctx context.Context) error {
+	return d.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		events, err := d.outboxRepository.ListUndispatched(txCtx, d.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if _, err := d.webhookRepository.CreateDeliveries(txCtx, event.Id, event.EventType); err != nil {
+				return err
+			}
+			if err := d.outboxRepository.MarkDispatched(txCtx, event.Id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *Dispatcher) deliverDue(ctx context.Context) error {
+	if d.stopping() {
+		return nil
+	}
+	deliveries, err := d.webhookRepository.ClaimDueDeliveries(ctx, d.config.DeliveryLease, d.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil || d.stopping() {
+			// Unattempted deliveries become due again once their lease expires.
+			return nil
+		}
+		d.deliver(ctx, delivery)
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery domain.PendingDelivery) {
+	statusCode, err := d.post(ctx, delivery)
+	if err == nil {
+		if markErr := d.webhookRepository.MarkDeliverySucceeded(ctx, delivery.Id, statusCode); markErr != nil {
+			logger.Errorf("error while record delivery %d success: %s", delivery.Id, markErr.Error())
+		}
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	failure := domain.DeliveryFailureParam{
+		Id:             delivery.Id,
+		Status:         domain.DeliveryStatusPending,
+		LastStatusCode: statusCode,
+		LastError:      err.Error(),
+		NextAttemptAt:  d.now().Add(d.backoff(attempts)),
+	}
+	if attempts >= d.config.MaxAttempts {
+		failure.Status = domain.DeliveryStatusDead
+		logger.Errorf("webhook delivery %d to subscription %d moved to dead letter after %d attempts: %s", delivery.Id, delivery.SubscriptionId, attempts, err.Error())
+	} else {
+		logger.Warnf("webhook delivery %d to subscription %d failed, attempt %d: %s", delivery.Id, delivery.SubscriptionId, attempts, err.Error())
+	}
+
+	if markErr := d.webhookRepository.MarkDeliveryFailed(ctx, failure); markErr != nil {
+		logger.Errorf("error while record delivery %d failure: %s", delivery.Id, markErr.Error())
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery domain.PendingDelivery) (int32, error) {
+	body, err := json.Marshal(Envelope{
+		Id:        delivery.EventId,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.EventCreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(constants.WebhookEventIdHeader, fmt.Sprintf("%d", delivery.EventId))
+	request.Header.Set(constants.WebhookEventTypeHeader, delivery.EventType)
+	request.Header.Set(constants.WebhookSignatureHeader, Sign(delivery.Secret, d.now(), body))
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	statusCode := int32(response.StatusCode)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodyBytes))
+		return statusCode, fmt.Errorf("unexpected status code %d: %s", response.StatusCode, responseBody)
+	}
+	return statusCode, nil
+}
+
+// backoff doubles the wait after every failed attempt: BaseBackoff, 2*BaseBackoff, ... up to MaxBackoff.
+func (d *Dispatcher) backoff(attempts int32) time.Duration {
+	wait := d.config.BaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		wait *= 2
+		if wait >= d.config.MaxBackoff {
+			return d.config.MaxBackoff
+		}
+	}
+	return wait
+}
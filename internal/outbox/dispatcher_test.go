@@ -0,0 +1,171 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DispatcherTestSuite struct {
+	suite.Suite
+	context               context.Context
+	mockController        *gomock.Controller
+	mockOutboxRepository  *mocks.MockOutboxRepository
+	mockWebhookRepository *mocks.MockWebhookRepository
+	mockTransactor        *mocks.MockTransactor
+	dispatcher            *Dispatcher
+	now                   time.Time
+}
+
+func TestDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
+
+func (suite *DispatcherTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockOutboxRepository = mocks.NewMockOutboxRepository(suite.mockController)
+	suite.mockWebhookRepository = mocks.NewMockWebhookRepository(suite.mockController)
+	suite.mockTransactor = mocks.NewMockTransactor(suite.mockController)
+	suite.mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	suite.dispatcher = NewDispatcher(suite.mockOutboxRepository, suite.mockWebhookRepository, suite.mockTransactor, DefaultConfig())
+	suite.now = time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	suite.dispatcher.now = func() time.Time { return suite.now }
+}
+
+func (suite *DispatcherTestSuite) TestDispatchOnce_FansOut_And_Delivers_Signed_Event() {
+	var receivedBody []byte
+	var receivedSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		receivedSignature = r.Header.Get("X-CC-Signature")
+		suite.Equal(domain.EventTransactionCreated, r.Header.Get("X-CC-Event-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event := domain.OutboxEvent{Id: 3, EventType: domain.EventTransactionCreated}
+	delivery := domain.PendingDelivery{
+		Id:        11,
+		EventId:   3,
+		EventType: domain.EventTransactionCreated,
+		Payload:   []byte(`{"transaction_id":3}`),
+		Url:       server.URL,
+		Secret:    "whsec_test",
+	}
+
+	suite.mockOutboxRepository.EXPECT().ListUndispatched(suite.context, int32(50)).Return([]domain.OutboxEvent{event}, nil)
+	suite.mockWebhookRepository.EXPECT().CreateDeliveries(suite.context, int64(3), domain.EventTransactionCreated).Return(int64(1), nil)
+	suite.mockOutboxRepository.EXPECT().MarkDispatched(suite.context, int64(3)).Return(nil)
+	suite.mockWebhookRepository.EXPECT().ClaimDueDeliveries(suite.context, time.Minute, int32(50)).Return([]domain.PendingDelivery{delivery}, nil)
+	suite.mockWebhookRepository.EXPECT().MarkDeliverySucceeded(suite.context, int64(11), int32(http.StatusNoContent)).Return(nil)
+
+	err := suite.dispatcher.DispatchOnce(suite.context)
+
+	suite.NoError(err)
+	suite.NoError(Verify("whsec_test", receivedSignature, receivedBody, time.Minute, suite.now))
+
+	var envelope Envelope
+	suite.NoError(json.Unmarshal(receivedBody, &envelope))
+	suite.Equal(int64(3), envelope.Id)
+	suite.JSONEq(`{"transaction_id":3}`, string(envelope.Data))
+}
+
+func (suite *DispatcherTestSuite) TestDeliver_When_Endpoint_Fails_Schedules_Retry_With_Backoff() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	delivery := domain.PendingDelivery{Id: 11, Attempts: 2, Url: server.URL, Payload: []byte(`{}`)}
+
+	suite.mockWebhookRepository.EXPECT().MarkDeliveryFailed(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, failure domain.DeliveryFailureParam) error {
+			suite.Equal(domain.DeliveryStatusPending, failure.Status)
+			suite.Equal(int32(http.StatusServiceUnavailable), failure.LastStatusCode)
+			suite.Equal(suite.now.Add(20*time.Second), failure.NextAttemptAt)
+			return nil
+		})
+
+	suite.dispatcher.deliver(suite.context, delivery)
+}
+
+func (suite *DispatcherTestSuite) TestDeliver_When_Attempts_Exhausted_Moves_To_DeadLetter() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	delivery := domain.PendingDelivery{Id: 11, Attempts: 7, Url: server.URL, Payload: []byte(`{}`)}
+
+	suite.mockWebhookRepository.EXPECT().MarkDeliveryFailed(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, failure domain.DeliveryFailureParam) error {
+			suite.Equal(domain.DeliveryStatusDead, failure.Status)
+			return nil
+		})
+
+	suite.dispatcher.deliver(suite.context, delivery)
+}
+
+func (suite *DispatcherTestSuite) TestShutdown_Waits_For_InFlight_Delivery_And_Stops_Claiming() {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	deliveries := []domain.PendingDelivery{
+		{Id: 11, Url: server.URL, Payload: []byte(`{}`)},
+		{Id: 12, Url: server.URL, Payload: []byte(`{}`)},
+	}
+	suite.mockOutboxRepository.EXPECT().ListUndispatched(suite.context, int32(50)).Return(nil, nil)
+	suite.mockWebhookRepository.EXPECT().ClaimDueDeliveries(suite.context, time.Minute, int32(50)).Return(deliveries, nil)
+	suite.mockWebhookRepository.EXPECT().MarkDeliverySucceeded(suite.context, int64(11), int32(http.StatusOK)).Return(nil)
+
+	go suite.dispatcher.Run(suite.context)
+	<-received
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- suite.dispatcher.Shutdown(context.Background()) }()
+	select {
+	case <-shutdownErr:
+		suite.Fail("shutdown returned before the in-flight delivery finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+
+	suite.NoError(<-shutdownErr)
+}
+
+func (suite *DispatcherTestSuite) TestShutdown_Returns_When_Deadline_Passes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := suite.dispatcher.Shutdown(ctx)
+
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *DispatcherTestSuite) TestBackoff_Is_Capped() {
+	suite.Equal(5*time.Second, suite.dispatcher.backoff(1))
+	suite.Equal(40*time.Second, suite.dispatcher.backoff(4))
+	suite.Equal(time.Hour, suite.dispatcher.backoff(20))
+}
+
+func (suite *DispatcherTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSignatureHeader = errors.New("invalid signature header")
+	ErrSignatureMismatch      = errors.New("signature does not match payload")
+	ErrSignatureExpired       = errors.New("signature timestamp outside of tolerance")
+)
+
+// Sign returns the value of the X-CC-Signature header for body. The signed content is
+// "<unix timestamp>.<body>", so receivers can reject replayed deliveries by their age.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", unix, computeSignature(secret, unix, body))
+}
+
+// Verify checks a X-CC-Signature header against body. It is the receiving side of Sign
+// and is what webhook consumers are expected to implement.
+func Verify(secret string, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var unix int64
+	var signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return ErrInvalidSignatureHeader
+		}
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrInvalidSignatureHeader
+			}
+			unix = parsed
+		case "v1":
+			signature = value
+		}
+	}
+	if unix == 0 || signature == "" {
+		return ErrInvalidSignatureHeader
+	}
+
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)).Abs() > tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := computeSignature(secret, unix, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+func computeSignature(secret string, unix int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(unix, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
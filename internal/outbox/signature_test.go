@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign_Verify_RoundTrip(t *testing.T) {
+	now := time.Unix(1767261600, 0)
+	body := []byte(`{"id":1}`)
+
+	header := Sign("whsec_test", now, body)
+
+	assert.Equal(t, "t=1767261600,v1=", header[:16])
+	assert.NoError(t, Verify("whsec_test", header, body, 5*time.Minute, now.Add(time.Minute)))
+}
+
+func TestVerify_Rejects_Tampered_Body(t *testing.T) {
+	now := time.Unix(1767261600, 0)
+	header := Sign("whsec_test", now, []byte(`{"id":1}`))
+
+	assert.ErrorIs(t, Verify("whsec_test", header, []byte(`{"id":2}`), 0, now), ErrSignatureMismatch)
+	assert.ErrorIs(t, Verify("whsec_other", header, []byte(`{"id":1}`), 0, now), ErrSignatureMismatch)
+}
+
+func TestVerify_Rejects_Old_Timestamp(t *testing.T) {
+	now := time.Unix(1767261600, 0)
+	header := Sign("whsec_test", now, []byte(`{}`))
+
+	assert.ErrorIs(t, Verify("whsec_test", header, []byte(`{}`), 5*time.Minute, now.Add(time.Hour)), ErrSignatureExpired)
+}
+
+func TestVerify_Rejects_Malformed_Header(t *testing.T) {
+	assert.ErrorIs(t, Verify("whsec_test", "v1=abc", []byte(`{}`), 0, time.Now()), ErrInvalidSignatureHeader)
+	assert.ErrorIs(t, Verify("whsec_test", "garbage", []byte(`{}`), 0, time.Now()), ErrInvalidSignatureHeader)
+}
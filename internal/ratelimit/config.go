@@ -0,0 +1,30 @@
+package ratelimit
+
+// Config holds the limits of each route group. Every limit applies per key: client IP, principal
+// or target account.
+type Config struct {
+	PerClientIP    Limit `yaml:"per_client_ip"`
+	AccountReads   Limit `yaml:"account_reads"`
+	AccountWrites  Limit `yaml:"account_writes"`
+	Transactions   Limit `yaml:"transactions"`
+	PerAccountTxns Limit `yaml:"per_account_transactions"`
+	// TransactionBatches limits POST /transactions:batch, whose items also count against Transactions, and
+	// whose accounts against PerAccountTxns once each.
+	TransactionBatches Limit `yaml:"transaction_batches"`
+	// TransactionExports limits GET /accounts/{accountId}/transactions/export, which streams a whole history.
+	TransactionExports Limit `yaml:"transaction_exports"`
+	Admin              Limit `yaml:"admin"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PerClientIP:        Limit{Rate: 50, Burst: 100},
+		AccountReads:       Limit{Rate: 20, Burst: 40},
+		AccountWrites:      Limit{Rate: 5, Burst: 10},
+		Transactions:       Limit{Rate: 50, Burst: 100},
+		PerAccountTxns:     Limit{Rate: 2, Burst: 10},
+		TransactionBatches: Limit{Rate: 1, Burst: 5},
+		TransactionExports: Limit{Rate: 1, Burst: 5},
+		Admin:              Limit{Rate: 5, Burst: 10},
+	}
+}
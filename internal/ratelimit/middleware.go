@@ -0,0 +1,207 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// KeyFunc picks the bucket of a request, false skips the rule for this request.
+type KeyFunc func(ctx *gin.Context) (string, bool)
+
+// CostFunc picks the buckets of a request that carries several operations, with the tokens to take from each. No
+// bucket skips the rule for this request.
+type CostFunc func(ctx *gin.Context) map[string]int
+
+type Rule struct {
+	// Name separates the buckets of rules that share a key function.
+	Name  string
+	Limit Limit
+	// Key takes one token from a single bucket, Cost replaces it for requests that take more.
+	Key  KeyFunc
+	Cost CostFunc
+}
+
+// costs returns the tokens the request takes from each bucket of the rule, in a stable order of keys.
+func (r Rule) costs(ctx *gin.Context) (keys []string, tokens map[string]int) {
+	if r.Cost != nil {
+		tokens = r.Cost(ctx)
+	} else if key, ok := r.Key(ctx); ok {
+		tokens = map[string]int{key: 1}
+	}
+	for key := range tokens {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, tokens
+}
+
+// MaxBodyBytes bounds the request bodies the key and cost functions read, it holds the largest batch
+// transaction_batch.max_items allows. A larger body is rejected with 413 before any token is taken.
+const MaxBodyBytes = 1 << 20
+
+// Middleware takes a token from the bucket of every rule, or the tokens of its Cost, and rejects the request with
+// 429 when one holds too few. The headers describe the most restrictive bucket. Store failures let the request
+// through.
+func Middleware(store Store, rules ...Rule) gin.HandlerFunc {
+	return middleware(store, time.Now, rules)
+}
+
+func middleware(store Store, now func() time.Time, rules []Rule) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var tightest *Result
+	rules:
+		for _, rule := range rules {
+			keys, tokens := rule.costs(ctx)
+			if ctx.IsAborted() {
+				return
+			}
+			for _, key := range keys {
+				result, err := store.Take(ctx.Request.Context(), rule.Name+":"+key, rule.Limit, tokens[key], now())
+				if err != nil {
+					logging.FromContext(ctx).Errorf("error while take rate limit token for %s: %s", rule.Name, err.Error())
+					continue
+				}
+				if tightest == nil || !result.Allowed || (tightest.Allowed && result.Remaining < tightest.Remaining) {
+					tightest = &result
+				}
+				if !result.Allowed {
+					logging.FromContext(ctx).Infof("rate limit %s exceeded by %s", rule.Name, key)
+					break rules
+				}
+			}
+		}
+		if tightest == nil {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header(constants.RateLimitLimitHeader, strconv.Itoa(tightest.Limit))
+		ctx.Header(constants.RateLimitRemainingHeader, strconv.Itoa(tightest.Remaining))
+		ctx.Header(constants.RateLimitResetHeader, strconv.Itoa(ceilSeconds(tightest.ResetAfter)))
+		if !tightest.Allowed {
+			ctx.Header(constants.RetryAfterHeader, strconv.Itoa(ceilSeconds(tightest.RetryAfter)))
+			utils.AbortWithError(ctx, &models.CCError{
+				ErrorCode:    domain.ErrRateLimited.Code,
+				ErrorMessage: domain.ErrRateLimited.Message,
+				StatusCode:   http.StatusTooManyRequests,
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func ByClientIP(ctx *gin.Context) (string, bool) {
+	return ctx.ClientIP(), true
+}
+
+// ByPrincipal keys on the authenticated api key or end user.
+func ByPrincipal(ctx *gin.Context) (string, bool) {
+	principal, ok := auth.PrincipalFromContext(ctx.Request.Context())
+	if !ok {
+		return "", false
+	}
+	return principal.Subject, true
+}
+
+// ByAccount keys on the target account, taken from the accountId path param or the account_id of a JSON body.
+func ByAccount(ctx *gin.Context) (string, bool) {
+	if accountId := ctx.Param(constants.AccountIdPathParam); accountId != "" {
+		return accountId, true
+	}
+	body, ok := peekBody(ctx)
+	if !ok {
+		return "", false
+	}
+	var target accountTarget
+	if json.Unmarshal(body, &target) != nil || target.AccountId == 0 {
+		return "", false
+	}
+	return strconv.FormatInt(target.AccountId, 10), true
+}
+
+// PerBatchItem takes one token per item of a transaction batch body from the bucket of key, a body it cannot read
+// counts as one item.
+func PerBatchItem(key KeyFunc) CostFunc {
+	return func(ctx *gin.Context) map[string]int {
+		bucket, ok := key(ctx)
+		if !ok {
+			return nil
+		}
+		items, ok := batchItems(ctx)
+		if !ok || len(items) == 0 {
+			return map[string]int{bucket: 1}
+		}
+		return map[string]int{bucket: len(items)}
+	}
+}
+
+// ByBatchAccount takes one token from the bucket of every account_id of a transaction batch body, however many of
+// its items target the account: a batch is one request to each of them, and the per-account burst does not cap it.
+func ByBatchAccount(ctx *gin.Context) map[string]int {
+	items, _ := batchItems(ctx)
+	tokens := map[string]int{}
+	for _, item := range items {
+		if item.AccountId != 0 {
+			tokens[strconv.FormatInt(item.AccountId, 10)] = 1
+		}
+	}
+	return tokens
+}
+
+type accountTarget struct {
+	AccountId int64 `json:"account_id"`
+}
+
+// batchItems reads the items of a transaction batch body, leaving the body for the handler.
+func batchItems(ctx *gin.Context) ([]accountTarget, bool) {
+	body, ok := peekBody(ctx)
+	if !ok {
+		return nil, false
+	}
+	var batch struct {
+		Items []accountTarget `json:"items"`
+	}
+	if json.Unmarshal(body, &batch) != nil {
+		return nil, false
+	}
+	return batch.Items, true
+}
+
+// peekBody reads the request body, up to MaxBodyBytes, and puts it back for the handler. A larger body aborts the
+// request with 413.
+func peekBody(ctx *gin.Context) ([]byte, bool) {
+	if ctx.Request.Body == nil {
+		return nil, false
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MaxBodyBytes))
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		utils.AbortWithError(ctx, &models.CCError{
+			ErrorCode:    domain.ErrRequestTooLarge.Code,
+			ErrorMessage: domain.ErrRequestTooLarge.Message,
+			StatusCode:   http.StatusRequestEntityTooLarge,
+		})
+	}
+	return body, err == nil
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
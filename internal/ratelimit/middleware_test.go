@@ -0,0 +1,153 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/pkg/constants"
+	"github.com/gin-gonic/gin"
+	logger "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit, int, time.Time) (Result, error) {
+	return Result{}, errors.New("store unavailable")
+}
+
+func TestMiddleware_Returns_429_With_Headers(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	router := gin.New()
+	router.Use(middleware(NewMemoryStore(), func() time.Time { return now }, []Rule{
+		{Name: "ip", Limit: Limit{Rate: 0.5, Burst: 1}, Key: ByClientIP},
+	}))
+	router.GET("/resource", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	allowed := httptest.NewRecorder()
+	router.ServeHTTP(allowed, httptest.NewRequest(http.MethodGet, "/resource", nil))
+	limited := httptest.NewRecorder()
+	router.ServeHTTP(limited, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+	assert.Equal(t, http.StatusOK, allowed.Code)
+	assert.Equal(t, "0", allowed.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "2", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "1", limited.Header().Get("X-RateLimit-Limit"))
+	assert.JSONEq(t, `{"error_code":"ERR_CC_RATE_LIMITED","error_message":"too many requests, retry later.","status_code":429}`, limited.Body.String())
+}
+
+func TestMiddleware_Logs_With_Request_Id(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(logging.Middleware(), Middleware(NewMemoryStore(), Rule{Name: "ip", Limit: Limit{Rate: 0.5, Burst: 1}, Key: ByClientIP}))
+	router.GET("/resource", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	for _, requestId := range []string{"req-1", "req-2"} {
+		req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+		req.Header.Set(constants.RequestIdHeader, requestId)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var exceeded []*logger.Entry
+	for _, entry := range hook.AllEntries() {
+		if strings.HasPrefix(entry.Message, "rate limit ip exceeded") {
+			exceeded = append(exceeded, entry)
+		}
+	}
+	assert.Len(t, exceeded, 1)
+	assert.Equal(t, "req-2", exceeded[0].Data[logging.RequestIdField])
+}
+
+func TestMiddleware_ByAccount_Keeps_Body_For_Handler(t *testing.T) {
+	router := gin.New()
+	router.Use(Middleware(NewMemoryStore(), Rule{Name: "account", Limit: Limit{Rate: 1, Burst: 1}, Key: ByAccount}))
+	router.POST("/transactions", func(ctx *gin.Context) {
+		body, _ := io.ReadAll(ctx.Request.Body)
+		ctx.String(http.StatusCreated, string(body))
+	})
+	post := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(body)))
+		return recorder
+	}
+
+	first := post(`{"account_id":1,"amount":10}`)
+	otherAccount := post(`{"account_id":2,"amount":10}`)
+	limited := post(`{"account_id":1,"amount":10}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, `{"account_id":1,"amount":10}`, first.Body.String())
+	assert.Equal(t, http.StatusCreated, otherAccount.Code)
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+}
+
+func TestMiddleware_Rejects_Oversized_Body_Without_Taking_Tokens(t *testing.T) {
+	router := gin.New()
+	router.Use(Middleware(NewMemoryStore(), Rule{Name: "account", Limit: Limit{Rate: 1, Burst: 1}, Key: ByAccount}))
+	router.POST("/transactions", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+	post := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(body)))
+		return recorder
+	}
+
+	oversized := post(`{"account_id":1,"description":"` + strings.Repeat("x", MaxBodyBytes) + `"}`)
+	allowed := post(`{"account_id":1,"amount":10}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, oversized.Code)
+	assert.JSONEq(t, `{"error_code":"ERR_CC_REQUEST_TOO_LARGE","error_message":"request body is too large.","status_code":413}`, oversized.Body.String())
+	assert.Empty(t, oversized.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, http.StatusCreated, allowed.Code)
+}
+
+func TestMiddleware_Lets_Requests_Through_When_Store_Fails(t *testing.T) {
+	router := gin.New()
+	router.Use(Middleware(failingStore{}, Rule{Name: "ip", Limit: Limit{Rate: 1, Burst: 1}, Key: ByClientIP}))
+	router.GET("/resource", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestMiddleware_Batch_Takes_A_Token_Per_Item_And_Per_Account(t *testing.T) {
+	router := gin.New()
+	router.Use(Middleware(NewMemoryStore(),
+		Rule{Name: "transactions", Limit: Limit{Rate: 1, Burst: 6}, Cost: PerBatchItem(ByClientIP)},
+		Rule{Name: "account-transactions", Limit: Limit{Rate: 1, Burst: 1}, Cost: ByBatchAccount},
+	))
+	router.POST("/transactions:batch", func(ctx *gin.Context) {
+		body, _ := io.ReadAll(ctx.Request.Body)
+		ctx.String(http.StatusOK, string(body))
+	})
+	post := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions:batch", bytes.NewBufferString(body)))
+		return recorder
+	}
+
+	first := post(`{"items":[{"account_id":1},{"account_id":1},{"account_id":1},{"account_id":2}]}`)
+	sameAccount := post(`{"items":[{"account_id":1}]}`)
+	otherAccount := post(`{"items":[{"account_id":3}]}`)
+	overPrincipal := post(`{"items":[{"account_id":4}]}`)
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, `{"items":[{"account_id":1},{"account_id":1},{"account_id":1},{"account_id":2}]}`, first.Body.String())
+	assert.Equal(t, http.StatusTooManyRequests, sameAccount.Code)
+	assert.Equal(t, http.StatusOK, otherAccount.Code)
+	assert.Equal(t, "0", otherAccount.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, http.StatusTooManyRequests, overPrincipal.Code)
+}
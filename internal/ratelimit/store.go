@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket refilled at Rate tokens per second and holding at most Burst tokens.
+type Limit struct {
+	Rate  float64 `yaml:"rate" validate:"gt=0"`
+	Burst int     `yaml:"burst" validate:"gte=1"`
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until the next token, zero when the request was allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store keeps the buckets. The in-memory store limits a single instance, a shared store
+// (e.g. Redis) can implement the same interface to limit a fleet.
+type Store interface {
+	// Take removes tokens from the bucket of key, or none when it holds fewer.
+	Take(ctx context.Context, key string, limit Limit, tokens int, now time.Time) (Result, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+type MemoryStore struct {
+	mu            sync.Mutex
+	buckets       map[string]*bucket
+	sweepInterval time.Duration
+	lastSweep     time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, sweepInterval: time.Minute}
+}
+
+func (ms *MemoryStore) Take(_ context.Context, key string, limit Limit, tokens int, now time.Time) (Result, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.sweep(now)
+
+	b, ok := ms.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: float64(limit.Burst), updated: now, limit: limit}
+		ms.buckets[key] = b
+	}
+	b.refill(now)
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= float64(tokens) {
+		b.tokens -= float64(tokens)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = limit.duration(float64(tokens) - b.tokens)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.ResetAfter = limit.duration(float64(limit.Burst) - b.tokens)
+	return result, nil
+}
+
+// sweep drops buckets that refilled completely, they are indistinguishable from new ones.
+func (ms *MemoryStore) sweep(now time.Time) {
+	if now.Sub(ms.lastSweep) < ms.sweepInterval {
+		return
+	}
+	ms.lastSweep = now
+	for key, b := range ms.buckets {
+		if now.Sub(b.updated) >= b.limit.duration(float64(b.limit.Burst)-b.tokens) {
+			delete(ms.buckets, key)
+		}
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.updated = now
+	}
+}
+
+func (l Limit) duration(tokens float64) time.Duration {
+	if tokens <= 0 || l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / l.Rate * float64(time.Second))
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_Take_Refills_Over_Time(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Rate: 1, Burst: 2}
+	now := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	first, _ := store.Take(context.TODO(), "key", limit, 1, now)
+	second, _ := store.Take(context.TODO(), "key", limit, 1, now)
+	third, _ := store.Take(context.TODO(), "key", limit, 1, now)
+	refilled, _ := store.Take(context.TODO(), "key", limit, 1, now.Add(time.Second))
+
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+	assert.False(t, third.Allowed)
+	assert.Equal(t, time.Second, third.RetryAfter)
+	assert.Equal(t, 2*time.Second, third.ResetAfter)
+	assert.True(t, refilled.Allowed)
+}
+
+func TestMemoryStore_Take_Several_Tokens(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Rate: 2, Burst: 10}
+	now := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	first, _ := store.Take(context.TODO(), "key", limit, 7, now)
+	limited, _ := store.Take(context.TODO(), "key", limit, 4, now)
+	rest, _ := store.Take(context.TODO(), "key", limit, 3, now)
+
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 3, first.Remaining)
+	assert.False(t, limited.Allowed)
+	assert.Equal(t, 500*time.Millisecond, limited.RetryAfter)
+	assert.True(t, rest.Allowed)
+	assert.Equal(t, 0, rest.Remaining)
+}
+
+func TestMemoryStore_Keys_Are_Independent(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Rate: 1, Burst: 1}
+	now := time.Now()
+
+	first, _ := store.Take(context.TODO(), "a", limit, 1, now)
+	other, _ := store.Take(context.TODO(), "b", limit, 1, now)
+
+	assert.True(t, first.Allowed)
+	assert.True(t, other.Allowed)
+}
+
+func TestMemoryStore_Sweeps_Full_Buckets(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Rate: 1, Burst: 1}
+	now := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	_, _ = store.Take(context.TODO(), "idle", limit, 1, now)
+	_, _ = store.Take(context.TODO(), "active", limit, 1, now.Add(2*time.Minute))
+
+	assert.Len(t, store.buckets, 1)
+	assert.Contains(t, store.buckets, "active")
+}
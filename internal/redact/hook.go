@@ -0,0 +1,42 @@
+package redact
+
+import (
+	"fmt"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+// Hook masks personal data in every log entry before it is formatted: the message, fields named after
+// a sensitive key, string and error fields, and struct fields tagged with Tag.
+type Hook struct{}
+
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+func (h *Hook) Levels() []logger.Level {
+	return logger.AllLevels
+}
+
+// Fire rewrites the entry in place. logrus hands hooks a copy of the entry, so the fields of the logger
+// it was derived from are left untouched.
+func (h *Hook) Fire(entry *logger.Entry) error {
+	entry.Message = Text(entry.Message)
+	for key, value := range entry.Data {
+		entry.Data[key] = field(key, value)
+	}
+	return nil
+}
+
+func field(key string, value any) any {
+	if IsSensitiveKey(key) {
+		return Mask(fmt.Sprint(value))
+	}
+	switch typed := value.(type) {
+	case string:
+		return Text(typed)
+	case error:
+		return Text(typed.Error())
+	}
+	return Value(value)
+}
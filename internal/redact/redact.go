@@ -0,0 +1,199 @@
+// Package redact masks personal data, such as document numbers and card numbers (PANs), before it
+// reaches the logs or a response.
+package redact
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// Tag marks a sensitive string field: `redact:"last4"` keeps the last four characters,
+	// `redact:"full"` replaces the whole value.
+	Tag = "redact"
+
+	TagLast4 = "last4"
+	TagFull  = "full"
+
+	Redacted = "[REDACTED]"
+
+	visibleSuffix = 4
+)
+
+// sensitiveKeys are field and parameter names whose value is always masked, whatever the format.
+var sensitiveKeys = []string{"document_number", "documentnumber", "pan", "card_number", "cardnumber"}
+
+var (
+	// A PAN has 13 to 19 digits, optionally grouped by spaces or dashes. Candidates are confirmed by Luhn.
+	panPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// key=value, key: value and "key":"value" pairs of a sensitive key.
+	keyValuePattern = regexp.MustCompile(`(?i)\b(document_?number|pan|card_?number)("?\s*[:=]\s*"?)([^\s",}]+)`)
+	// PostgreSQL error details quote the offending row, e.g. Key (document_number)=(0987654321).
+	pgKeyPattern = regexp.MustCompile(`(Key \([^)]*\)=\()[^)]*(\))`)
+	// PostgreSQL input errors quote the rejected value, e.g. invalid input syntax for type bigint: "abc".
+	pgInputPattern = regexp.MustCompile(`(invalid input (?:syntax|value) for [^:]+: ")[^"]*(")`)
+)
+
+// Mask keeps the last four characters of value and replaces the others with '*'.
+func Mask(value string) string {
+	if len(value) <= visibleSuffix {
+		return strings.Repeat("*", len(value))
+	}
+	return strings.Repeat("*", len(value)-visibleSuffix) + value[len(value)-visibleSuffix:]
+}
+
+// IsSensitiveKey reports whether a log field or parameter named key always holds personal data.
+func IsSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+	for _, sensitiveKey := range sensitiveKeys {
+		if normalized == sensitiveKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Text masks the PANs, sensitive key-value pairs and quoted database values found in free text.
+func Text(text string) string {
+	text = keyValuePattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := keyValuePattern.FindStringSubmatch(match)
+		return groups[1] + groups[2] + Mask(groups[3])
+	})
+	text = panPattern.ReplaceAllStringFunc(text, func(match string) string {
+		digits := stripSeparators(match)
+		if !IsPAN(digits) {
+			return match
+		}
+		return Mask(digits)
+	})
+	text = pgKeyPattern.ReplaceAllString(text, "${1}"+Redacted+"${2}")
+	return pgInputPattern.ReplaceAllString(text, "${1}"+Redacted+"${2}")
+}
+
+// IsPAN reports whether digits looks like a card number: 13 to 19 digits passing the Luhn check.
+func IsPAN(digits string) bool {
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Value returns a copy of v in which every string field tagged with Tag is masked. Structs, pointers,
+// slices, arrays and maps are walked; v itself is never modified.
+func Value(v any) any {
+	if v == nil {
+		return nil
+	}
+	value := reflect.ValueOf(v)
+	if !hasTaggedField(value.Type(), map[reflect.Type]bool{}) {
+		return v
+	}
+	return redactValue(value).Interface()
+}
+
+func redactValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(redactValue(value.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if tag, ok := field.Tag.Lookup(Tag); ok && field.Type.Kind() == reflect.String {
+				copied.Field(i).SetString(maskTagged(tag, value.Field(i).String()))
+				continue
+			}
+			copied.Field(i).Set(redactValue(value.Field(i)))
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(redactValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(redactValue(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), redactValue(iter.Value()))
+		}
+		return copied
+	}
+	return value
+}
+
+func maskTagged(tag, value string) string {
+	if value == "" {
+		return value
+	}
+	if tag == TagFull {
+		return Redacted
+	}
+	return Mask(value)
+}
+
+// hasTaggedField saves the copy of values that carry no sensitive field, which is most of them.
+func hasTaggedField(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return hasTaggedField(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := field.Tag.Lookup(Tag); ok || hasTaggedField(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stripSeparators(value string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
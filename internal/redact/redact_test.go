@@ -0,0 +1,87 @@
+package redact
+
+import (
+	"errors"
+	"testing"
+
+	logger "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type holder struct {
+	Name           string
+	DocumentNumber string `redact:"last4"`
+	Secret         string `redact:"full"`
+}
+
+type statement struct {
+	Holder  *holder
+	Holders []holder
+	Total   float64
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "******6789", Mask("0123456789"))
+	assert.Equal(t, "***", Mask("123"))
+	assert.Equal(t, "", Mask(""))
+}
+
+func TestText(t *testing.T) {
+	cases := map[string]string{
+		"Started to create account with documentNumber: 0123456789": "Started to create account with documentNumber: ******6789",
+		`{"document_number":"0123456789"}`:                          `{"document_number":"******6789"}`,
+		"card 4111 1111 1111 1111 declined":                         "card ************1111 declined",
+		"card 4111111111111112 is not a PAN":                        "card 4111111111111112 is not a PAN",
+		"Key (document_number)=(0123456789) already exists.":        "Key (document_number)=([REDACTED]) already exists.",
+		`invalid input syntax for type bigint: "0123456789"`:        `invalid input syntax for type bigint: "[REDACTED]"`,
+		"error while fetch account by id:12, error: no rows":        "error while fetch account by id:12, error: no rows",
+	}
+	for text, expected := range cases {
+		assert.Equal(t, expected, Text(text), text)
+	}
+}
+
+func TestValue_Masks_Tagged_Fields_Of_A_Copy(t *testing.T) {
+	original := statement{
+		Holder:  &holder{Name: "Ana", DocumentNumber: "0123456789", Secret: "s3cr3t"},
+		Holders: []holder{{Name: "Bia", DocumentNumber: "9876543210"}},
+		Total:   10,
+	}
+
+	redacted := Value(original).(statement)
+
+	assert.Equal(t, statement{
+		Holder:  &holder{Name: "Ana", DocumentNumber: "******6789", Secret: Redacted},
+		Holders: []holder{{Name: "Bia", DocumentNumber: "******3210"}},
+		Total:   10,
+	}, redacted)
+	assert.Equal(t, "0123456789", original.Holder.DocumentNumber)
+	assert.Equal(t, "9876543210", original.Holders[0].DocumentNumber)
+}
+
+func TestValue_Returns_Untagged_Values_As_Is(t *testing.T) {
+	value := struct{ Name string }{Name: "Ana"}
+
+	assert.Equal(t, value, Value(value))
+	assert.Nil(t, Value(nil))
+}
+
+func TestHook_Masks_Message_And_Fields(t *testing.T) {
+	entry := logger.NewEntry(logger.New()).WithFields(logger.Fields{
+		"document_number": "0123456789",
+		"holder":          holder{Name: "Ana", DocumentNumber: "0123456789"},
+		"error":           errors.New("Key (document_number)=(0123456789) already exists."),
+		"account_id":      int64(1),
+	})
+	entry.Message = "charged 4111111111111111"
+
+	assert.NoError(t, NewHook().Fire(entry))
+
+	assert.Equal(t, "charged ************1111", entry.Message)
+	assert.Equal(t, logger.Fields{
+		"document_number": "******6789",
+		"holder":          holder{Name: "Ana", DocumentNumber: "******6789"},
+		"error":           "Key (document_number)=([REDACTED]) already exists.",
+		"account_id":      int64(1),
+	}, entry.Data)
+}
@@ -0,0 +1,55 @@
+// Package redacttest captures the lines logged during a test and fails it when they leak personal data.
+package redacttest
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/redact"
+	logger "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+var digitRun = regexp.MustCompile(`\d(?:[ -]?\d){12,18}`)
+
+// Capture installs the redaction hook on the global logger, as main does, and records every entry logged
+// until the test ends. The test then fails if a line still contains one of pii, or a card number.
+// The global logger is shared, so tests using Capture must not run in parallel.
+func Capture(t testing.TB, pii ...string) *test.Hook {
+	t.Helper()
+	standardLogger := logger.StandardLogger()
+	previousHooks := standardLogger.ReplaceHooks(make(logger.LevelHooks))
+	hook := &test.Hook{}
+	standardLogger.AddHook(redact.NewHook())
+	standardLogger.AddHook(hook)
+
+	t.Cleanup(func() {
+		standardLogger.ReplaceHooks(previousHooks)
+		for _, entry := range hook.AllEntries() {
+			line, err := entry.String()
+			if err != nil {
+				t.Errorf("failed to format log entry %q: %s", entry.Message, err.Error())
+				continue
+			}
+			if leak := findLeak(line, pii); leak != "" {
+				t.Errorf("log line leaks personal data %q: %s", leak, line)
+			}
+		}
+	})
+	return hook
+}
+
+func findLeak(line string, pii []string) string {
+	for _, value := range pii {
+		if value != "" && strings.Contains(line, value) {
+			return value
+		}
+	}
+	for _, candidate := range digitRun.FindAllString(line, -1) {
+		if redact.IsPAN(strings.NewReplacer(" ", "", "-", "").Replace(candidate)) {
+			return candidate
+		}
+	}
+	return ""
+}
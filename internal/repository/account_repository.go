@@ -4,53 +4,136 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/logging"
 	"github.com/credit-card-api/internal/repository/sqlc"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	logger "github.com/sirupsen/logrus"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type AccountRepository interface {
 	Create(ctx context.Context, accountParam domain.CreateAccountParam) (domainAccount *domain.Account, err error)
 	GetById(ctx context.Context, id int64) (domainAccount *domain.Account, err error)
+	// LockById must run inside a transaction. It returns the account like GetById and blocks the writers of its
+	// transactions that lock it too until commit.
+	LockById(ctx context.Context, id int64) (domainAccount *domain.Account, err error)
+	// Block stamps blocked_at, an already blocked account keeps its original timestamp.
+	Block(ctx context.Context, id int64) (domainAccount *domain.Account, err error)
+	// UpdateProfile replaces the profile and bumps the version, only while the account is still at the version of
+	// the param. An account at another version returns ErrAccountVersionMismatch.
+	UpdateProfile(ctx context.Context, profileParam domain.UpdateAccountProfileParam) (domainAccount *domain.Account, err error)
 }
 
+// accountRepository stores document numbers encrypted with the keyring, next to a blind index that keeps
+// them unique. The holder profile is sealed with the same keyring.
 type accountRepository struct {
 	querier sqlc.Querier
+	keyring *encryption.Keyring
 }
 
-func NewAccountRepository(querier sqlc.Querier) AccountRepository {
-	return &accountRepository{querier: querier}
+func NewAccountRepository(querier sqlc.Querier, keyring *encryption.Keyring) AccountRepository {
+	return &accountRepository{querier: querier, keyring: keyring}
 }
 
 func (ar *accountRepository) Create(ctx context.Context, accountParam domain.CreateAccountParam) (domainAccount *domain.Account, err error) {
-	account, err := ar.getQuerier(ctx).CreateAccount(ctx, accountParam.DocumentNumber)
+	keyId, ciphertext, err := ar.keyring.Encrypt(accountParam.DocumentNumber)
 	if err != nil {
-		logger.Error("error while create an account: ", err.Error())
+		logging.FromContext(ctx).Error("error while encrypt document number: ", err.Error())
+		return nil, err
+	}
+	account, err := ar.getQuerier(ctx).CreateAccount(ctx, sqlc.CreateAccountParams{
+		DocumentNumber:      ciphertext,
+		DocumentKeyID:       keyId,
+		DocumentNumberIndex: ar.keyring.BlindIndex(accountParam.DocumentNumber),
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("error while create an account: ", err.Error())
 		if isUniqueViolation(err) {
 			return nil, domain.ErrAccountAlreadyExist
 		}
 		return nil, err
 	}
-	logger.Info("account created successfully in db.")
-	return mapToDomainAccount(account), err
+	logging.FromContext(ctx).Info("account created successfully in db.")
+	return ar.mapToDomainAccount(account)
 }
 
 func (ar *accountRepository) GetById(ctx context.Context, id int64) (domainAccount *domain.Account, err error) {
 	account, err := ar.getQuerier(ctx).GetAccountByID(ctx, id)
 	if err != nil {
-		logger.Errorf("error while fetch account by id:%d, error: %s", id, err.Error())
+		logging.FromContext(ctx).Errorf("error while fetch account by id:%d, error: %s", id, err.Error())
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrAccountNotFound
 		}
 		return nil, err
 	}
-	logger.Info("account fetched successfully from db.")
-	return mapToDomainAccount(account), err
+	logging.FromContext(ctx).Info("account fetched successfully from db.")
+	return ar.mapToDomainAccount(account)
+}
+
+func (ar *accountRepository) LockById(ctx context.Context, id int64) (domainAccount *domain.Account, err error) {
+	account, err := ar.getQuerier(ctx).GetAccountForUpdate(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while lock account by id:%d, error: %s", id, err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return ar.mapToDomainAccount(account)
+}
+
+func (ar *accountRepository) Block(ctx context.Context, id int64) (domainAccount *domain.Account, err error) {
+	account, err := ar.getQuerier(ctx).BlockAccount(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while block account by id:%d, error: %s", id, err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("account blocked successfully in db.")
+	return ar.mapToDomainAccount(account)
+}
+
+func (ar *accountRepository) UpdateProfile(ctx context.Context, profileParam domain.UpdateAccountProfileParam) (domainAccount *domain.Account, err error) {
+	keyId, ciphertext, err := SealProfile(ar.keyring, profileParam.Profile)
+	if err != nil {
+		logging.FromContext(ctx).Error("error while encrypt account profile: ", err.Error())
+		return nil, err
+	}
+	params := sqlc.UpdateAccountProfileParams{
+		AccountID:    profileParam.Id,
+		Version:      profileParam.Version,
+		Profile:      ciphertext,
+		ProfileKeyID: pgtype.Text{String: keyId, Valid: ciphertext != nil},
+	}
+
+	account, err := ar.getQuerier(ctx).UpdateAccountProfile(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while update profile of account by id:%d, error: %s", profileParam.Id, err.Error())
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		// No row matched the id and version, tell a missing account from a stale version.
+		if _, getErr := ar.getQuerier(ctx).GetAccountByID(ctx, profileParam.Id); getErr != nil {
+			if errors.Is(getErr, pgx.ErrNoRows) {
+				return nil, domain.ErrAccountNotFound
+			}
+			return nil, getErr
+		}
+		return nil, domain.ErrAccountVersionMismatch
+	}
+	logging.FromContext(ctx).Info("account profile updated successfully in db.")
+	return ar.mapToDomainAccount(account)
 }
 
 func isUniqueViolation(err error) bool {
@@ -61,12 +144,108 @@ func isUniqueViolation(err error) bool {
 	return false
 }
 
-func mapToDomainAccount(account sqlc.Account) *domain.Account {
-	return &domain.Account{
+func (ar *accountRepository) mapToDomainAccount(account sqlc.Account) (*domain.Account, error) {
+	documentNumber, err := ar.keyring.Decrypt(account.DocumentKeyID, account.DocumentNumber)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt document number of account %d: %w", account.AccountID, err)
+	}
+	profile, err := OpenProfile(ar.keyring, account.ProfileKeyID.String, account.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt profile of account %d: %w", account.AccountID, err)
+	}
+	domainAccount := &domain.Account{
 		Id:             account.AccountID,
-		DocumentNumber: account.DocumentNumber,
+		DocumentNumber: documentNumber,
 		CreatedAt:      account.CreatedAt.Time,
+		Profile:        profile,
+		Version:        account.Version,
+		UpdatedAt:      account.UpdatedAt.Time,
+	}
+	if account.BlockedAt.Valid {
+		blockedAt := account.BlockedAt.Time
+		domainAccount.BlockedAt = &blockedAt
+	}
+	return domainAccount, nil
+}
+
+// sealedProfile is the JSON document of a profile before it is sealed, empty fields are left out.
+type sealedProfile struct {
+	FullName  string         `json:"full_name,omitempty"`
+	Email     string         `json:"email,omitempty"`
+	Phone     string         `json:"phone,omitempty"`
+	Address   *sealedAddress `json:"address,omitempty"`
+	BirthDate string         `json:"birth_date,omitempty"`
+}
+
+type sealedAddress struct {
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// SealProfile encrypts profile as a single JSON document with the primary key of keyring. An empty profile is
+// stored as NULL, it returns no ciphertext.
+func SealProfile(keyring *encryption.Keyring, profile domain.AccountProfile) (keyId string, ciphertext []byte, err error) {
+	if reflect.DeepEqual(profile, domain.AccountProfile{}) {
+		return "", nil, nil
+	}
+	document := sealedProfile{FullName: profile.FullName, Email: profile.Email, Phone: profile.Phone}
+	if address := profile.Address; address != nil {
+		document.Address = &sealedAddress{
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		}
+	}
+	if profile.BirthDate != nil {
+		document.BirthDate = profile.BirthDate.Format(time.DateOnly)
+	}
+	plaintext, err := json.Marshal(document)
+	if err != nil {
+		return "", nil, err
+	}
+	return keyring.Encrypt(string(plaintext))
+}
+
+// OpenProfile decrypts a profile sealed by SealProfile, no ciphertext is the empty profile.
+func OpenProfile(keyring *encryption.Keyring, keyId string, ciphertext []byte) (domain.AccountProfile, error) {
+	var profile domain.AccountProfile
+	if ciphertext == nil {
+		return profile, nil
+	}
+	plaintext, err := keyring.Decrypt(keyId, ciphertext)
+	if err != nil {
+		return profile, err
+	}
+	var document sealedProfile
+	if err := json.Unmarshal([]byte(plaintext), &document); err != nil {
+		return profile, err
+	}
+	profile = domain.AccountProfile{FullName: document.FullName, Email: document.Email, Phone: document.Phone}
+	if address := document.Address; address != nil {
+		profile.Address = &domain.PostalAddress{
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		}
+	}
+	if document.BirthDate != "" {
+		birthDate, err := time.Parse(time.DateOnly, document.BirthDate)
+		if err != nil {
+			return profile, err
+		}
+		profile.BirthDate = &birthDate
 	}
+	return profile, nil
 }
 
 // Helper to switch between Pool and Transaction
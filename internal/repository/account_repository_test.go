@@ -1,14 +1,19 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/redact/redacttest"
 	"github.com/credit-card-api/internal/repository/mocks"
 	"github.com/credit-card-api/internal/repository/sqlc"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -23,6 +28,7 @@ type AccountRepositoryTestSuite struct {
 	context           context.Context
 	mockController    *gomock.Controller
 	mockQuerier       *mocks.MockQuerier
+	keyring           *encryption.Keyring
 	accountRepository AccountRepository
 }
 
@@ -34,28 +40,31 @@ func (suite *AccountRepositoryTestSuite) SetupTest() {
 	suite.context = context.TODO()
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
-	suite.accountRepository = NewAccountRepository(suite.mockQuerier)
+	suite.keyring = newTestKeyring(suite.T(), "2026-10")
+	suite.accountRepository = NewAccountRepository(suite.mockQuerier, suite.keyring)
 	accountId = 1
 	documentNumber = "0123456789"
 }
 
 func (suite *AccountRepositoryTestSuite) TestAccountRepository_Create_Success() {
-	expectedRow := sqlc.Account{
-		AccountID:      1,
-		DocumentNumber: documentNumber,
-	}
-
-	suite.mockQuerier.EXPECT().CreateAccount(suite.context, documentNumber).Return(expectedRow, nil)
+	suite.mockQuerier.EXPECT().CreateAccount(suite.context, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.CreateAccountParams) (sqlc.Account, error) {
+		suite.Equal("2026-10", params.DocumentKeyID)
+		suite.NotContains(string(params.DocumentNumber), documentNumber)
+		suite.Equal(suite.keyring.BlindIndex(documentNumber), params.DocumentNumberIndex)
+		return sqlc.Account{AccountID: 1, DocumentNumber: params.DocumentNumber, DocumentKeyID: params.DocumentKeyID}, nil
+	})
 
 	response, err := suite.accountRepository.Create(suite.context, domain.CreateAccountParam{DocumentNumber: documentNumber})
 
 	suite.NoError(err)
 	suite.Equal(int64(1), response.Id)
+	suite.Equal(documentNumber, response.DocumentNumber)
 }
 
 func (suite *AccountRepositoryTestSuite) TestAccountRepository_Create_Returns_ConflictError() {
-	pgErr := &pgconn.PgError{Code: "23505"}
-	suite.mockQuerier.EXPECT().CreateAccount(suite.context, documentNumber).Return(sqlc.Account{}, pgErr)
+	redacttest.Capture(suite.T(), documentNumber)
+	pgErr := &pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint", Detail: "Key (document_number)=(" + documentNumber + ") already exists."}
+	suite.mockQuerier.EXPECT().CreateAccount(suite.context, gomock.Any()).Return(sqlc.Account{}, pgErr)
 
 	res, err := suite.accountRepository.Create(suite.context, domain.CreateAccountParam{DocumentNumber: documentNumber})
 
@@ -64,12 +73,24 @@ func (suite *AccountRepositoryTestSuite) TestAccountRepository_Create_Returns_Co
 }
 
 func (suite *AccountRepositoryTestSuite) TestAccountRepository_GetById_Success() {
-	suite.mockQuerier.EXPECT().GetAccountByID(suite.context, int64(1)).Return(sqlc.Account{AccountID: 1}, nil)
+	keyId, ciphertext, _ := suite.keyring.Encrypt(documentNumber)
+	suite.mockQuerier.EXPECT().GetAccountByID(suite.context, int64(1)).Return(sqlc.Account{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId}, nil)
 
 	res, err := suite.accountRepository.GetById(suite.context, accountId)
 
 	suite.NoError(err)
 	suite.Equal(int64(1), res.Id)
+	suite.Equal(documentNumber, res.DocumentNumber)
+}
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_GetById_When_Key_Is_Not_In_Keyring() {
+	_, ciphertext, _ := suite.keyring.Encrypt(documentNumber)
+	suite.mockQuerier.EXPECT().GetAccountByID(suite.context, int64(1)).Return(sqlc.Account{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: "2024-01"}, nil)
+
+	res, err := suite.accountRepository.GetById(suite.context, accountId)
+
+	suite.Nil(res)
+	suite.ErrorIs(err, encryption.ErrUnknownKey)
 }
 
 func (suite *AccountRepositoryTestSuite) TestAccountRepository_GetById_Account_Not_Found() {
@@ -80,3 +101,95 @@ func (suite *AccountRepositoryTestSuite) TestAccountRepository_GetById_Account_N
 	suite.Nil(res)
 	suite.ErrorIs(err, domain.ErrAccountNotFound)
 }
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_Block_Success() {
+	keyId, ciphertext, _ := suite.keyring.Encrypt(documentNumber)
+	blockedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockQuerier.EXPECT().BlockAccount(suite.context, int64(1)).Return(sqlc.Account{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId, BlockedAt: pgtype.Timestamptz{Time: blockedAt, Valid: true}}, nil)
+
+	res, err := suite.accountRepository.Block(suite.context, accountId)
+
+	suite.NoError(err)
+	suite.Equal(&blockedAt, res.BlockedAt)
+}
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_Block_Account_Not_Found() {
+	suite.mockQuerier.EXPECT().BlockAccount(suite.context, int64(404)).Return(sqlc.Account{}, pgx.ErrNoRows)
+
+	res, err := suite.accountRepository.Block(suite.context, 404)
+
+	suite.Nil(res)
+	suite.ErrorIs(err, domain.ErrAccountNotFound)
+}
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_UpdateProfile_Success() {
+	keyId, ciphertext, _ := suite.keyring.Encrypt(documentNumber)
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	profile := domain.AccountProfile{
+		FullName:  "Maria da Silva",
+		Address:   &domain.PostalAddress{Line1: "Av. Paulista, 1000", City: "São Paulo", State: "SP", PostalCode: "01310-100", Country: "BR"},
+		BirthDate: &birthDate,
+	}
+	suite.mockQuerier.EXPECT().UpdateAccountProfile(suite.context, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.UpdateAccountProfileParams) (sqlc.Account, error) {
+		suite.Equal(int32(3), params.Version)
+		suite.Equal(pgtype.Text{String: "2026-10", Valid: true}, params.ProfileKeyID)
+		suite.NotContains(string(params.Profile), "Maria")
+		return sqlc.Account{
+			AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId,
+			Profile: params.Profile, ProfileKeyID: params.ProfileKeyID, Version: 4,
+		}, nil
+	})
+
+	res, err := suite.accountRepository.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: accountId, Version: 3, Profile: profile})
+
+	suite.NoError(err)
+	suite.Equal(profile, res.Profile)
+	suite.Equal(int32(4), res.Version)
+}
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_UpdateProfile_Empty_Profile_Is_Null() {
+	keyId, ciphertext, _ := suite.keyring.Encrypt(documentNumber)
+	suite.mockQuerier.EXPECT().UpdateAccountProfile(suite.context, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.UpdateAccountProfileParams) (sqlc.Account, error) {
+		suite.Nil(params.Profile)
+		suite.False(params.ProfileKeyID.Valid)
+		return sqlc.Account{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId, Version: 4}, nil
+	})
+
+	res, err := suite.accountRepository.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: accountId, Version: 3})
+
+	suite.NoError(err)
+	suite.Equal(domain.AccountProfile{}, res.Profile)
+}
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_UpdateProfile_Version_Mismatch() {
+	keyId, ciphertext, _ := suite.keyring.Encrypt(documentNumber)
+	suite.mockQuerier.EXPECT().UpdateAccountProfile(suite.context, gomock.Any()).Return(sqlc.Account{}, pgx.ErrNoRows)
+	suite.mockQuerier.EXPECT().GetAccountByID(suite.context, int64(1)).Return(sqlc.Account{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId, Version: 4}, nil)
+
+	res, err := suite.accountRepository.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: accountId, Version: 3})
+
+	suite.Nil(res)
+	suite.ErrorIs(err, domain.ErrAccountVersionMismatch)
+}
+
+func (suite *AccountRepositoryTestSuite) TestAccountRepository_UpdateProfile_Account_Not_Found() {
+	suite.mockQuerier.EXPECT().UpdateAccountProfile(suite.context, gomock.Any()).Return(sqlc.Account{}, pgx.ErrNoRows)
+	suite.mockQuerier.EXPECT().GetAccountByID(suite.context, int64(404)).Return(sqlc.Account{}, pgx.ErrNoRows)
+
+	res, err := suite.accountRepository.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: 404, Version: 1})
+
+	suite.Nil(res)
+	suite.ErrorIs(err, domain.ErrAccountNotFound)
+}
+
+// newTestKeyring holds the keys 2026-09 and 2026-10, each made of a single repeated byte.
+func newTestKeyring(t *testing.T, primaryKeyId string) *encryption.Keyring {
+	keyring, err := encryption.NewKeyring(primaryKeyId, map[string][]byte{
+		"2026-09": bytes.Repeat([]byte{2}, 32),
+		"2026-10": bytes.Repeat([]byte{3}, 32),
+	}, bytes.Repeat([]byte{9}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
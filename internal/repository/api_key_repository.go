@@ -0,0 +1,111 @@
+package repository
+
+//go:generate mockgen -source=api_key_repository.go -destination=mocks/mock_api_key_repository.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ApiKeyRepository interface {
+	Create(ctx context.Context, apiKeyParam domain.CreateApiKeyParam) (*domain.ApiKey, error)
+	GetActiveByHash(ctx context.Context, hash string) (*domain.ApiKey, error)
+	List(ctx context.Context) ([]domain.ApiKey, error)
+	Revoke(ctx context.Context, id int64) (*domain.ApiKey, error)
+}
+
+type apiKeyRepository struct {
+	querier sqlc.Querier
+}
+
+func NewApiKeyRepository(querier sqlc.Querier) ApiKeyRepository {
+	return &apiKeyRepository{querier: querier}
+}
+
+func (ar *apiKeyRepository) Create(ctx context.Context, apiKeyParam domain.CreateApiKeyParam) (*domain.ApiKey, error) {
+	params := sqlc.CreateApiKeyParams{
+		Name:      apiKeyParam.Name,
+		KeyPrefix: apiKeyParam.Prefix,
+		KeyHash:   apiKeyParam.Hash,
+		Scopes:    apiKeyParam.Scopes,
+	}
+	if apiKeyParam.RotatedFrom != nil {
+		params.RotatedFrom = pgtype.Int8{Int64: *apiKeyParam.RotatedFrom, Valid: true}
+	}
+
+	apiKey, err := ar.getQuerier(ctx).CreateApiKey(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while create api key: %s", err.Error())
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("api key created successfully in db.")
+	return mapToDomainApiKey(apiKey), nil
+}
+
+func (ar *apiKeyRepository) GetActiveByHash(ctx context.Context, hash string) (*domain.ApiKey, error) {
+	apiKey, err := ar.getQuerier(ctx).GetActiveApiKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApiKeyNotFound
+		}
+		logging.FromContext(ctx).Errorf("error while fetch api key by hash: %s", err.Error())
+		return nil, err
+	}
+	return mapToDomainApiKey(apiKey), nil
+}
+
+func (ar *apiKeyRepository) List(ctx context.Context) ([]domain.ApiKey, error) {
+	var apiKeyList []domain.ApiKey
+	apiKeys, err := ar.getQuerier(ctx).ListApiKeys(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch api keys: %s", err.Error())
+		return nil, err
+	}
+	for _, apiKey := range apiKeys {
+		apiKeyList = append(apiKeyList, *mapToDomainApiKey(apiKey))
+	}
+	return apiKeyList, nil
+}
+
+// Revoke only matches active keys, revoking an unknown or already revoked key returns ErrApiKeyNotFound.
+func (ar *apiKeyRepository) Revoke(ctx context.Context, id int64) (*domain.ApiKey, error) {
+	apiKey, err := ar.getQuerier(ctx).RevokeApiKey(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while revoke api key by id:%d, error: %s", id, err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApiKeyNotFound
+		}
+		return nil, err
+	}
+	return mapToDomainApiKey(apiKey), nil
+}
+
+func mapToDomainApiKey(apiKey sqlc.ApiKey) *domain.ApiKey {
+	mapped := &domain.ApiKey{
+		Id:        apiKey.KeyID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.KeyPrefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt.Time,
+	}
+	if apiKey.RotatedFrom.Valid {
+		mapped.RotatedFrom = &apiKey.RotatedFrom.Int64
+	}
+	if apiKey.RevokedAt.Valid {
+		mapped.RevokedAt = &apiKey.RevokedAt.Time
+	}
+	return mapped
+}
+
+func (ar *apiKeyRepository) getQuerier(ctx context.Context) sqlc.Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return sqlc.New(tx)
+	}
+	return ar.querier
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ApiKeyRepositoryTestSuite struct {
+	suite.Suite
+	context          context.Context
+	mockController   *gomock.Controller
+	mockQuerier      *mocks.MockQuerier
+	apiKeyRepository ApiKeyRepository
+}
+
+func TestApiKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyRepositoryTestSuite))
+}
+
+func (suite *ApiKeyRepositoryTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
+	suite.apiKeyRepository = NewApiKeyRepository(suite.mockQuerier)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) TestApiKeyRepository_Create_With_RotatedFrom() {
+	rotatedFrom := int64(1)
+	params := sqlc.CreateApiKeyParams{
+		Name:        "billing-service",
+		KeyPrefix:   "cck_01234567",
+		KeyHash:     "hash",
+		Scopes:      []string{domain.ScopeAccountsRead},
+		RotatedFrom: pgtype.Int8{Int64: 1, Valid: true},
+	}
+	suite.mockQuerier.EXPECT().CreateApiKey(suite.context, params).
+		Return(sqlc.ApiKey{KeyID: 2, Name: params.Name, RotatedFrom: params.RotatedFrom}, nil)
+
+	res, err := suite.apiKeyRepository.Create(suite.context, domain.CreateApiKeyParam{
+		Name:        "billing-service",
+		Prefix:      "cck_01234567",
+		Hash:        "hash",
+		Scopes:      []string{domain.ScopeAccountsRead},
+		RotatedFrom: &rotatedFrom,
+	})
+
+	suite.NoError(err)
+	suite.Equal(int64(2), res.Id)
+	suite.Equal(int64(1), *res.RotatedFrom)
+	suite.Nil(res.RevokedAt)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) TestApiKeyRepository_Revoke_When_Not_Active() {
+	suite.mockQuerier.EXPECT().RevokeApiKey(suite.context, int64(1)).Return(sqlc.ApiKey{}, pgx.ErrNoRows)
+
+	res, err := suite.apiKeyRepository.Revoke(suite.context, 1)
+
+	suite.Nil(res)
+	suite.Equal(domain.ErrApiKeyNotFound, err)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) TestApiKeyRepository_GetActiveByHash_When_Unknown() {
+	suite.mockQuerier.EXPECT().GetActiveApiKeyByHash(suite.context, "hash").Return(sqlc.ApiKey{}, pgx.ErrNoRows)
+
+	res, err := suite.apiKeyRepository.GetActiveByHash(suite.context, "hash")
+
+	suite.Nil(res)
+	suite.Equal(domain.ErrApiKeyNotFound, err)
+}
+
+func (suite *ApiKeyRepositoryTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,125 @@
+package repository
+
+//go:generate mockgen -source=audit_repository.go -destination=mocks/mock_audit_repository.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// auditChainLockKey identifies the advisory lock that serializes appends to the hash chain.
+const auditChainLockKey int64 = 0x61756469745f6c67
+
+type AuditRepository interface {
+	// LockChainHead must run inside a transaction. It blocks concurrent appenders until commit
+	// and returns the hash of the latest entry, or an empty string for an empty log.
+	LockChainHead(ctx context.Context) (string, error)
+	Create(ctx context.Context, entry domain.AuditEntry) (*domain.AuditEntry, error)
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type auditRepository struct {
+	querier sqlc.Querier
+}
+
+func NewAuditRepository(querier sqlc.Querier) AuditRepository {
+	return &auditRepository{querier: querier}
+}
+
+func (ar *auditRepository) LockChainHead(ctx context.Context) (string, error) {
+	querier := ar.getQuerier(ctx)
+	if err := querier.AcquireAuditChainLock(ctx, auditChainLockKey); err != nil {
+		logging.FromContext(ctx).Errorf("error while acquire audit chain lock: %s", err.Error())
+		return "", err
+	}
+
+	hash, err := querier.GetLatestAuditHash(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		logging.FromContext(ctx).Errorf("error while fetch latest audit hash: %s", err.Error())
+		return "", err
+	}
+	return hash, nil
+}
+
+func (ar *auditRepository) Create(ctx context.Context, entry domain.AuditEntry) (*domain.AuditEntry, error) {
+	auditLog, err := ar.getQuerier(ctx).CreateAuditEntry(ctx, sqlc.CreateAuditEntryParams{
+		Actor:        entry.Actor,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceId,
+		BeforeState:  entry.Before,
+		AfterState:   entry.After,
+		Diff:         entry.Diff,
+		RequestID:    entry.RequestId,
+		SourceIp:     entry.SourceIp,
+		CreatedAt:    pgtype.Timestamptz{Time: entry.CreatedAt, Valid: true},
+		PrevHash:     entry.PrevHash,
+		Hash:         entry.Hash,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while create audit entry: %s", err.Error())
+		return nil, err
+	}
+	return mapToDomainAuditEntry(auditLog), nil
+}
+
+func (ar *auditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var entryList []domain.AuditEntry
+	params := sqlc.ListAuditEntriesParams{
+		ResourceType: pgtype.Text{String: filter.ResourceType, Valid: filter.ResourceType != ""},
+		ResourceID:   pgtype.Text{String: filter.ResourceId, Valid: filter.ResourceId != ""},
+		Actor:        pgtype.Text{String: filter.Actor, Valid: filter.Actor != ""},
+		AfterID:      filter.AfterId,
+		RowLimit:     filter.Limit,
+	}
+	if filter.From != nil {
+		params.FromTime = pgtype.Timestamptz{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		params.ToTime = pgtype.Timestamptz{Time: *filter.To, Valid: true}
+	}
+
+	auditLogs, err := ar.getQuerier(ctx).ListAuditEntries(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch audit entries: %s", err.Error())
+		return nil, err
+	}
+	for _, auditLog := range auditLogs {
+		entryList = append(entryList, *mapToDomainAuditEntry(auditLog))
+	}
+	return entryList, nil
+}
+
+func mapToDomainAuditEntry(auditLog sqlc.AuditLog) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		Id:           auditLog.AuditID,
+		Actor:        auditLog.Actor,
+		Action:       auditLog.Action,
+		ResourceType: auditLog.ResourceType,
+		ResourceId:   auditLog.ResourceID,
+		Before:       auditLog.BeforeState,
+		After:        auditLog.AfterState,
+		Diff:         auditLog.Diff,
+		RequestId:    auditLog.RequestID,
+		SourceIp:     auditLog.SourceIp,
+		CreatedAt:    auditLog.CreatedAt.Time,
+		PrevHash:     auditLog.PrevHash,
+		Hash:         auditLog.Hash,
+	}
+}
+
+func (ar *auditRepository) getQuerier(ctx context.Context) sqlc.Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return sqlc.New(tx)
+	}
+	return ar.querier
+}
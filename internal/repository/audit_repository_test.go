@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AuditRepositoryTestSuite struct {
+	suite.Suite
+	context         context.Context
+	mockController  *gomock.Controller
+	mockQuerier     *mocks.MockQuerier
+	auditRepository AuditRepository
+}
+
+func TestAuditRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepositoryTestSuite))
+}
+
+func (suite *AuditRepositoryTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
+	suite.auditRepository = NewAuditRepository(suite.mockQuerier)
+}
+
+func (suite *AuditRepositoryTestSuite) TestAuditRepository_LockChainHead_When_Log_Is_Empty() {
+	gomock.InOrder(
+		suite.mockQuerier.EXPECT().AcquireAuditChainLock(suite.context, auditChainLockKey).Return(nil),
+		suite.mockQuerier.EXPECT().GetLatestAuditHash(suite.context).Return("", pgx.ErrNoRows),
+	)
+
+	hash, err := suite.auditRepository.LockChainHead(suite.context)
+
+	suite.NoError(err)
+	suite.Empty(hash)
+}
+
+func (suite *AuditRepositoryTestSuite) TestAuditRepository_List_Maps_Filter() {
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	params := sqlc.ListAuditEntriesParams{
+		ResourceType: pgtype.Text{String: domain.AuditResourceAccount, Valid: true},
+		FromTime:     pgtype.Timestamptz{Time: from, Valid: true},
+		AfterID:      10,
+		RowLimit:     50,
+	}
+	suite.mockQuerier.EXPECT().ListAuditEntries(suite.context, params).Return([]sqlc.AuditLog{{AuditID: 11, ResourceType: domain.AuditResourceAccount}}, nil)
+
+	res, err := suite.auditRepository.List(suite.context, domain.AuditFilter{
+		ResourceType: domain.AuditResourceAccount,
+		From:         &from,
+		AfterId:      10,
+		Limit:        50,
+	})
+
+	suite.NoError(err)
+	suite.Len(res, 1)
+	suite.Equal(int64(11), res[0].Id)
+}
+
+func (suite *AuditRepositoryTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	logger "github.com/sirupsen/logrus"
+)
+
+// DocumentReencryptor re-encrypts with the primary key every account whose document number or profile is sealed
+// with an older key of the keyring, both are sealed again together. Rows are updated one by one, so an interrupted
+// run is simply started again, and only at the version they were read at, so a concurrent update is never lost.
+// Blind indexes do not depend on the encryption key and are left as they are.
+type DocumentReencryptor struct {
+	querier   sqlc.Querier
+	keyring   *encryption.Keyring
+	batchSize int32
+}
+
+func NewDocumentReencryptor(querier sqlc.Querier, keyring *encryption.Keyring, batchSize int32) *DocumentReencryptor {
+	return &DocumentReencryptor{querier: querier, keyring: keyring, batchSize: batchSize}
+}
+
+// Run returns the number of re-encrypted accounts.
+func (dr *DocumentReencryptor) Run(ctx context.Context) (int64, error) {
+	var reencrypted int64
+	var afterAccountId int64
+	for {
+		accounts, err := dr.querier.ListAccountsToReencrypt(ctx, sqlc.ListAccountsToReencryptParams{
+			PrimaryKeyID:   dr.keyring.PrimaryKeyId(),
+			AfterAccountID: afterAccountId,
+			BatchSize:      dr.batchSize,
+		})
+		if err != nil {
+			return reencrypted, err
+		}
+		if len(accounts) == 0 {
+			return reencrypted, nil
+		}
+		for _, account := range accounts {
+			if err := dr.reencrypt(ctx, account); err != nil {
+				return reencrypted, err
+			}
+			reencrypted++
+			afterAccountId = account.AccountID
+		}
+		logger.Infof("re-encrypted %d accounts up to account id: %d", reencrypted, afterAccountId)
+	}
+}
+
+// reencrypt reseals account, reading it again as long as an update committed since it was read.
+func (dr *DocumentReencryptor) reencrypt(ctx context.Context, account sqlc.Account) error {
+	accountId := account.AccountID
+	for {
+		resealed, err := dr.reseal(ctx, account)
+		if err != nil || resealed {
+			return err
+		}
+		logger.Infof("account %d changed while re-encrypted, reading it again.", accountId)
+		if account, err = dr.querier.GetAccountByID(ctx, accountId); err != nil {
+			return fmt.Errorf("read account %d: %w", accountId, err)
+		}
+	}
+}
+
+// reseal returns false when the account is no longer at the version it was read at.
+func (dr *DocumentReencryptor) reseal(ctx context.Context, account sqlc.Account) (bool, error) {
+	documentNumber, err := dr.keyring.Decrypt(account.DocumentKeyID, account.DocumentNumber)
+	if err != nil {
+		return false, fmt.Errorf("decrypt document number of account %d: %w", account.AccountID, err)
+	}
+	keyId, ciphertext, err := dr.keyring.Encrypt(documentNumber)
+	if err != nil {
+		return false, fmt.Errorf("encrypt document number of account %d: %w", account.AccountID, err)
+	}
+	profile, err := OpenProfile(dr.keyring, account.ProfileKeyID.String, account.Profile)
+	if err != nil {
+		return false, fmt.Errorf("decrypt profile of account %d: %w", account.AccountID, err)
+	}
+	profileKeyId, profileCiphertext, err := SealProfile(dr.keyring, profile)
+	if err != nil {
+		return false, fmt.Errorf("encrypt profile of account %d: %w", account.AccountID, err)
+	}
+	resealed, err := dr.querier.ResealAccount(ctx, sqlc.ResealAccountParams{
+		AccountID:      account.AccountID,
+		Version:        account.Version,
+		DocumentNumber: ciphertext,
+		DocumentKeyID:  keyId,
+		Profile:        profileCiphertext,
+		ProfileKeyID:   pgtype.Text{String: profileKeyId, Valid: profileCiphertext != nil},
+	})
+	return resealed == 1, err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDocumentReencryptor_Moves_Old_Keys_To_Primary_Key(t *testing.T) {
+	ctx := context.TODO()
+	mockQuerier := mocks.NewMockQuerier(gomock.NewController(t))
+	oldKeyring := newTestKeyring(t, "2026-09")
+	keyring := newTestKeyring(t, "2026-10")
+	keyId, ciphertext, _ := oldKeyring.Encrypt("0123456789")
+	secondKeyId, secondCiphertext, _ := oldKeyring.Encrypt("9876543210")
+
+	gomock.InOrder(
+		mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, sqlc.ListAccountsToReencryptParams{PrimaryKeyID: "2026-10", AfterAccountID: 0, BatchSize: 2}).
+			Return([]sqlc.Account{{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId}, {AccountID: 3, DocumentNumber: secondCiphertext, DocumentKeyID: secondKeyId}}, nil),
+		mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, sqlc.ListAccountsToReencryptParams{PrimaryKeyID: "2026-10", AfterAccountID: 3, BatchSize: 2}).
+			Return(nil, nil),
+	)
+	var updated []string
+	mockQuerier.EXPECT().ResealAccount(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.ResealAccountParams) (int64, error) {
+		assert.Equal(t, "2026-10", params.DocumentKeyID)
+		documentNumber, err := keyring.Decrypt(params.DocumentKeyID, params.DocumentNumber)
+		assert.NoError(t, err)
+		updated = append(updated, documentNumber)
+		assert.False(t, params.ProfileKeyID.Valid)
+		assert.Nil(t, params.Profile)
+		return 1, nil
+	}).Times(2)
+
+	reencrypted, err := NewDocumentReencryptor(mockQuerier, keyring, 2).Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), reencrypted)
+	assert.Equal(t, []string{"0123456789", "9876543210"}, updated)
+}
+
+func TestDocumentReencryptor_Stops_On_Update_Error(t *testing.T) {
+	ctx := context.TODO()
+	mockQuerier := mocks.NewMockQuerier(gomock.NewController(t))
+	keyring := newTestKeyring(t, "2026-10")
+	keyId, ciphertext, _ := newTestKeyring(t, "2026-09").Encrypt("0123456789")
+	expectedErr := errors.New("connection reset")
+
+	mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, gomock.Any()).
+		Return([]sqlc.Account{{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId}}, nil)
+	mockQuerier.EXPECT().ResealAccount(ctx, gomock.Any()).Return(int64(0), expectedErr)
+
+	reencrypted, err := NewDocumentReencryptor(mockQuerier, keyring, 100).Run(ctx)
+
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, int64(0), reencrypted)
+}
+
+func TestDocumentReencryptor_Reseals_Profile(t *testing.T) {
+	ctx := context.TODO()
+	mockQuerier := mocks.NewMockQuerier(gomock.NewController(t))
+	oldKeyring := newTestKeyring(t, "2026-09")
+	keyring := newTestKeyring(t, "2026-10")
+	keyId, ciphertext, _ := keyring.Encrypt("0123456789")
+	profile := domain.AccountProfile{FullName: "Maria da Silva", Email: "maria@example.com"}
+	profileKeyId, profileCiphertext, _ := SealProfile(oldKeyring, profile)
+
+	gomock.InOrder(
+		mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, gomock.Any()).
+			Return([]sqlc.Account{{AccountID: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId, Profile: profileCiphertext, ProfileKeyID: pgtype.Text{String: profileKeyId, Valid: true}}}, nil),
+		mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, gomock.Any()).Return(nil, nil),
+	)
+	mockQuerier.EXPECT().ResealAccount(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.ResealAccountParams) (int64, error) {
+		assert.Equal(t, pgtype.Text{String: "2026-10", Valid: true}, params.ProfileKeyID)
+		resealed, err := OpenProfile(keyring, params.ProfileKeyID.String, params.Profile)
+		assert.NoError(t, err)
+		assert.Equal(t, profile, resealed)
+		return 1, nil
+	})
+
+	reencrypted, err := NewDocumentReencryptor(mockQuerier, keyring, 100).Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), reencrypted)
+}
+
+func TestDocumentReencryptor_Reads_Again_Account_Updated_Meanwhile(t *testing.T) {
+	ctx := context.TODO()
+	mockQuerier := mocks.NewMockQuerier(gomock.NewController(t))
+	oldKeyring := newTestKeyring(t, "2026-09")
+	keyring := newTestKeyring(t, "2026-10")
+	keyId, ciphertext, _ := oldKeyring.Encrypt("0123456789")
+	staleKeyId, staleProfile, _ := SealProfile(oldKeyring, domain.AccountProfile{FullName: "Maria da Silva"})
+	patched := domain.AccountProfile{FullName: "Maria Souza"}
+	patchedKeyId, patchedProfile, _ := SealProfile(keyring, patched)
+	read := sqlc.Account{AccountID: 1, Version: 1, DocumentNumber: ciphertext, DocumentKeyID: keyId, Profile: staleProfile, ProfileKeyID: pgtype.Text{String: staleKeyId, Valid: true}}
+	reread := read
+	reread.Version, reread.Profile, reread.ProfileKeyID = 2, patchedProfile, pgtype.Text{String: patchedKeyId, Valid: true}
+
+	gomock.InOrder(
+		mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, gomock.Any()).Return([]sqlc.Account{read}, nil),
+		mockQuerier.EXPECT().ResealAccount(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.ResealAccountParams) (int64, error) {
+			assert.Equal(t, int32(1), params.Version)
+			return 0, nil
+		}),
+		mockQuerier.EXPECT().GetAccountByID(ctx, int64(1)).Return(reread, nil),
+		mockQuerier.EXPECT().ResealAccount(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, params sqlc.ResealAccountParams) (int64, error) {
+			assert.Equal(t, int32(2), params.Version)
+			resealed, err := OpenProfile(keyring, params.ProfileKeyID.String, params.Profile)
+			assert.NoError(t, err)
+			assert.Equal(t, patched, resealed)
+			return 1, nil
+		}),
+		mockQuerier.EXPECT().ListAccountsToReencrypt(ctx, gomock.Any()).Return(nil, nil),
+	)
+
+	reencrypted, err := NewDocumentReencryptor(mockQuerier, keyring, 100).Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), reencrypted)
+}
@@ -0,0 +1,56 @@
+package repository
+
+//go:generate mockgen -source=health_repository.go -destination=mocks/mock_health_repository.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+// Pinger is satisfied by *pgxpool.Pool.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	// GetSchemaVersion returns the latest applied migration, nil when none is recorded.
+	GetSchemaVersion(ctx context.Context) (*domain.SchemaVersion, error)
+	CountOperationTypes(ctx context.Context, ids []int64) (int64, error)
+}
+
+type healthRepository struct {
+	pinger  Pinger
+	querier sqlc.Querier
+}
+
+func NewHealthRepository(pinger Pinger, querier sqlc.Querier) HealthRepository {
+	return &healthRepository{pinger: pinger, querier: querier}
+}
+
+func (hr *healthRepository) Ping(ctx context.Context) error {
+	return hr.pinger.Ping(ctx)
+}
+
+func (hr *healthRepository) GetSchemaVersion(ctx context.Context) (*domain.SchemaVersion, error) {
+	version, err := hr.querier.GetSchemaVersion(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SchemaVersion{Version: version.Version, Dirty: version.Dirty}, nil
+}
+
+func (hr *healthRepository) CountOperationTypes(ctx context.Context, ids []int64) (int64, error) {
+	operationTypeIds := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		operationTypeIds = append(operationTypeIds, int32(id))
+	}
+	return hr.querier.CountOperationTypes(ctx, operationTypeIds)
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type pingFunc func(ctx context.Context) error
+
+func (f pingFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+type HealthRepositoryTestSuite struct {
+	suite.Suite
+	context          context.Context
+	mockController   *gomock.Controller
+	mockQuerier      *mocks.MockQuerier
+	pingErr          error
+	healthRepository HealthRepository
+}
+
+func TestHealthRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthRepositoryTestSuite))
+}
+
+func (suite *HealthRepositoryTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
+	suite.pingErr = nil
+	suite.healthRepository = NewHealthRepository(pingFunc(func(context.Context) error { return suite.pingErr }), suite.mockQuerier)
+}
+
+func (suite *HealthRepositoryTestSuite) TestHealthRepository_Ping() {
+	suite.pingErr = errors.New("connection refused")
+
+	suite.EqualError(suite.healthRepository.Ping(suite.context), "connection refused")
+}
+
+func (suite *HealthRepositoryTestSuite) TestHealthRepository_GetSchemaVersion() {
+	suite.mockQuerier.EXPECT().GetSchemaVersion(suite.context).Return(sqlc.SchemaMigration{Version: 1}, nil)
+
+	version, err := suite.healthRepository.GetSchemaVersion(suite.context)
+
+	suite.NoError(err)
+	suite.Equal(&domain.SchemaVersion{Version: 1}, version)
+}
+
+func (suite *HealthRepositoryTestSuite) TestHealthRepository_GetSchemaVersion_When_None_Applied() {
+	suite.mockQuerier.EXPECT().GetSchemaVersion(suite.context).Return(sqlc.SchemaMigration{}, pgx.ErrNoRows)
+
+	version, err := suite.healthRepository.GetSchemaVersion(suite.context)
+
+	suite.NoError(err)
+	suite.Nil(version)
+}
+
+func (suite *HealthRepositoryTestSuite) TestHealthRepository_CountOperationTypes() {
+	suite.mockQuerier.EXPECT().CountOperationTypes(suite.context, []int32{1, 2}).Return(int64(2), nil)
+
+	count, err := suite.healthRepository.CountOperationTypes(suite.context, []int64{1, 2})
+
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+}
+
+func (suite *HealthRepositoryTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
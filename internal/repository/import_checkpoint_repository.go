@@ -0,0 +1,98 @@
+package repository
+
+//go:generate mockgen -source=import_checkpoint_repository.go -destination=mocks/mock_import_checkpoint_repository.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+type ImportCheckpointRepository interface {
+	// Start returns the checkpoint of a file, creating it at line 0 on the first import of the file.
+	Start(ctx context.Context, fileHash string, fileName string) (*domain.ImportCheckpoint, error)
+	// Lock must run inside a transaction. It blocks concurrent imports of the same file until commit
+	// and returns the checkpoint as of the lock.
+	Lock(ctx context.Context, fileHash string) (*domain.ImportCheckpoint, error)
+	// Save must be called with the same context as the lines it records, so both commit or roll back together.
+	Save(ctx context.Context, checkpoint domain.ImportCheckpoint) error
+	// ClaimReference records that the line of a file posts reference of source. It returns false when the reference
+	// is already posted, by any file. It must run in the transaction that posts the line: a concurrent claim of
+	// the same reference waits for it to commit or roll back.
+	ClaimReference(ctx context.Context, source string, reference string, fileHash string, line int64) (bool, error)
+}
+
+type importCheckpointRepository struct {
+	querier sqlc.Querier
+}
+
+func NewImportCheckpointRepository(querier sqlc.Querier) ImportCheckpointRepository {
+	return &importCheckpointRepository{querier: querier}
+}
+
+func (ir *importCheckpointRepository) Start(ctx context.Context, fileHash string, fileName string) (*domain.ImportCheckpoint, error) {
+	checkpoint, err := ir.getQuerier(ctx).StartImportCheckpoint(ctx, sqlc.StartImportCheckpointParams{FileHash: fileHash, FileName: fileName})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while start import checkpoint of file %s: %s", fileName, err.Error())
+		return nil, err
+	}
+	return mapToDomainImportCheckpoint(checkpoint), nil
+}
+
+func (ir *importCheckpointRepository) Lock(ctx context.Context, fileHash string) (*domain.ImportCheckpoint, error) {
+	checkpoint, err := ir.getQuerier(ctx).LockImportCheckpoint(ctx, fileHash)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while lock import checkpoint %s: %s", fileHash, err.Error())
+		return nil, err
+	}
+	return mapToDomainImportCheckpoint(checkpoint), nil
+}
+
+func (ir *importCheckpointRepository) Save(ctx context.Context, checkpoint domain.ImportCheckpoint) error {
+	err := ir.getQuerier(ctx).UpdateImportCheckpoint(ctx, sqlc.UpdateImportCheckpointParams{
+		FileHash: checkpoint.FileHash,
+		Line:     checkpoint.Line,
+		Posted:   checkpoint.Posted,
+		Rejected: checkpoint.Rejected,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while save import checkpoint %s at line %d: %s", checkpoint.FileHash, checkpoint.Line, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (ir *importCheckpointRepository) ClaimReference(ctx context.Context, source string, reference string, fileHash string, line int64) (bool, error) {
+	claimed, err := ir.getQuerier(ctx).ClaimImportedReference(ctx, sqlc.ClaimImportedReferenceParams{
+		Source:    source,
+		Reference: reference,
+		FileHash:  fileHash,
+		Line:      line,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while claim reference %s of %s: %s", reference, source, err.Error())
+		return false, err
+	}
+	return claimed == 1, nil
+}
+
+func mapToDomainImportCheckpoint(checkpoint sqlc.ImportCheckpoint) *domain.ImportCheckpoint {
+	return &domain.ImportCheckpoint{
+		FileHash:  checkpoint.FileHash,
+		FileName:  checkpoint.FileName,
+		Line:      checkpoint.Line,
+		Posted:    checkpoint.Posted,
+		Rejected:  checkpoint.Rejected,
+		UpdatedAt: checkpoint.UpdatedAt.Time,
+	}
+}
+
+func (ir *importCheckpointRepository) getQuerier(ctx context.Context) sqlc.Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return sqlc.New(tx)
+	}
+	return ir.querier
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ImportCheckpointRepositoryTestSuite struct {
+	suite.Suite
+	context                    context.Context
+	mockController             *gomock.Controller
+	mockQuerier                *mocks.MockQuerier
+	importCheckpointRepository ImportCheckpointRepository
+}
+
+func TestImportCheckpointRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportCheckpointRepositoryTestSuite))
+}
+
+func (suite *ImportCheckpointRepositoryTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
+	suite.importCheckpointRepository = NewImportCheckpointRepository(suite.mockQuerier)
+}
+
+func (suite *ImportCheckpointRepositoryTestSuite) TestImportCheckpointRepository_Start() {
+	suite.mockQuerier.EXPECT().StartImportCheckpoint(suite.context, sqlc.StartImportCheckpointParams{FileHash: "hash", FileName: "clearing.csv"}).
+		Return(sqlc.ImportCheckpoint{FileHash: "hash", FileName: "clearing.csv", Line: 12, Posted: 10, Rejected: 1}, nil)
+
+	res, err := suite.importCheckpointRepository.Start(suite.context, "hash", "clearing.csv")
+
+	suite.NoError(err)
+	suite.Equal(domain.ImportCheckpoint{FileHash: "hash", FileName: "clearing.csv", Line: 12, Posted: 10, Rejected: 1}, *res)
+}
+
+func (suite *ImportCheckpointRepositoryTestSuite) TestImportCheckpointRepository_Save() {
+	suite.mockQuerier.EXPECT().UpdateImportCheckpoint(suite.context, sqlc.UpdateImportCheckpointParams{FileHash: "hash", Line: 13, Posted: 11, Rejected: 1}).
+		Return(nil)
+
+	err := suite.importCheckpointRepository.Save(suite.context, domain.ImportCheckpoint{FileHash: "hash", FileName: "clearing.csv", Line: 13, Posted: 11, Rejected: 1})
+
+	suite.NoError(err)
+}
+
+func (suite *ImportCheckpointRepositoryTestSuite) TestImportCheckpointRepository_Lock_Error() {
+	expectedErr := errors.New("connection reset")
+	suite.mockQuerier.EXPECT().LockImportCheckpoint(suite.context, "hash").Return(sqlc.ImportCheckpoint{}, expectedErr)
+
+	res, err := suite.importCheckpointRepository.Lock(suite.context, "hash")
+
+	suite.Nil(res)
+	suite.ErrorIs(err, expectedErr)
+}
+
+func (suite *ImportCheckpointRepositoryTestSuite) TestImportCheckpointRepository_ClaimReference() {
+	params := sqlc.ClaimImportedReferenceParams{Source: "network", Reference: "REF-1", FileHash: "hash", Line: 2}
+	suite.mockQuerier.EXPECT().ClaimImportedReference(suite.context, params).Return(int64(1), nil)
+	suite.mockQuerier.EXPECT().ClaimImportedReference(suite.context, params).Return(int64(0), nil)
+
+	claimed, err := suite.importCheckpointRepository.ClaimReference(suite.context, "network", "REF-1", "hash", 2)
+	again, againErr := suite.importCheckpointRepository.ClaimReference(suite.context, "network", "REF-1", "hash", 2)
+
+	suite.NoError(err)
+	suite.True(claimed)
+	suite.NoError(againErr)
+	suite.False(again)
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type accountRepository struct {
+	store *Store
+}
+
+func NewAccountRepository(store *Store) repository.AccountRepository {
+	return &accountRepository{store: store}
+}
+
+func (ar *accountRepository) Create(ctx context.Context, accountParam domain.CreateAccountParam) (*domain.Account, error) {
+	var account domain.Account
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		if _, exists := t.documentNumbers[accountParam.DocumentNumber]; exists {
+			return domain.ErrAccountAlreadyExist
+		}
+		now := ar.store.now()
+		account = domain.Account{
+			Id:             t.nextId("accounts"),
+			DocumentNumber: accountParam.DocumentNumber,
+			CreatedAt:      now,
+			Version:        1,
+			UpdatedAt:      now,
+		}
+		t.accounts[account.Id] = account
+		t.documentNumbers[account.DocumentNumber] = account.Id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (ar *accountRepository) GetById(ctx context.Context, id int64) (*domain.Account, error) {
+	var account domain.Account
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		account, exists = t.accounts[id]
+		if !exists {
+			return domain.ErrAccountNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.Profile = copyProfile(account.Profile)
+	return &account, nil
+}
+
+// LockById needs no lock of its own: the transaction it runs in already holds the store.
+func (ar *accountRepository) LockById(ctx context.Context, id int64) (*domain.Account, error) {
+	if current, ok := ctx.Value(txKey{}).(*tx); !ok || current.store != ar.store {
+		return nil, errors.New("memory: LockById must run inside a transaction")
+	}
+	return ar.GetById(ctx, id)
+}
+
+func (ar *accountRepository) Block(ctx context.Context, id int64) (*domain.Account, error) {
+	var account domain.Account
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		account, exists = t.accounts[id]
+		if !exists {
+			return domain.ErrAccountNotFound
+		}
+		if account.BlockedAt == nil {
+			blockedAt := ar.store.now()
+			account.BlockedAt = &blockedAt
+			account.Version++
+			account.UpdatedAt = blockedAt
+			t.accounts[id] = account
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.Profile = copyProfile(account.Profile)
+	return &account, nil
+}
+
+func (ar *accountRepository) UpdateProfile(ctx context.Context, profileParam domain.UpdateAccountProfileParam) (*domain.Account, error) {
+	var account domain.Account
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		account, exists = t.accounts[profileParam.Id]
+		if !exists {
+			return domain.ErrAccountNotFound
+		}
+		if account.Version != profileParam.Version {
+			return domain.ErrAccountVersionMismatch
+		}
+		account.Profile = copyProfile(profileParam.Profile)
+		account.Version++
+		account.UpdatedAt = ar.store.now()
+		t.accounts[account.Id] = account
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.Profile = copyProfile(account.Profile)
+	return &account, nil
+}
+
+// copyProfile keeps callers from modifying the stored address and birth date through the returned pointers.
+func copyProfile(profile domain.AccountProfile) domain.AccountProfile {
+	if profile.Address != nil {
+		address := *profile.Address
+		profile.Address = &address
+	}
+	if profile.BirthDate != nil {
+		birthDate := *profile.BirthDate
+		profile.BirthDate = &birthDate
+	}
+	return profile
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type apiKey struct {
+	domain.ApiKey
+	hash string
+}
+
+type apiKeyRepository struct {
+	store *Store
+}
+
+func NewApiKeyRepository(store *Store) repository.ApiKeyRepository {
+	return &apiKeyRepository{store: store}
+}
+
+func (ar *apiKeyRepository) Create(ctx context.Context, apiKeyParam domain.CreateApiKeyParam) (*domain.ApiKey, error) {
+	var created domain.ApiKey
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, existing := range t.apiKeys {
+			if existing.hash == apiKeyParam.Hash {
+				return fmt.Errorf("memory: api key hash %s already exists", apiKeyParam.Prefix)
+			}
+		}
+		if apiKeyParam.RotatedFrom != nil {
+			if _, exists := t.apiKeys[*apiKeyParam.RotatedFrom]; !exists {
+				return fmt.Errorf("memory: api key rotated from missing key %d", *apiKeyParam.RotatedFrom)
+			}
+		}
+		created = domain.ApiKey{
+			Id:          t.nextId("api_keys"),
+			Name:        apiKeyParam.Name,
+			Prefix:      apiKeyParam.Prefix,
+			Scopes:      slices.Clone(apiKeyParam.Scopes),
+			RotatedFrom: apiKeyParam.RotatedFrom,
+			CreatedAt:   ar.store.now(),
+		}
+		t.apiKeys[created.Id] = apiKey{ApiKey: created, hash: apiKeyParam.Hash}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyApiKey(created), nil
+}
+
+func (ar *apiKeyRepository) GetActiveByHash(ctx context.Context, hash string) (*domain.ApiKey, error) {
+	var found *domain.ApiKey
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, key := range t.apiKeys {
+			if key.hash == hash && key.RevokedAt == nil {
+				found = copyApiKey(key.ApiKey)
+				return nil
+			}
+		}
+		return domain.ErrApiKeyNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (ar *apiKeyRepository) List(ctx context.Context) ([]domain.ApiKey, error) {
+	var apiKeys []domain.ApiKey
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, id := range sortedKeys(t.apiKeys) {
+			apiKeys = append(apiKeys, *copyApiKey(t.apiKeys[id].ApiKey))
+		}
+		return nil
+	})
+	return apiKeys, err
+}
+
+// Revoke only matches active keys, revoking an unknown or already revoked key returns ErrApiKeyNotFound.
+func (ar *apiKeyRepository) Revoke(ctx context.Context, id int64) (*domain.ApiKey, error) {
+	var revoked domain.ApiKey
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		key, exists := t.apiKeys[id]
+		if !exists || key.RevokedAt != nil {
+			return domain.ErrApiKeyNotFound
+		}
+		revokedAt := ar.store.now()
+		key.RevokedAt = &revokedAt
+		t.apiKeys[id] = key
+		revoked = key.ApiKey
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyApiKey(revoked), nil
+}
+
+// copyApiKey keeps callers from modifying the stored scopes through the returned slice.
+func copyApiKey(apiKey domain.ApiKey) *domain.ApiKey {
+	apiKey.Scopes = slices.Clone(apiKey.Scopes)
+	return &apiKey
+}
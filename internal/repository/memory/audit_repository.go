@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type auditRepository struct {
+	store *Store
+}
+
+func NewAuditRepository(store *Store) repository.AuditRepository {
+	return &auditRepository{store: store}
+}
+
+// LockChainHead needs no lock of its own: the transaction it runs in already holds the store.
+func (ar *auditRepository) LockChainHead(ctx context.Context) (string, error) {
+	if current, ok := ctx.Value(txKey{}).(*tx); !ok || current.store != ar.store {
+		return "", errors.New("memory: LockChainHead must run inside a transaction")
+	}
+	var hash string
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		if len(t.auditLog) > 0 {
+			hash = t.auditLog[len(t.auditLog)-1].Hash
+		}
+		return nil
+	})
+	return hash, err
+}
+
+func (ar *auditRepository) Create(ctx context.Context, entry domain.AuditEntry) (*domain.AuditEntry, error) {
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, existing := range t.auditLog {
+			if existing.Hash == entry.Hash {
+				return fmt.Errorf("memory: audit hash %s already exists", entry.Hash)
+			}
+		}
+		entry.Id = t.nextId("audit_log")
+		t.auditLog = append(t.auditLog, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (ar *auditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var entries []domain.AuditEntry
+	err := ar.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, entry := range t.auditLog {
+			if len(entries) == int(filter.Limit) {
+				break
+			}
+			if matchesAuditFilter(entry, filter) {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func matchesAuditFilter(entry domain.AuditEntry, filter domain.AuditFilter) bool {
+	return entry.Id > filter.AfterId &&
+		(filter.ResourceType == "" || entry.ResourceType == filter.ResourceType) &&
+		(filter.ResourceId == "" || entry.ResourceId == filter.ResourceId) &&
+		(filter.Actor == "" || entry.Actor == filter.Actor) &&
+		(filter.From == nil || !entry.CreatedAt.Before(*filter.From)) &&
+		(filter.To == nil || entry.CreatedAt.Before(*filter.To))
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type importCheckpointRepository struct {
+	store *Store
+}
+
+func NewImportCheckpointRepository(store *Store) repository.ImportCheckpointRepository {
+	return &importCheckpointRepository{store: store}
+}
+
+func (ir *importCheckpointRepository) Start(ctx context.Context, fileHash string, fileName string) (*domain.ImportCheckpoint, error) {
+	var checkpoint domain.ImportCheckpoint
+	err := ir.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		if checkpoint, exists = t.importCheckpoints[fileHash]; !exists {
+			checkpoint = domain.ImportCheckpoint{FileHash: fileHash, FileName: fileName, UpdatedAt: ir.store.now()}
+			t.importCheckpoints[fileHash] = checkpoint
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Lock needs no lock of its own: the transaction it runs in already holds the store.
+func (ir *importCheckpointRepository) Lock(ctx context.Context, fileHash string) (*domain.ImportCheckpoint, error) {
+	if current, ok := ctx.Value(txKey{}).(*tx); !ok || current.store != ir.store {
+		return nil, errors.New("memory: Lock must run inside a transaction")
+	}
+	var checkpoint domain.ImportCheckpoint
+	err := ir.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		if checkpoint, exists = t.importCheckpoints[fileHash]; !exists {
+			return errNoRows
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (ir *importCheckpointRepository) Save(ctx context.Context, checkpoint domain.ImportCheckpoint) error {
+	return ir.store.run(ctx, func(_ context.Context, t *tables) error {
+		stored, exists := t.importCheckpoints[checkpoint.FileHash]
+		if !exists {
+			return nil
+		}
+		stored.Line = checkpoint.Line
+		stored.Posted = checkpoint.Posted
+		stored.Rejected = checkpoint.Rejected
+		stored.UpdatedAt = ir.store.now()
+		t.importCheckpoints[checkpoint.FileHash] = stored
+		return nil
+	})
+}
+
+func (ir *importCheckpointRepository) ClaimReference(ctx context.Context, source string, reference string, _ string, _ int64) (bool, error) {
+	var claimed bool
+	err := ir.store.run(ctx, func(_ context.Context, t *tables) error {
+		key := [2]string{source, reference}
+		if _, exists := t.importedReferences[key]; !exists {
+			t.importedReferences[key], claimed = struct{}{}, true
+		}
+		return nil
+	})
+	return claimed, err
+}
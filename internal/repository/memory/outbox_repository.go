@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/redact"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type outboxEvent struct {
+	domain.OutboxEvent
+	dispatchedAt *time.Time
+}
+
+type outboxRepository struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) repository.OutboxRepository {
+	return &outboxRepository{store: store}
+}
+
+func (or *outboxRepository) Create(ctx context.Context, eventParam domain.CreateOutboxEventParam) (*domain.OutboxEvent, error) {
+	payload, err := json.Marshal(redact.Value(eventParam.Payload))
+	if err != nil {
+		return nil, err
+	}
+
+	var event domain.OutboxEvent
+	err = or.store.run(ctx, func(_ context.Context, t *tables) error {
+		if _, exists := t.accounts[eventParam.AccountId]; !exists {
+			return fmt.Errorf("memory: outbox event references missing account %d", eventParam.AccountId)
+		}
+		event = domain.OutboxEvent{
+			Id:            t.nextId("outbox_events"),
+			EventType:     eventParam.EventType,
+			AggregateType: eventParam.AggregateType,
+			AggregateId:   eventParam.AggregateId,
+			AccountId:     eventParam.AccountId,
+			Payload:       payload,
+			CreatedAt:     or.store.now(),
+		}
+		t.outboxEvents[event.Id] = outboxEvent{OutboxEvent: event}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (or *outboxRepository) ListUndispatched(ctx context.Context, limit int32) ([]domain.OutboxEvent, error) {
+	return or.list(ctx, limit, func(event outboxEvent) bool { return event.dispatchedAt == nil })
+}
+
+func (or *outboxRepository) MarkDispatched(ctx context.Context, eventId int64) error {
+	return or.store.run(ctx, func(_ context.Context, t *tables) error {
+		if event, exists := t.outboxEvents[eventId]; exists {
+			dispatchedAt := or.store.now()
+			event.dispatchedAt = &dispatchedAt
+			t.outboxEvents[eventId] = event
+		}
+		return nil
+	})
+}
+
+func (or *outboxRepository) ListByAccountAfter(ctx context.Context, accountId int64, afterEventId int64, eventTypes []string, limit int32) ([]domain.OutboxEvent, error) {
+	return or.list(ctx, limit, func(event outboxEvent) bool {
+		return event.AccountId == accountId && event.Id > afterEventId && slices.Contains(eventTypes, event.EventType)
+	})
+}
+
+func (or *outboxRepository) GetLatestEventId(ctx context.Context, accountId int64) (int64, error) {
+	var latest int64
+	err := or.store.run(ctx, func(_ context.Context, t *tables) error {
+		for id, event := range t.outboxEvents {
+			if event.AccountId == accountId && id > latest {
+				latest = id
+			}
+		}
+		return nil
+	})
+	return latest, err
+}
+
+// list returns at most limit events matching keep, in event id order.
+func (or *outboxRepository) list(ctx context.Context, limit int32, keep func(event outboxEvent) bool) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := or.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, id := range sortedKeys(t.outboxEvents) {
+			if len(events) == int(limit) {
+				break
+			}
+			if event := t.outboxEvents[id]; keep(event) {
+				events = append(events, event.OutboxEvent)
+			}
+		}
+		return nil
+	})
+	return events, err
+}
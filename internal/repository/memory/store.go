@@ -0,0 +1,150 @@
+// Package memory keeps every repository in process memory, for local development without Postgres and for
+// service tests that need real storage behaviour instead of scripted mocks. It enforces the constraints the
+// schema does and returns the same domain errors as the Postgres repositories. Changes are lost on shutdown.
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+// errNoRows is what an update of a missing row returns, like pgx.ErrNoRows from a Postgres repository.
+var errNoRows = errors.New("memory: no rows in result set")
+
+// Store holds the tables. Transactions are serialized: one runs at a time on a copy of the tables, which
+// replaces them on commit and is dropped on rollback. A call outside a transaction is a transaction of its own.
+type Store struct {
+	mu        sync.Mutex
+	committed *tables
+	now       func() time.Time
+}
+
+type txKey struct{}
+
+type tx struct {
+	store  *Store
+	tables *tables
+}
+
+type tables struct {
+	lastIds map[string]int64
+
+	accounts map[int64]domain.Account
+	// documentNumbers is the unique index of accounts.document_number.
+	documentNumbers   map[string]int64
+	transactions      map[int64]domain.Transaction
+	outboxEvents      map[int64]outboxEvent
+	auditLog          []domain.AuditEntry
+	apiKeys           map[int64]apiKey
+	subscriptions     map[int64]domain.WebhookSubscription
+	deliveries        map[int64]domain.WebhookDelivery
+	importCheckpoints map[string]domain.ImportCheckpoint
+	// importedReferences is the primary key of imported_references, source then reference.
+	importedReferences map[[2]string]struct{}
+}
+
+func NewStore() *Store {
+	return &Store{
+		committed: &tables{
+			lastIds:            map[string]int64{},
+			accounts:           map[int64]domain.Account{},
+			documentNumbers:    map[string]int64{},
+			transactions:       map[int64]domain.Transaction{},
+			outboxEvents:       map[int64]outboxEvent{},
+			apiKeys:            map[int64]apiKey{},
+			subscriptions:      map[int64]domain.WebhookSubscription{},
+			deliveries:         map[int64]domain.WebhookDelivery{},
+			importCheckpoints:  map[string]domain.ImportCheckpoint{},
+			importedReferences: map[[2]string]struct{}{},
+		},
+		now: time.Now,
+	}
+}
+
+// NewRepositories returns every repository over a new empty store.
+func NewRepositories() repository.Repositories {
+	store := NewStore()
+	return repository.Repositories{
+		Accounts:          NewAccountRepository(store),
+		Transactions:      NewTransactionRepository(store),
+		Outbox:            NewOutboxRepository(store),
+		Audit:             NewAuditRepository(store),
+		ApiKeys:           NewApiKeyRepository(store),
+		Webhooks:          NewWebhookRepository(store),
+		Health:            NewHealthRepository(),
+		ImportCheckpoints: NewImportCheckpointRepository(store),
+		Transactor:        NewTransactor(store),
+	}
+}
+
+// run calls fn with the tables of the transaction carried by ctx, or within a transaction of its own.
+func (s *Store) run(ctx context.Context, fn func(ctx context.Context, t *tables) error) error {
+	if current, ok := ctx.Value(txKey{}).(*tx); ok && current.store == s {
+		return fn(ctx, current.tables)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	working := s.committed.clone()
+	if err := fn(context.WithValue(ctx, txKey{}, &tx{store: s, tables: working}), working); err != nil {
+		return err
+	}
+	s.committed = working
+	return nil
+}
+
+// clone copies the maps; rows are values and are replaced rather than modified, so they can be shared.
+func (t *tables) clone() *tables {
+	return &tables{
+		lastIds:            maps.Clone(t.lastIds),
+		accounts:           maps.Clone(t.accounts),
+		documentNumbers:    maps.Clone(t.documentNumbers),
+		transactions:       maps.Clone(t.transactions),
+		outboxEvents:       maps.Clone(t.outboxEvents),
+		auditLog:           slices.Clip(t.auditLog),
+		apiKeys:            maps.Clone(t.apiKeys),
+		subscriptions:      maps.Clone(t.subscriptions),
+		deliveries:         maps.Clone(t.deliveries),
+		importCheckpoints:  maps.Clone(t.importCheckpoints),
+		importedReferences: maps.Clone(t.importedReferences),
+	}
+}
+
+func (t *tables) nextId(table string) int64 {
+	t.lastIds[table]++
+	return t.lastIds[table]
+}
+
+// sortedKeys returns the ids of a table in ascending order, the order of a serial primary key.
+func sortedKeys[V any](rows map[int64]V) []int64 {
+	return slices.Sorted(maps.Keys(rows))
+}
+
+type transactor struct {
+	store *Store
+}
+
+func NewTransactor(store *Store) repository.Transactor {
+	return &transactor{store: store}
+}
+
+// WithinTransaction joins the transaction already carried by ctx, like the Postgres transactor, and runs the
+// repository.AfterCommit callbacks once the outermost one has committed.
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, committed := repository.WithAfterCommit(ctx)
+	err := t.store.run(ctx, func(txCtx context.Context, _ *tables) error {
+		return fn(txCtx)
+	})
+	if err != nil {
+		return err
+	}
+	committed()
+	return nil
+}
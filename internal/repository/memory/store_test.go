@@ -0,0 +1,318 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type StoreTestSuite struct {
+	suite.Suite
+	context      context.Context
+	repositories repository.Repositories
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
+
+func (suite *StoreTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.repositories = NewRepositories()
+}
+
+func (suite *StoreTestSuite) createAccount(documentNumber string) *domain.Account {
+	account, err := suite.repositories.Accounts.Create(suite.context, domain.CreateAccountParam{DocumentNumber: documentNumber})
+	suite.Require().NoError(err)
+	return account
+}
+
+func (suite *StoreTestSuite) TestWithinTransaction_Rollback_Discards_Changes() {
+	account := suite.createAccount("0123456789")
+	expectedErr := errors.New("audit failed")
+
+	err := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		_, createErr := suite.repositories.Transactions.Create(txCtx, domain.CreateTransactionParam{AccountId: account.Id, OperationTypeId: 1, Amount: -10, Balance: -10})
+		suite.Require().NoError(createErr)
+		_, blockErr := suite.repositories.Accounts.Block(txCtx, account.Id)
+		suite.Require().NoError(blockErr)
+		return expectedErr
+	})
+
+	suite.ErrorIs(err, expectedErr)
+	transactions, listErr := suite.repositories.Transactions.GetAllTransactions(suite.context, account.Id)
+	suite.NoError(listErr)
+	suite.Empty(transactions)
+	stored, getErr := suite.repositories.Accounts.GetById(suite.context, account.Id)
+	suite.NoError(getErr)
+	suite.Nil(stored.BlockedAt)
+}
+
+func (suite *StoreTestSuite) TestWithinTransaction_Rollback_Releases_Unique_Values() {
+	err := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		_, createErr := suite.repositories.Accounts.Create(txCtx, domain.CreateAccountParam{DocumentNumber: "0123456789"})
+		suite.Require().NoError(createErr)
+		return errors.New("rollback")
+	})
+
+	suite.Error(err)
+	suite.createAccount("0123456789")
+}
+
+func (suite *StoreTestSuite) TestWithinTransaction_Nested_Joins_Outer_Transaction() {
+	var account *domain.Account
+
+	err := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		innerErr := suite.repositories.Transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+			var createErr error
+			account, createErr = suite.repositories.Accounts.Create(innerCtx, domain.CreateAccountParam{DocumentNumber: "0123456789"})
+			return createErr
+		})
+		suite.Require().NoError(innerErr)
+
+		// The outer transaction sees the inner change, and discards it on rollback.
+		_, getErr := suite.repositories.Accounts.GetById(txCtx, account.Id)
+		suite.Require().NoError(getErr)
+		return errors.New("rollback")
+	})
+
+	suite.Error(err)
+	_, getErr := suite.repositories.Accounts.GetById(suite.context, account.Id)
+	suite.ErrorIs(getErr, domain.ErrAccountNotFound)
+}
+
+func (suite *StoreTestSuite) TestWithinTransaction_After_Commit_Callbacks() {
+	var calls []string
+
+	err := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		innerErr := suite.repositories.Transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+			repository.AfterCommit(innerCtx, func() { calls = append(calls, "inner") })
+			return nil
+		})
+		suite.Require().NoError(innerErr)
+		// The inner transaction joined the outer one, its callback waits for the outer commit.
+		suite.Empty(calls)
+		repository.AfterCommit(txCtx, func() { calls = append(calls, "outer") })
+		return nil
+	})
+	rollbackErr := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		repository.AfterCommit(txCtx, func() { calls = append(calls, "rolled back") })
+		return errors.New("rollback")
+	})
+
+	suite.NoError(err)
+	suite.Error(rollbackErr)
+	suite.Equal([]string{"inner", "outer"}, calls)
+}
+
+func (suite *StoreTestSuite) TestAccounts_Create_Duplicate_Document_Number() {
+	suite.createAccount("0123456789")
+
+	account, err := suite.repositories.Accounts.Create(suite.context, domain.CreateAccountParam{DocumentNumber: "0123456789"})
+
+	suite.Nil(account)
+	suite.ErrorIs(err, domain.ErrAccountAlreadyExist)
+}
+
+func (suite *StoreTestSuite) TestAccounts_Not_Found() {
+	_, getErr := suite.repositories.Accounts.GetById(suite.context, 99)
+	_, blockErr := suite.repositories.Accounts.Block(suite.context, 99)
+
+	suite.ErrorIs(getErr, domain.ErrAccountNotFound)
+	suite.ErrorIs(blockErr, domain.ErrAccountNotFound)
+}
+
+func (suite *StoreTestSuite) TestAccounts_UpdateProfile_Bumps_Version() {
+	account := suite.createAccount("0123456789")
+	profile := domain.AccountProfile{FullName: "Maria da Silva", Address: &domain.PostalAddress{Line1: "Av. Paulista, 1000", Country: "BR"}}
+
+	updated, updateErr := suite.repositories.Accounts.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: account.Id, Version: 1, Profile: profile})
+	updated.Profile.Address.Line1 = "changed by the caller"
+	_, staleErr := suite.repositories.Accounts.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: account.Id, Version: 1, Profile: profile})
+	blocked, blockErr := suite.repositories.Accounts.Block(suite.context, account.Id)
+	_, missingErr := suite.repositories.Accounts.UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: 99, Version: 1})
+
+	suite.NoError(updateErr)
+	suite.Equal(int32(2), updated.Version)
+	suite.ErrorIs(staleErr, domain.ErrAccountVersionMismatch)
+	suite.NoError(blockErr)
+	suite.Equal(int32(3), blocked.Version)
+	suite.Equal("Av. Paulista, 1000", blocked.Profile.Address.Line1)
+	suite.ErrorIs(missingErr, domain.ErrAccountNotFound)
+}
+
+func (suite *StoreTestSuite) TestTransactions_Create_Requires_Account_And_Operation_Type() {
+	account := suite.createAccount("0123456789")
+
+	_, accountErr := suite.repositories.Transactions.Create(suite.context, domain.CreateTransactionParam{AccountId: 99, OperationTypeId: 1, Amount: -10})
+	_, operationErr := suite.repositories.Transactions.Create(suite.context, domain.CreateTransactionParam{AccountId: account.Id, OperationTypeId: 9, Amount: -10})
+
+	suite.Error(accountErr)
+	suite.Error(operationErr)
+}
+
+func (suite *StoreTestSuite) TestTransactions_Export_Filters_Period() {
+	account := suite.createAccount("0123456789")
+	var created []*domain.Transaction
+	for range 3 {
+		transaction, err := suite.repositories.Transactions.Create(suite.context, domain.CreateTransactionParam{AccountId: account.Id, OperationTypeId: 1, Amount: -10, Balance: -10})
+		suite.Require().NoError(err)
+		created = append(created, transaction)
+	}
+	from, to := created[1].CreatedAt, created[2].CreatedAt
+	filter := domain.TransactionExportFilter{AccountId: account.Id, From: &from, To: &to}
+	var exportedIds []int64
+
+	outsideErr := suite.repositories.Transactions.ExportTransactions(suite.context, filter, func(domain.ExportedTransaction) error { return nil })
+	err := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		return suite.repositories.Transactions.ExportTransactions(txCtx, filter, func(transaction domain.ExportedTransaction) error {
+			exportedIds = append(exportedIds, transaction.Id)
+			return nil
+		})
+	})
+
+	suite.Error(outsideErr)
+	suite.NoError(err)
+	suite.Equal([]int64{created[1].Id}, exportedIds)
+}
+
+func (suite *StoreTestSuite) TestAccounts_LockById_Requires_Transaction() {
+	account := suite.createAccount("0123456789")
+
+	_, outsideErr := suite.repositories.Accounts.LockById(suite.context, account.Id)
+	var locked *domain.Account
+	insideErr := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		var err error
+		locked, err = suite.repositories.Accounts.LockById(txCtx, account.Id)
+		return err
+	})
+
+	suite.Error(outsideErr)
+	suite.NoError(insideErr)
+	suite.Equal(account.Id, locked.Id)
+}
+
+func (suite *StoreTestSuite) TestAuditLockChainHead_Requires_Transaction() {
+	_, err := suite.repositories.Audit.LockChainHead(suite.context)
+
+	suite.Error(err)
+}
+
+func (suite *StoreTestSuite) TestImportCheckpoints_Start_Keeps_Progress() {
+	started, startErr := suite.repositories.ImportCheckpoints.Start(suite.context, "hash", "clearing.csv")
+	_, lockErr := suite.repositories.ImportCheckpoints.Lock(suite.context, "hash")
+	saveErr := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		checkpoint, err := suite.repositories.ImportCheckpoints.Lock(txCtx, "hash")
+		suite.Require().NoError(err)
+		checkpoint.Line, checkpoint.Posted = 3, 2
+		return suite.repositories.ImportCheckpoints.Save(txCtx, *checkpoint)
+	})
+	restarted, restartErr := suite.repositories.ImportCheckpoints.Start(suite.context, "hash", "renamed.csv")
+
+	suite.NoError(startErr)
+	suite.Equal(int64(0), started.Line)
+	suite.Error(lockErr)
+	suite.NoError(saveErr)
+	suite.NoError(restartErr)
+	suite.Equal("clearing.csv", restarted.FileName)
+	suite.Equal(int64(3), restarted.Line)
+	suite.Equal(int64(2), restarted.Posted)
+}
+
+func (suite *StoreTestSuite) TestImportCheckpoints_ClaimReference_Rolls_Back() {
+	rollbackErr := errors.New("rollback")
+	err := suite.repositories.Transactor.WithinTransaction(suite.context, func(txCtx context.Context) error {
+		claimed, err := suite.repositories.ImportCheckpoints.ClaimReference(txCtx, "network", "REF-1", "hash", 1)
+		suite.Require().NoError(err)
+		suite.True(claimed)
+		return rollbackErr
+	})
+	claimed, claimErr := suite.repositories.ImportCheckpoints.ClaimReference(suite.context, "network", "REF-1", "hash", 2)
+	again, againErr := suite.repositories.ImportCheckpoints.ClaimReference(suite.context, "network", "REF-1", "other", 1)
+	otherSource, otherErr := suite.repositories.ImportCheckpoints.ClaimReference(suite.context, "acquirer", "REF-1", "other", 1)
+
+	suite.ErrorIs(err, rollbackErr)
+	suite.NoError(claimErr)
+	suite.True(claimed)
+	suite.NoError(againErr)
+	suite.False(again)
+	suite.NoError(otherErr)
+	suite.True(otherSource)
+}
+
+func (suite *StoreTestSuite) TestOutbox_Create_Masks_Redacted_Fields() {
+	account := suite.createAccount("0123456789")
+
+	event, err := suite.repositories.Outbox.Create(suite.context, domain.CreateOutboxEventParam{
+		EventType: domain.EventAccountCreated, AggregateType: domain.AggregateAccount, AggregateId: account.Id, AccountId: account.Id,
+		Payload: domain.AccountCreatedPayload{AccountId: account.Id, DocumentNumber: account.DocumentNumber},
+	})
+
+	suite.Require().NoError(err)
+	suite.Contains(string(event.Payload), `"document_number":"******6789"`)
+	suite.NotContains(string(event.Payload), "0123456789")
+}
+
+func (suite *StoreTestSuite) TestWebhooks_Delete_Cascades_To_Deliveries() {
+	account := suite.createAccount("0123456789")
+	event, err := suite.repositories.Outbox.Create(suite.context, domain.CreateOutboxEventParam{
+		EventType: domain.EventAccountCreated, AggregateType: domain.AggregateAccount, AggregateId: account.Id, AccountId: account.Id,
+	})
+	suite.Require().NoError(err)
+	subscription, err := suite.repositories.Webhooks.CreateSubscription(suite.context, domain.CreateWebhookSubscriptionParam{
+		Url: "https://example.com/hook", Secret: "secret", EventTypes: []string{domain.EventAccountCreated},
+	})
+	suite.Require().NoError(err)
+
+	created, createErr := suite.repositories.Webhooks.CreateDeliveries(suite.context, event.Id, event.EventType)
+	again, againErr := suite.repositories.Webhooks.CreateDeliveries(suite.context, event.Id, event.EventType)
+	claimed, claimErr := suite.repositories.Webhooks.ClaimDueDeliveries(suite.context, time.Minute, 10)
+	deleteErr := suite.repositories.Webhooks.DeleteSubscription(suite.context, subscription.Id)
+	deliveries, listErr := suite.repositories.Webhooks.ListDeliveries(suite.context, subscription.Id, "", 10)
+
+	suite.NoError(createErr)
+	suite.NoError(againErr)
+	suite.Equal(int64(1), created)
+	suite.Equal(int64(0), again)
+	suite.NoError(claimErr)
+	suite.Len(claimed, 1)
+	suite.Equal("https://example.com/hook", claimed[0].Url)
+	suite.NoError(deleteErr)
+	suite.NoError(listErr)
+	suite.Empty(deliveries)
+	suite.ErrorIs(suite.repositories.Webhooks.DeleteSubscription(suite.context, subscription.Id), domain.ErrWebhookNotFound)
+}
+
+func (suite *StoreTestSuite) TestConcurrent_Creates_Keep_Document_Numbers_Unique() {
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.repositories.Accounts.Create(suite.context, domain.CreateAccountParam{DocumentNumber: fmt.Sprintf("%010d", i%10)})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var created, duplicates int
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, domain.ErrAccountAlreadyExist):
+			duplicates++
+		}
+	}
+	suite.Equal(10, created)
+	suite.Equal(40, duplicates)
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type transactionRepository struct {
+	store *Store
+}
+
+func NewTransactionRepository(store *Store) repository.TransactionRepository {
+	return &transactionRepository{store: store}
+}
+
+func (tr *transactionRepository) Create(ctx context.Context, transactionParam domain.CreateTransactionParam) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	err := tr.store.run(ctx, func(_ context.Context, t *tables) error {
+		if _, exists := t.accounts[transactionParam.AccountId]; !exists {
+			return fmt.Errorf("memory: transaction references missing account %d", transactionParam.AccountId)
+		}
+		if _, exists := domain.ValidOperations[transactionParam.OperationTypeId]; !exists {
+			return fmt.Errorf("memory: transaction references missing operation type %d", transactionParam.OperationTypeId)
+		}
+		transaction = domain.Transaction{
+			Id:              t.nextId("transactions"),
+			AccountId:       transactionParam.AccountId,
+			OperationTypeId: transactionParam.OperationTypeId,
+			Amount:          roundCents(transactionParam.Amount),
+			Balance:         roundCents(transactionParam.Balance),
+			CreatedAt:       tr.store.now(),
+		}
+		t.transactions[transaction.Id] = transaction
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// GetAllTransactions returns the transactions of an account oldest first.
+func (tr *transactionRepository) GetAllTransactions(ctx context.Context, accountId int64) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := tr.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, id := range sortedKeys(t.transactions) {
+			if transaction := t.transactions[id]; transaction.AccountId == accountId {
+				transactions = append(transactions, transaction)
+			}
+		}
+		return nil
+	})
+	// Ids increase with insertion, so a stable sort on the timestamp breaks ties by id like Postgres does.
+	slices.SortStableFunc(transactions, func(a, b domain.Transaction) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return transactions, err
+}
+
+func (tr *transactionRepository) UpdateTransactionById(ctx context.Context, transactionId int64, balance float64) error {
+	return tr.store.run(ctx, func(_ context.Context, t *tables) error {
+		transaction, exists := t.transactions[transactionId]
+		if !exists {
+			return errNoRows
+		}
+		transaction.Balance = roundCents(balance)
+		t.transactions[transactionId] = transaction
+		return nil
+	})
+}
+
+// ExportTransactions needs a transaction like the Postgres cursor does, although it reads the rows at once.
+func (tr *transactionRepository) ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+	if current, ok := ctx.Value(txKey{}).(*tx); !ok || current.store != tr.store {
+		return errors.New("memory: ExportTransactions must run inside a transaction")
+	}
+	var transactions []domain.Transaction
+	err := tr.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, id := range sortedKeys(t.transactions) {
+			transaction := t.transactions[id]
+			if transaction.AccountId != filter.AccountId ||
+				(filter.From != nil && transaction.CreatedAt.Before(*filter.From)) ||
+				(filter.To != nil && !transaction.CreatedAt.Before(*filter.To)) {
+				continue
+			}
+			transactions = append(transactions, transaction)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(transactions, func(a, b domain.Transaction) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	for _, transaction := range transactions {
+		exported := domain.ExportedTransaction{Transaction: transaction, OperationDescription: domain.ValidOperations[transaction.OperationTypeId].Description}
+		if err := fn(exported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roundCents keeps the two decimals of the NUMERIC columns.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
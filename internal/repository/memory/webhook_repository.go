@@ -0,0 +1,243 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type webhookRepository struct {
+	store *Store
+}
+
+func NewWebhookRepository(store *Store) repository.WebhookRepository {
+	return &webhookRepository{store: store}
+}
+
+func (wr *webhookRepository) CreateSubscription(ctx context.Context, subscriptionParam domain.CreateWebhookSubscriptionParam) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		now := wr.store.now()
+		subscription = domain.WebhookSubscription{
+			Id:         t.nextId("webhook_subscriptions"),
+			Url:        subscriptionParam.Url,
+			Secret:     subscriptionParam.Secret,
+			EventTypes: slices.Clone(subscriptionParam.EventTypes),
+			Active:     true,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		t.subscriptions[subscription.Id] = subscription
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copySubscription(subscription), nil
+}
+
+func (wr *webhookRepository) GetSubscriptionById(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		subscription, exists = t.subscriptions[id]
+		if !exists {
+			return domain.ErrWebhookNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copySubscription(subscription), nil
+}
+
+func (wr *webhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		for _, id := range sortedKeys(t.subscriptions) {
+			subscriptions = append(subscriptions, *copySubscription(t.subscriptions[id]))
+		}
+		return nil
+	})
+	return subscriptions, err
+}
+
+func (wr *webhookRepository) UpdateSubscription(ctx context.Context, subscriptionParam domain.UpdateWebhookSubscriptionParam) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		var exists bool
+		subscription, exists = t.subscriptions[subscriptionParam.Id]
+		if !exists {
+			return domain.ErrWebhookNotFound
+		}
+		subscription.Url = subscriptionParam.Url
+		subscription.EventTypes = slices.Clone(subscriptionParam.EventTypes)
+		subscription.Active = subscriptionParam.Active
+		subscription.UpdatedAt = wr.store.now()
+		t.subscriptions[subscription.Id] = subscription
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copySubscription(subscription), nil
+}
+
+// DeleteSubscription also removes the deliveries of the subscription, like the ON DELETE CASCADE of the schema.
+func (wr *webhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	return wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		if _, exists := t.subscriptions[id]; !exists {
+			return domain.ErrWebhookNotFound
+		}
+		delete(t.subscriptions, id)
+		for deliveryId, delivery := range t.deliveries {
+			if delivery.SubscriptionId == id {
+				delete(t.deliveries, deliveryId)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateDeliveries fans an event out to the active subscriptions listening to its type, skipping the
+// subscriptions that already have a delivery of it. It returns the number of deliveries created.
+func (wr *webhookRepository) CreateDeliveries(ctx context.Context, eventId int64, eventType string) (int64, error) {
+	var created int64
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		if _, exists := t.outboxEvents[eventId]; !exists {
+			return fmt.Errorf("memory: webhook delivery references missing event %d", eventId)
+		}
+		delivered := map[int64]bool{}
+		for _, delivery := range t.deliveries {
+			if delivery.EventId == eventId {
+				delivered[delivery.SubscriptionId] = true
+			}
+		}
+		for _, id := range sortedKeys(t.subscriptions) {
+			subscription := t.subscriptions[id]
+			if !subscription.Active || !slices.Contains(subscription.EventTypes, eventType) || delivered[id] {
+				continue
+			}
+			now := wr.store.now()
+			delivery := domain.WebhookDelivery{
+				Id:             t.nextId("webhook_deliveries"),
+				EventId:        eventId,
+				SubscriptionId: id,
+				Status:         domain.DeliveryStatusPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+			}
+			t.deliveries[delivery.Id] = delivery
+			created++
+		}
+		return nil
+	})
+	return created, err
+}
+
+// ClaimDueDeliveries leases the oldest due pending deliveries by moving their next attempt past the lease.
+func (wr *webhookRepository) ClaimDueDeliveries(ctx context.Context, lease time.Duration, batchSize int32) ([]domain.PendingDelivery, error) {
+	var claimed []domain.PendingDelivery
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		now := wr.store.now()
+		var due []domain.WebhookDelivery
+		for _, id := range sortedKeys(t.deliveries) {
+			if delivery := t.deliveries[id]; delivery.Status == domain.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+				due = append(due, delivery)
+			}
+		}
+		slices.SortStableFunc(due, func(a, b domain.WebhookDelivery) int {
+			return a.NextAttemptAt.Compare(b.NextAttemptAt)
+		})
+
+		for _, delivery := range due {
+			if len(claimed) == int(batchSize) {
+				break
+			}
+			event, eventExists := t.outboxEvents[delivery.EventId]
+			subscription, subscriptionExists := t.subscriptions[delivery.SubscriptionId]
+			if !eventExists || !subscriptionExists {
+				continue
+			}
+			delivery.NextAttemptAt = now.Add(lease)
+			t.deliveries[delivery.Id] = delivery
+			claimed = append(claimed, domain.PendingDelivery{
+				Id:             delivery.Id,
+				Attempts:       delivery.Attempts,
+				EventId:        event.Id,
+				EventType:      event.EventType,
+				Payload:        slices.Clone(event.Payload),
+				EventCreatedAt: event.CreatedAt,
+				SubscriptionId: subscription.Id,
+				Url:            subscription.Url,
+				Secret:         subscription.Secret,
+			})
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+func (wr *webhookRepository) MarkDeliverySucceeded(ctx context.Context, deliveryId int64, statusCode int32) error {
+	return wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		delivery, exists := t.deliveries[deliveryId]
+		if !exists {
+			return nil
+		}
+		deliveredAt := wr.store.now()
+		delivery.Status = domain.DeliveryStatusDelivered
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		delivery.LastError = ""
+		delivery.DeliveredAt = &deliveredAt
+		t.deliveries[deliveryId] = delivery
+		return nil
+	})
+}
+
+func (wr *webhookRepository) MarkDeliveryFailed(ctx context.Context, failureParam domain.DeliveryFailureParam) error {
+	return wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		delivery, exists := t.deliveries[failureParam.Id]
+		if !exists {
+			return nil
+		}
+		delivery.Status = failureParam.Status
+		delivery.Attempts++
+		delivery.LastStatusCode = failureParam.LastStatusCode
+		delivery.LastError = failureParam.LastError
+		delivery.NextAttemptAt = failureParam.NextAttemptAt
+		t.deliveries[delivery.Id] = delivery
+		return nil
+	})
+}
+
+// ListDeliveries returns the latest deliveries of a subscription first, optionally only those in status.
+func (wr *webhookRepository) ListDeliveries(ctx context.Context, subscriptionId int64, status string, limit int32) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := wr.store.run(ctx, func(_ context.Context, t *tables) error {
+		ids := sortedKeys(t.deliveries)
+		slices.Reverse(ids)
+		for _, id := range ids {
+			if len(deliveries) == int(limit) {
+				break
+			}
+			delivery := t.deliveries[id]
+			if delivery.SubscriptionId == subscriptionId && (status == "" || delivery.Status == status) {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+// copySubscription keeps callers from modifying the stored event types through the returned slice.
+func copySubscription(subscription domain.WebhookSubscription) *domain.WebhookSubscription {
+	subscription.EventTypes = slices.Clone(subscription.EventTypes)
+	return &subscription
+}
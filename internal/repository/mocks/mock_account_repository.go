@@ -41,6 +41,21 @@ func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Block mocks base method.
+func (m *MockAccountRepository) Block(ctx context.Context, id int64) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, id)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockAccountRepositoryMockRecorder) Block(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockAccountRepository)(nil).Block), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockAccountRepository) Create(ctx context.Context, accountParam domain.CreateAccountParam) (*domain.Account, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +85,33 @@ func (mr *MockAccountRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAccountRepository)(nil).GetById), ctx, id)
 }
+
+// LockById mocks base method.
+func (m *MockAccountRepository) LockById(ctx context.Context, id int64) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockById", ctx, id)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockById indicates an expected call of LockById.
+func (mr *MockAccountRepositoryMockRecorder) LockById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockById", reflect.TypeOf((*MockAccountRepository)(nil).LockById), ctx, id)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockAccountRepository) UpdateProfile(ctx context.Context, profileParam domain.UpdateAccountProfileParam) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, profileParam)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockAccountRepositoryMockRecorder) UpdateProfile(ctx, profileParam any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockAccountRepository)(nil).UpdateProfile), ctx, profileParam)
+}
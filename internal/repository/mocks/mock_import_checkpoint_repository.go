@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import_checkpoint_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=import_checkpoint_repository.go -destination=mocks/mock_import_checkpoint_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/credit-card-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportCheckpointRepository is a mock of ImportCheckpointRepository interface.
+type MockImportCheckpointRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportCheckpointRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImportCheckpointRepositoryMockRecorder is the mock recorder for MockImportCheckpointRepository.
+type MockImportCheckpointRepositoryMockRecorder struct {
+	mock *MockImportCheckpointRepository
+}
+
+// NewMockImportCheckpointRepository creates a new mock instance.
+func NewMockImportCheckpointRepository(ctrl *gomock.Controller) *MockImportCheckpointRepository {
+	mock := &MockImportCheckpointRepository{ctrl: ctrl}
+	mock.recorder = &MockImportCheckpointRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportCheckpointRepository) EXPECT() *MockImportCheckpointRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimReference mocks base method.
+func (m *MockImportCheckpointRepository) ClaimReference(ctx context.Context, source, reference, fileHash string, line int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReference", ctx, source, reference, fileHash, line)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReference indicates an expected call of ClaimReference.
+func (mr *MockImportCheckpointRepositoryMockRecorder) ClaimReference(ctx, source, reference, fileHash, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReference", reflect.TypeOf((*MockImportCheckpointRepository)(nil).ClaimReference), ctx, source, reference, fileHash, line)
+}
+
+// Lock mocks base method.
+func (m *MockImportCheckpointRepository) Lock(ctx context.Context, fileHash string) (*domain.ImportCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, fileHash)
+	ret0, _ := ret[0].(*domain.ImportCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockImportCheckpointRepositoryMockRecorder) Lock(ctx, fileHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockImportCheckpointRepository)(nil).Lock), ctx, fileHash)
+}
+
+// Save mocks base method.
+func (m *MockImportCheckpointRepository) Save(ctx context.Context, checkpoint domain.ImportCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockImportCheckpointRepositoryMockRecorder) Save(ctx, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockImportCheckpointRepository)(nil).Save), ctx, checkpoint)
+}
+
+// Start mocks base method.
+func (m *MockImportCheckpointRepository) Start(ctx context.Context, fileHash, fileName string) (*domain.ImportCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, fileHash, fileName)
+	ret0, _ := ret[0].(*domain.ImportCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockImportCheckpointRepositoryMockRecorder) Start(ctx, fileHash, fileName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockImportCheckpointRepository)(nil).Start), ctx, fileHash, fileName)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox_repository.go -destination=mocks/mock_outbox_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/credit-card-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOutboxRepository) Create(ctx context.Context, eventParam domain.CreateOutboxEventParam) (*domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, eventParam)
+	ret0, _ := ret[0].(*domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxRepositoryMockRecorder) Create(ctx, eventParam any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxRepository)(nil).Create), ctx, eventParam)
+}
+
+// GetLatestEventId mocks base method.
+func (m *MockOutboxRepository) GetLatestEventId(ctx context.Context, accountId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEventId", ctx, accountId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEventId indicates an expected call of GetLatestEventId.
+func (mr *MockOutboxRepositoryMockRecorder) GetLatestEventId(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEventId", reflect.TypeOf((*MockOutboxRepository)(nil).GetLatestEventId), ctx, accountId)
+}
+
+// ListByAccountAfter mocks base method.
+func (m *MockOutboxRepository) ListByAccountAfter(ctx context.Context, accountId, afterEventId int64, eventTypes []string, limit int32) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountAfter", ctx, accountId, afterEventId, eventTypes, limit)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountAfter indicates an expected call of ListByAccountAfter.
+func (mr *MockOutboxRepositoryMockRecorder) ListByAccountAfter(ctx, accountId, afterEventId, eventTypes, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountAfter", reflect.TypeOf((*MockOutboxRepository)(nil).ListByAccountAfter), ctx, accountId, afterEventId, eventTypes, limit)
+}
+
+// ListUndispatched mocks base method.
+func (m *MockOutboxRepository) ListUndispatched(ctx context.Context, limit int32) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUndispatched", ctx, limit)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUndispatched indicates an expected call of ListUndispatched.
+func (mr *MockOutboxRepositoryMockRecorder) ListUndispatched(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUndispatched", reflect.TypeOf((*MockOutboxRepository)(nil).ListUndispatched), ctx, limit)
+}
+
+// MarkDispatched mocks base method.
+func (m *MockOutboxRepository) MarkDispatched(ctx context.Context, eventId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDispatched", ctx, eventId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDispatched indicates an expected call of MarkDispatched.
+func (mr *MockOutboxRepositoryMockRecorder) MarkDispatched(ctx, eventId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDispatched", reflect.TypeOf((*MockOutboxRepository)(nil).MarkDispatched), ctx, eventId)
+}
@@ -41,19 +41,166 @@ func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
 	return m.recorder
 }
 
+// AcquireAuditChainLock mocks base method.
+func (m *MockQuerier) AcquireAuditChainLock(ctx context.Context, lockKey int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireAuditChainLock", ctx, lockKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcquireAuditChainLock indicates an expected call of AcquireAuditChainLock.
+func (mr *MockQuerierMockRecorder) AcquireAuditChainLock(ctx, lockKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireAuditChainLock", reflect.TypeOf((*MockQuerier)(nil).AcquireAuditChainLock), ctx, lockKey)
+}
+
+// AcquireMigrationLock mocks base method.
+func (m *MockQuerier) AcquireMigrationLock(ctx context.Context, lockKey int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireMigrationLock", ctx, lockKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcquireMigrationLock indicates an expected call of AcquireMigrationLock.
+func (mr *MockQuerierMockRecorder) AcquireMigrationLock(ctx, lockKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireMigrationLock", reflect.TypeOf((*MockQuerier)(nil).AcquireMigrationLock), ctx, lockKey)
+}
+
+// BlockAccount mocks base method.
+func (m *MockQuerier) BlockAccount(ctx context.Context, accountID int64) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAccount", ctx, accountID)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockAccount indicates an expected call of BlockAccount.
+func (mr *MockQuerierMockRecorder) BlockAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAccount", reflect.TypeOf((*MockQuerier)(nil).BlockAccount), ctx, accountID)
+}
+
+// ClaimDueWebhookDeliveries mocks base method.
+func (m *MockQuerier) ClaimDueWebhookDeliveries(ctx context.Context, arg sqlc.ClaimDueWebhookDeliveriesParams) ([]sqlc.ClaimDueWebhookDeliveriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ClaimDueWebhookDeliveriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueWebhookDeliveries indicates an expected call of ClaimDueWebhookDeliveries.
+func (mr *MockQuerierMockRecorder) ClaimDueWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueWebhookDeliveries", reflect.TypeOf((*MockQuerier)(nil).ClaimDueWebhookDeliveries), ctx, arg)
+}
+
+// ClaimImportedReference mocks base method.
+func (m *MockQuerier) ClaimImportedReference(ctx context.Context, arg sqlc.ClaimImportedReferenceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimImportedReference", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimImportedReference indicates an expected call of ClaimImportedReference.
+func (mr *MockQuerierMockRecorder) ClaimImportedReference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimImportedReference", reflect.TypeOf((*MockQuerier)(nil).ClaimImportedReference), ctx, arg)
+}
+
+// CountOperationTypes mocks base method.
+func (m *MockQuerier) CountOperationTypes(ctx context.Context, operationTypeIds []int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOperationTypes", ctx, operationTypeIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOperationTypes indicates an expected call of CountOperationTypes.
+func (mr *MockQuerierMockRecorder) CountOperationTypes(ctx, operationTypeIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOperationTypes", reflect.TypeOf((*MockQuerier)(nil).CountOperationTypes), ctx, operationTypeIds)
+}
+
 // CreateAccount mocks base method.
-func (m *MockQuerier) CreateAccount(ctx context.Context, documentNumber string) (sqlc.Account, error) {
+func (m *MockQuerier) CreateAccount(ctx context.Context, arg sqlc.CreateAccountParams) (sqlc.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", ctx, documentNumber)
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
 	ret0, _ := ret[0].(sqlc.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockQuerierMockRecorder) CreateAccount(ctx, documentNumber any) *gomock.Call {
+func (mr *MockQuerierMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockQuerier)(nil).CreateAccount), ctx, documentNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockQuerier)(nil).CreateAccount), ctx, arg)
+}
+
+// CreateApiKey mocks base method.
+func (m *MockQuerier) CreateApiKey(ctx context.Context, arg sqlc.CreateApiKeyParams) (sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockQuerierMockRecorder) CreateApiKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockQuerier)(nil).CreateApiKey), ctx, arg)
+}
+
+// CreateAuditEntry mocks base method.
+func (m *MockQuerier) CreateAuditEntry(ctx context.Context, arg sqlc.CreateAuditEntryParams) (sqlc.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEntry", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditEntry indicates an expected call of CreateAuditEntry.
+func (mr *MockQuerierMockRecorder) CreateAuditEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEntry", reflect.TypeOf((*MockQuerier)(nil).CreateAuditEntry), ctx, arg)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockQuerier) CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) (sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockQuerierMockRecorder) CreateOutboxEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockQuerier)(nil).CreateOutboxEvent), ctx, arg)
+}
+
+// CreateSchemaMigrationsTable mocks base method.
+func (m *MockQuerier) CreateSchemaMigrationsTable(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchemaMigrationsTable", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchemaMigrationsTable indicates an expected call of CreateSchemaMigrationsTable.
+func (mr *MockQuerierMockRecorder) CreateSchemaMigrationsTable(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchemaMigrationsTable", reflect.TypeOf((*MockQuerier)(nil).CreateSchemaMigrationsTable), ctx)
 }
 
 // CreateTransaction mocks base method.
@@ -71,6 +218,94 @@ func (mr *MockQuerierMockRecorder) CreateTransaction(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockQuerier)(nil).CreateTransaction), ctx, arg)
 }
 
+// CreateWebhookDeliveries mocks base method.
+func (m *MockQuerier) CreateWebhookDeliveries(ctx context.Context, arg sqlc.CreateWebhookDeliveriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockQuerierMockRecorder) CreateWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockQuerier)(nil).CreateWebhookDeliveries), ctx, arg)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockQuerier) CreateWebhookSubscription(ctx context.Context, arg sqlc.CreateWebhookSubscriptionParams) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockQuerierMockRecorder) CreateWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockQuerier)(nil).CreateWebhookSubscription), ctx, arg)
+}
+
+// DeclareTransactionExportCursor mocks base method.
+func (m *MockQuerier) DeclareTransactionExportCursor(ctx context.Context, arg sqlc.DeclareTransactionExportCursorParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclareTransactionExportCursor", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclareTransactionExportCursor indicates an expected call of DeclareTransactionExportCursor.
+func (mr *MockQuerierMockRecorder) DeclareTransactionExportCursor(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclareTransactionExportCursor", reflect.TypeOf((*MockQuerier)(nil).DeclareTransactionExportCursor), ctx, arg)
+}
+
+// DeleteSchemaVersionsFrom mocks base method.
+func (m *MockQuerier) DeleteSchemaVersionsFrom(ctx context.Context, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchemaVersionsFrom", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchemaVersionsFrom indicates an expected call of DeleteSchemaVersionsFrom.
+func (mr *MockQuerierMockRecorder) DeleteSchemaVersionsFrom(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchemaVersionsFrom", reflect.TypeOf((*MockQuerier)(nil).DeleteSchemaVersionsFrom), ctx, version)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockQuerier) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, subscriptionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockQuerierMockRecorder) DeleteWebhookSubscription(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockQuerier)(nil).DeleteWebhookSubscription), ctx, subscriptionID)
+}
+
+// FetchTransactionExportCursor mocks base method.
+func (m *MockQuerier) FetchTransactionExportCursor(ctx context.Context) ([]sqlc.FetchTransactionExportCursorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTransactionExportCursor", ctx)
+	ret0, _ := ret[0].([]sqlc.FetchTransactionExportCursorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTransactionExportCursor indicates an expected call of FetchTransactionExportCursor.
+func (mr *MockQuerierMockRecorder) FetchTransactionExportCursor(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTransactionExportCursor", reflect.TypeOf((*MockQuerier)(nil).FetchTransactionExportCursor), ctx)
+}
+
 // GetAccountByID mocks base method.
 func (m *MockQuerier) GetAccountByID(ctx context.Context, accountID int64) (sqlc.Account, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +321,96 @@ func (mr *MockQuerierMockRecorder) GetAccountByID(ctx, accountID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockQuerier)(nil).GetAccountByID), ctx, accountID)
 }
 
+// GetAccountForUpdate mocks base method.
+func (m *MockQuerier) GetAccountForUpdate(ctx context.Context, accountID int64) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, accountID)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockQuerierMockRecorder) GetAccountForUpdate(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetAccountForUpdate), ctx, accountID)
+}
+
+// GetActiveApiKeyByHash mocks base method.
+func (m *MockQuerier) GetActiveApiKeyByHash(ctx context.Context, keyHash string) (sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveApiKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveApiKeyByHash indicates an expected call of GetActiveApiKeyByHash.
+func (mr *MockQuerierMockRecorder) GetActiveApiKeyByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveApiKeyByHash", reflect.TypeOf((*MockQuerier)(nil).GetActiveApiKeyByHash), ctx, keyHash)
+}
+
+// GetAllTransactionById mocks base method.
+func (m *MockQuerier) GetAllTransactionById(ctx context.Context, accountID int64) ([]sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTransactionById", ctx, accountID)
+	ret0, _ := ret[0].([]sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTransactionById indicates an expected call of GetAllTransactionById.
+func (mr *MockQuerierMockRecorder) GetAllTransactionById(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTransactionById", reflect.TypeOf((*MockQuerier)(nil).GetAllTransactionById), ctx, accountID)
+}
+
+// GetLatestAccountOutboxEventID mocks base method.
+func (m *MockQuerier) GetLatestAccountOutboxEventID(ctx context.Context, accountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAccountOutboxEventID", ctx, accountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAccountOutboxEventID indicates an expected call of GetLatestAccountOutboxEventID.
+func (mr *MockQuerierMockRecorder) GetLatestAccountOutboxEventID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAccountOutboxEventID", reflect.TypeOf((*MockQuerier)(nil).GetLatestAccountOutboxEventID), ctx, accountID)
+}
+
+// GetLatestAuditHash mocks base method.
+func (m *MockQuerier) GetLatestAuditHash(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAuditHash", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAuditHash indicates an expected call of GetLatestAuditHash.
+func (mr *MockQuerierMockRecorder) GetLatestAuditHash(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAuditHash", reflect.TypeOf((*MockQuerier)(nil).GetLatestAuditHash), ctx)
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockQuerier) GetSchemaVersion(ctx context.Context) (sqlc.SchemaMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx)
+	ret0, _ := ret[0].(sqlc.SchemaMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockQuerierMockRecorder) GetSchemaVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockQuerier)(nil).GetSchemaVersion), ctx)
+}
+
 // GetTransaction mocks base method.
 func (m *MockQuerier) GetTransaction(ctx context.Context, transactionID int64) (sqlc.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +426,81 @@ func (mr *MockQuerierMockRecorder) GetTransaction(ctx, transactionID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockQuerier)(nil).GetTransaction), ctx, transactionID)
 }
 
+// GetWebhookSubscriptionByID mocks base method.
+func (m *MockQuerier) GetWebhookSubscriptionByID(ctx context.Context, subscriptionID int64) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionByID", ctx, subscriptionID)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscriptionByID indicates an expected call of GetWebhookSubscriptionByID.
+func (mr *MockQuerierMockRecorder) GetWebhookSubscriptionByID(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionByID", reflect.TypeOf((*MockQuerier)(nil).GetWebhookSubscriptionByID), ctx, subscriptionID)
+}
+
+// ListAccountOutboxEventsAfter mocks base method.
+func (m *MockQuerier) ListAccountOutboxEventsAfter(ctx context.Context, arg sqlc.ListAccountOutboxEventsAfterParams) ([]sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountOutboxEventsAfter", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountOutboxEventsAfter indicates an expected call of ListAccountOutboxEventsAfter.
+func (mr *MockQuerierMockRecorder) ListAccountOutboxEventsAfter(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountOutboxEventsAfter", reflect.TypeOf((*MockQuerier)(nil).ListAccountOutboxEventsAfter), ctx, arg)
+}
+
+// ListAccountsToReencrypt mocks base method.
+func (m *MockQuerier) ListAccountsToReencrypt(ctx context.Context, arg sqlc.ListAccountsToReencryptParams) ([]sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsToReencrypt", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsToReencrypt indicates an expected call of ListAccountsToReencrypt.
+func (mr *MockQuerierMockRecorder) ListAccountsToReencrypt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsToReencrypt", reflect.TypeOf((*MockQuerier)(nil).ListAccountsToReencrypt), ctx, arg)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockQuerier) ListApiKeys(ctx context.Context) ([]sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys", ctx)
+	ret0, _ := ret[0].([]sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockQuerierMockRecorder) ListApiKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockQuerier)(nil).ListApiKeys), ctx)
+}
+
+// ListAuditEntries mocks base method.
+func (m *MockQuerier) ListAuditEntries(ctx context.Context, arg sqlc.ListAuditEntriesParams) ([]sqlc.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockQuerierMockRecorder) ListAuditEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockQuerier)(nil).ListAuditEntries), ctx, arg)
+}
+
 // ListTransactionsByAccount mocks base method.
 func (m *MockQuerier) ListTransactionsByAccount(ctx context.Context, accountID int64) ([]sqlc.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -115,3 +515,237 @@ func (mr *MockQuerierMockRecorder) ListTransactionsByAccount(ctx, accountID any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsByAccount", reflect.TypeOf((*MockQuerier)(nil).ListTransactionsByAccount), ctx, accountID)
 }
+
+// ListUndispatchedOutboxEvents mocks base method.
+func (m *MockQuerier) ListUndispatchedOutboxEvents(ctx context.Context, limit int32) ([]sqlc.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUndispatchedOutboxEvents", ctx, limit)
+	ret0, _ := ret[0].([]sqlc.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUndispatchedOutboxEvents indicates an expected call of ListUndispatchedOutboxEvents.
+func (mr *MockQuerierMockRecorder) ListUndispatchedOutboxEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUndispatchedOutboxEvents", reflect.TypeOf((*MockQuerier)(nil).ListUndispatchedOutboxEvents), ctx, limit)
+}
+
+// ListWebhookDeliveriesBySubscription mocks base method.
+func (m *MockQuerier) ListWebhookDeliveriesBySubscription(ctx context.Context, arg sqlc.ListWebhookDeliveriesBySubscriptionParams) ([]sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveriesBySubscription", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveriesBySubscription indicates an expected call of ListWebhookDeliveriesBySubscription.
+func (mr *MockQuerierMockRecorder) ListWebhookDeliveriesBySubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveriesBySubscription", reflect.TypeOf((*MockQuerier)(nil).ListWebhookDeliveriesBySubscription), ctx, arg)
+}
+
+// ListWebhookSubscriptions mocks base method.
+func (m *MockQuerier) ListWebhookSubscriptions(ctx context.Context) ([]sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions", ctx)
+	ret0, _ := ret[0].([]sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions.
+func (mr *MockQuerierMockRecorder) ListWebhookSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockQuerier)(nil).ListWebhookSubscriptions), ctx)
+}
+
+// LockImportCheckpoint mocks base method.
+func (m *MockQuerier) LockImportCheckpoint(ctx context.Context, fileHash string) (sqlc.ImportCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockImportCheckpoint", ctx, fileHash)
+	ret0, _ := ret[0].(sqlc.ImportCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockImportCheckpoint indicates an expected call of LockImportCheckpoint.
+func (mr *MockQuerierMockRecorder) LockImportCheckpoint(ctx, fileHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockImportCheckpoint", reflect.TypeOf((*MockQuerier)(nil).LockImportCheckpoint), ctx, fileHash)
+}
+
+// MarkOutboxEventDispatched mocks base method.
+func (m *MockQuerier) MarkOutboxEventDispatched(ctx context.Context, eventID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventDispatched", ctx, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxEventDispatched indicates an expected call of MarkOutboxEventDispatched.
+func (mr *MockQuerierMockRecorder) MarkOutboxEventDispatched(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventDispatched", reflect.TypeOf((*MockQuerier)(nil).MarkOutboxEventDispatched), ctx, eventID)
+}
+
+// MarkWebhookDeliveryFailed mocks base method.
+func (m *MockQuerier) MarkWebhookDeliveryFailed(ctx context.Context, arg sqlc.MarkWebhookDeliveryFailedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDeliveryFailed", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDeliveryFailed indicates an expected call of MarkWebhookDeliveryFailed.
+func (mr *MockQuerierMockRecorder) MarkWebhookDeliveryFailed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDeliveryFailed", reflect.TypeOf((*MockQuerier)(nil).MarkWebhookDeliveryFailed), ctx, arg)
+}
+
+// MarkWebhookDeliverySucceeded mocks base method.
+func (m *MockQuerier) MarkWebhookDeliverySucceeded(ctx context.Context, arg sqlc.MarkWebhookDeliverySucceededParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDeliverySucceeded", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDeliverySucceeded indicates an expected call of MarkWebhookDeliverySucceeded.
+func (mr *MockQuerierMockRecorder) MarkWebhookDeliverySucceeded(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDeliverySucceeded", reflect.TypeOf((*MockQuerier)(nil).MarkWebhookDeliverySucceeded), ctx, arg)
+}
+
+// ReleaseMigrationLock mocks base method.
+func (m *MockQuerier) ReleaseMigrationLock(ctx context.Context, lockKey int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMigrationLock", ctx, lockKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseMigrationLock indicates an expected call of ReleaseMigrationLock.
+func (mr *MockQuerierMockRecorder) ReleaseMigrationLock(ctx, lockKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMigrationLock", reflect.TypeOf((*MockQuerier)(nil).ReleaseMigrationLock), ctx, lockKey)
+}
+
+// ResealAccount mocks base method.
+func (m *MockQuerier) ResealAccount(ctx context.Context, arg sqlc.ResealAccountParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResealAccount", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResealAccount indicates an expected call of ResealAccount.
+func (mr *MockQuerierMockRecorder) ResealAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResealAccount", reflect.TypeOf((*MockQuerier)(nil).ResealAccount), ctx, arg)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockQuerier) RevokeApiKey(ctx context.Context, keyID int64) (sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", ctx, keyID)
+	ret0, _ := ret[0].(sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockQuerierMockRecorder) RevokeApiKey(ctx, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockQuerier)(nil).RevokeApiKey), ctx, keyID)
+}
+
+// SetSchemaVersion mocks base method.
+func (m *MockQuerier) SetSchemaVersion(ctx context.Context, arg sqlc.SetSchemaVersionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchemaVersion", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSchemaVersion indicates an expected call of SetSchemaVersion.
+func (mr *MockQuerierMockRecorder) SetSchemaVersion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchemaVersion", reflect.TypeOf((*MockQuerier)(nil).SetSchemaVersion), ctx, arg)
+}
+
+// StartImportCheckpoint mocks base method.
+func (m *MockQuerier) StartImportCheckpoint(ctx context.Context, arg sqlc.StartImportCheckpointParams) (sqlc.ImportCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImportCheckpoint", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ImportCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImportCheckpoint indicates an expected call of StartImportCheckpoint.
+func (mr *MockQuerierMockRecorder) StartImportCheckpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImportCheckpoint", reflect.TypeOf((*MockQuerier)(nil).StartImportCheckpoint), ctx, arg)
+}
+
+// UpdateAccountProfile mocks base method.
+func (m *MockQuerier) UpdateAccountProfile(ctx context.Context, arg sqlc.UpdateAccountProfileParams) (sqlc.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountProfile", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountProfile indicates an expected call of UpdateAccountProfile.
+func (mr *MockQuerierMockRecorder) UpdateAccountProfile(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountProfile", reflect.TypeOf((*MockQuerier)(nil).UpdateAccountProfile), ctx, arg)
+}
+
+// UpdateImportCheckpoint mocks base method.
+func (m *MockQuerier) UpdateImportCheckpoint(ctx context.Context, arg sqlc.UpdateImportCheckpointParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImportCheckpoint", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImportCheckpoint indicates an expected call of UpdateImportCheckpoint.
+func (mr *MockQuerierMockRecorder) UpdateImportCheckpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImportCheckpoint", reflect.TypeOf((*MockQuerier)(nil).UpdateImportCheckpoint), ctx, arg)
+}
+
+// UpdateTransaction mocks base method.
+func (m *MockQuerier) UpdateTransaction(ctx context.Context, arg sqlc.UpdateTransactionParams) (sqlc.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransaction", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransaction indicates an expected call of UpdateTransaction.
+func (mr *MockQuerierMockRecorder) UpdateTransaction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransaction", reflect.TypeOf((*MockQuerier)(nil).UpdateTransaction), ctx, arg)
+}
+
+// UpdateWebhookSubscription mocks base method.
+func (m *MockQuerier) UpdateWebhookSubscription(ctx context.Context, arg sqlc.UpdateWebhookSubscriptionParams) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookSubscription indicates an expected call of UpdateWebhookSubscription.
+func (mr *MockQuerierMockRecorder) UpdateWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookSubscription", reflect.TypeOf((*MockQuerier)(nil).UpdateWebhookSubscription), ctx, arg)
+}
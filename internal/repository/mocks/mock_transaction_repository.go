@@ -55,3 +55,46 @@ func (mr *MockTransactionRepositoryMockRecorder) Create(ctx, transactionParam an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionRepository)(nil).Create), ctx, transactionParam)
 }
+
+// ExportTransactions mocks base method.
+func (m *MockTransactionRepository) ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockTransactionRepositoryMockRecorder) ExportTransactions(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).ExportTransactions), ctx, filter, fn)
+}
+
+// GetAllTransactions mocks base method.
+func (m *MockTransactionRepository) GetAllTransactions(ctx context.Context, accountId int64) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTransactions", ctx, accountId)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTransactions indicates an expected call of GetAllTransactions.
+func (mr *MockTransactionRepositoryMockRecorder) GetAllTransactions(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).GetAllTransactions), ctx, accountId)
+}
+
+// UpdateTransactionById mocks base method.
+func (m *MockTransactionRepository) UpdateTransactionById(ctx context.Context, transactionId int64, balance float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionById", ctx, transactionId, balance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTransactionById indicates an expected call of UpdateTransactionById.
+func (mr *MockTransactionRepositoryMockRecorder) UpdateTransactionById(ctx, transactionId, balance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionById", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateTransactionById), ctx, transactionId, balance)
+}
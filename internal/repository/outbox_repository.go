@@ -0,0 +1,125 @@
+package repository
+
+//go:generate mockgen -source=outbox_repository.go -destination=mocks/mock_outbox_repository.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/redact"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, eventParam domain.CreateOutboxEventParam) (*domain.OutboxEvent, error)
+	ListUndispatched(ctx context.Context, limit int32) ([]domain.OutboxEvent, error)
+	MarkDispatched(ctx context.Context, eventId int64) error
+	// ListByAccountAfter and GetLatestEventId only see events whose transaction is older than every transaction
+	// still running: event ids are drawn before commit, so a reader resuming after an id would otherwise skip a lower
+	// id committed later.
+	ListByAccountAfter(ctx context.Context, accountId int64, afterEventId int64, eventTypes []string, limit int32) ([]domain.OutboxEvent, error)
+	GetLatestEventId(ctx context.Context, accountId int64) (int64, error)
+}
+
+type outboxRepository struct {
+	querier sqlc.Querier
+}
+
+func NewOutboxRepository(querier sqlc.Querier) OutboxRepository {
+	return &outboxRepository{querier: querier}
+}
+
+// Create must be called with the same context as the state change it describes,
+// so the event is committed or rolled back together with it. Payload fields tagged for redaction are masked:
+// events are sent to third-party webhooks.
+func (or *outboxRepository) Create(ctx context.Context, eventParam domain.CreateOutboxEventParam) (*domain.OutboxEvent, error) {
+	payload, err := json.Marshal(redact.Value(eventParam.Payload))
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while marshal %s event payload: %s", eventParam.EventType, err.Error())
+		return nil, err
+	}
+
+	event, err := or.getQuerier(ctx).CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		EventType:     eventParam.EventType,
+		AggregateType: eventParam.AggregateType,
+		AggregateID:   eventParam.AggregateId,
+		AccountID:     eventParam.AccountId,
+		Payload:       payload,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while create outbox event: %s", err.Error())
+		return nil, err
+	}
+	return mapToDomainOutboxEvent(event), nil
+}
+
+func (or *outboxRepository) ListUndispatched(ctx context.Context, limit int32) ([]domain.OutboxEvent, error) {
+	var eventList []domain.OutboxEvent
+	events, err := or.getQuerier(ctx).ListUndispatchedOutboxEvents(ctx, limit)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch undispatched outbox events: %s", err.Error())
+		return nil, err
+	}
+	for _, event := range events {
+		eventList = append(eventList, *mapToDomainOutboxEvent(event))
+	}
+	return eventList, nil
+}
+
+func (or *outboxRepository) MarkDispatched(ctx context.Context, eventId int64) error {
+	err := or.getQuerier(ctx).MarkOutboxEventDispatched(ctx, eventId)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while mark outbox event %d as dispatched: %s", eventId, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (or *outboxRepository) ListByAccountAfter(ctx context.Context, accountId int64, afterEventId int64, eventTypes []string, limit int32) ([]domain.OutboxEvent, error) {
+	var eventList []domain.OutboxEvent
+	events, err := or.getQuerier(ctx).ListAccountOutboxEventsAfter(ctx, sqlc.ListAccountOutboxEventsAfterParams{
+		AccountID:    accountId,
+		AfterEventID: afterEventId,
+		EventTypes:   eventTypes,
+		RowLimit:     limit,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch outbox events of account id:%d after event id:%d, error: %s", accountId, afterEventId, err.Error())
+		return nil, err
+	}
+	for _, event := range events {
+		eventList = append(eventList, *mapToDomainOutboxEvent(event))
+	}
+	return eventList, nil
+}
+
+func (or *outboxRepository) GetLatestEventId(ctx context.Context, accountId int64) (int64, error) {
+	eventId, err := or.getQuerier(ctx).GetLatestAccountOutboxEventID(ctx, accountId)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch latest outbox event id of account id:%d, error: %s", accountId, err.Error())
+		return 0, err
+	}
+	return eventId, nil
+}
+
+func mapToDomainOutboxEvent(event sqlc.OutboxEvent) *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		Id:            event.EventID,
+		EventType:     event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateId:   event.AggregateID,
+		AccountId:     event.AccountID,
+		Payload:       event.Payload,
+		CreatedAt:     event.CreatedAt.Time,
+	}
+}
+
+func (or *outboxRepository) getQuerier(ctx context.Context) sqlc.Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return sqlc.New(tx)
+	}
+	return or.querier
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OutboxRepositoryTestSuite struct {
+	suite.Suite
+	context          context.Context
+	mockController   *gomock.Controller
+	mockQuerier      *mocks.MockQuerier
+	outboxRepository OutboxRepository
+}
+
+func TestOutboxRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxRepositoryTestSuite))
+}
+
+func (suite *OutboxRepositoryTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
+	suite.outboxRepository = NewOutboxRepository(suite.mockQuerier)
+}
+
+func (suite *OutboxRepositoryTestSuite) TestOutboxRepository_Create_Marshals_Payload() {
+	params := sqlc.CreateOutboxEventParams{
+		EventType:     domain.EventTransactionBalanceUpdated,
+		AggregateType: domain.AggregateTransaction,
+		AggregateID:   3,
+		AccountID:     1,
+		Payload:       []byte(`{"transaction_id":3,"account_id":1,"previous_balance":-50,"balance":0}`),
+	}
+	suite.mockQuerier.EXPECT().CreateOutboxEvent(suite.context, params).Return(sqlc.OutboxEvent{EventID: 9, EventType: params.EventType, Payload: params.Payload}, nil)
+
+	res, err := suite.outboxRepository.Create(suite.context, domain.CreateOutboxEventParam{
+		EventType:     domain.EventTransactionBalanceUpdated,
+		AggregateType: domain.AggregateTransaction,
+		AggregateId:   3,
+		AccountId:     1,
+		Payload: domain.TransactionBalanceUpdatedPayload{
+			TransactionId:   3,
+			AccountId:       1,
+			PreviousBalance: -50,
+			Balance:         0,
+		},
+	})
+
+	suite.NoError(err)
+	suite.Equal(int64(9), res.Id)
+}
+
+func (suite *OutboxRepositoryTestSuite) TestOutboxRepository_Create_Masks_Redacted_Fields() {
+	params := sqlc.CreateOutboxEventParams{
+		EventType:     domain.EventAccountCreated,
+		AggregateType: domain.AggregateAccount,
+		AggregateID:   1,
+		AccountID:     1,
+		Payload:       []byte(`{"account_id":1,"document_number":"******6789","created_at":"2026-01-01T10:00:00Z"}`),
+	}
+	suite.mockQuerier.EXPECT().CreateOutboxEvent(suite.context, params).Return(sqlc.OutboxEvent{EventID: 4, EventType: params.EventType, Payload: params.Payload}, nil)
+
+	_, err := suite.outboxRepository.Create(suite.context, domain.CreateOutboxEventParam{
+		EventType:     domain.EventAccountCreated,
+		AggregateType: domain.AggregateAccount,
+		AggregateId:   1,
+		AccountId:     1,
+		Payload: domain.AccountCreatedPayload{
+			AccountId:      1,
+			DocumentNumber: "0123456789",
+			CreatedAt:      time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
+		},
+	})
+
+	suite.NoError(err)
+}
+
+func (suite *OutboxRepositoryTestSuite) TestOutboxRepository_ListUndispatched() {
+	suite.mockQuerier.EXPECT().ListUndispatchedOutboxEvents(suite.context, int32(50)).Return([]sqlc.OutboxEvent{{EventID: 1}, {EventID: 2}}, nil)
+
+	res, err := suite.outboxRepository.ListUndispatched(suite.context, 50)
+
+	suite.NoError(err)
+	suite.Len(res, 2)
+	suite.Equal(int64(2), res[1].Id)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repositories is a storage backend: Postgres, or the memory package for local development and tests.
+type Repositories struct {
+	Accounts          AccountRepository
+	Transactions      TransactionRepository
+	Outbox            OutboxRepository
+	Audit             AuditRepository
+	ApiKeys           ApiKeyRepository
+	Webhooks          WebhookRepository
+	Health            HealthRepository
+	ImportCheckpoints ImportCheckpointRepository
+	Transactor        Transactor
+}
+
+// NewPostgresRepositories returns every repository over pool, document numbers encrypted with keyring.
+func NewPostgresRepositories(pool *pgxpool.Pool, keyring *encryption.Keyring) Repositories {
+	queries := sqlc.New(pool)
+	return Repositories{
+		Accounts:          NewAccountRepository(queries, keyring),
+		Transactions:      NewTransactionRepository(queries),
+		Outbox:            NewOutboxRepository(queries),
+		Audit:             NewAuditRepository(queries),
+		ApiKeys:           NewApiKeyRepository(queries),
+		Webhooks:          NewWebhookRepository(queries),
+		Health:            NewHealthRepository(pool, queries),
+		ImportCheckpoints: NewImportCheckpointRepository(queries),
+		Transactor:        NewTransactor(pool),
+	}
+}
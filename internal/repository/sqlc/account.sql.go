@@ -7,29 +7,230 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const blockAccount = `-- name: BlockAccount :one
+UPDATE accounts
+SET blocked_at = COALESCE(blocked_at, now()),
+    version    = CASE WHEN blocked_at IS NULL THEN version + 1 ELSE version END,
+    updated_at = CASE WHEN blocked_at IS NULL THEN now() ELSE updated_at END
+WHERE account_id = $1
+    RETURNING account_id, document_number, created_at, document_key_id, document_number_index, blocked_at, version, updated_at, profile, profile_key_id
+`
+
+func (q *Queries) BlockAccount(ctx context.Context, accountID int64) (Account, error) {
+	row := q.db.QueryRow(ctx, blockAccount, accountID)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.DocumentNumber,
+		&i.CreatedAt,
+		&i.DocumentKeyID,
+		&i.DocumentNumberIndex,
+		&i.BlockedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Profile,
+		&i.ProfileKeyID,
+	)
+	return i, err
+}
+
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (document_number)
-VALUES ($1)
-    RETURNING account_id, document_number, created_at
+INSERT INTO accounts (document_number, document_key_id, document_number_index)
+VALUES ($1, $2, $3)
+    RETURNING account_id, document_number, created_at, document_key_id, document_number_index, blocked_at, version, updated_at, profile, profile_key_id
 `
 
-func (q *Queries) CreateAccount(ctx context.Context, documentNumber string) (Account, error) {
-	row := q.db.QueryRow(ctx, createAccount, documentNumber)
+type CreateAccountParams struct {
+	DocumentNumber      []byte `json:"document_number"`
+	DocumentKeyID       string `json:"document_key_id"`
+	DocumentNumberIndex []byte `json:"document_number_index"`
+}
+
+func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	row := q.db.QueryRow(ctx, createAccount, arg.DocumentNumber, arg.DocumentKeyID, arg.DocumentNumberIndex)
 	var i Account
-	err := row.Scan(&i.AccountID, &i.DocumentNumber, &i.CreatedAt)
+	err := row.Scan(
+		&i.AccountID,
+		&i.DocumentNumber,
+		&i.CreatedAt,
+		&i.DocumentKeyID,
+		&i.DocumentNumberIndex,
+		&i.BlockedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Profile,
+		&i.ProfileKeyID,
+	)
 	return i, err
 }
 
 const getAccountByID = `-- name: GetAccountByID :one
-SELECT account_id, document_number, created_at FROM accounts
+SELECT account_id, document_number, created_at, document_key_id, document_number_index, blocked_at, version, updated_at, profile, profile_key_id FROM accounts
 WHERE account_id = $1 LIMIT 1
 `
 
 func (q *Queries) GetAccountByID(ctx context.Context, accountID int64) (Account, error) {
 	row := q.db.QueryRow(ctx, getAccountByID, accountID)
 	var i Account
-	err := row.Scan(&i.AccountID, &i.DocumentNumber, &i.CreatedAt)
+	err := row.Scan(
+		&i.AccountID,
+		&i.DocumentNumber,
+		&i.CreatedAt,
+		&i.DocumentKeyID,
+		&i.DocumentNumberIndex,
+		&i.BlockedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Profile,
+		&i.ProfileKeyID,
+	)
+	return i, err
+}
+
+const getAccountForUpdate = `-- name: GetAccountForUpdate :one
+SELECT account_id, document_number, created_at, document_key_id, document_number_index, blocked_at, version, updated_at, profile, profile_key_id FROM accounts
+WHERE account_id = $1 LIMIT 1
+    FOR UPDATE
+`
+
+func (q *Queries) GetAccountForUpdate(ctx context.Context, accountID int64) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountForUpdate, accountID)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.DocumentNumber,
+		&i.CreatedAt,
+		&i.DocumentKeyID,
+		&i.DocumentNumberIndex,
+		&i.BlockedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Profile,
+		&i.ProfileKeyID,
+	)
+	return i, err
+}
+
+const listAccountsToReencrypt = `-- name: ListAccountsToReencrypt :many
+SELECT account_id, document_number, created_at, document_key_id, document_number_index, blocked_at, version, updated_at, profile, profile_key_id FROM accounts
+WHERE (document_key_id <> $1 OR profile_key_id <> $1)
+  AND account_id > $2
+ORDER BY account_id
+LIMIT $3
+`
+
+type ListAccountsToReencryptParams struct {
+	PrimaryKeyID   string `json:"primary_key_id"`
+	AfterAccountID int64  `json:"after_account_id"`
+	BatchSize      int32  `json:"batch_size"`
+}
+
+func (q *Queries) ListAccountsToReencrypt(ctx context.Context, arg ListAccountsToReencryptParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsToReencrypt, arg.PrimaryKeyID, arg.AfterAccountID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.DocumentNumber,
+			&i.CreatedAt,
+			&i.DocumentKeyID,
+			&i.DocumentNumberIndex,
+			&i.BlockedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Profile,
+			&i.ProfileKeyID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resealAccount = `-- name: ResealAccount :execrows
+UPDATE accounts
+SET document_number = $3,
+    document_key_id = $4,
+    profile         = $5,
+    profile_key_id  = $6
+WHERE account_id = $1
+  AND version = $2
+`
+
+type ResealAccountParams struct {
+	AccountID      int64       `json:"account_id"`
+	Version        int32       `json:"version"`
+	DocumentNumber []byte      `json:"document_number"`
+	DocumentKeyID  string      `json:"document_key_id"`
+	Profile        []byte      `json:"profile"`
+	ProfileKeyID   pgtype.Text `json:"profile_key_id"`
+}
+
+func (q *Queries) ResealAccount(ctx context.Context, arg ResealAccountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resealAccount,
+		arg.AccountID,
+		arg.Version,
+		arg.DocumentNumber,
+		arg.DocumentKeyID,
+		arg.Profile,
+		arg.ProfileKeyID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAccountProfile = `-- name: UpdateAccountProfile :one
+UPDATE accounts
+SET profile        = $3,
+    profile_key_id = $4,
+    version        = version + 1,
+    updated_at     = now()
+WHERE account_id = $1
+  AND version = $2
+    RETURNING account_id, document_number, created_at, document_key_id, document_number_index, blocked_at, version, updated_at, profile, profile_key_id
+`
+
+type UpdateAccountProfileParams struct {
+	AccountID    int64       `json:"account_id"`
+	Version      int32       `json:"version"`
+	Profile      []byte      `json:"profile"`
+	ProfileKeyID pgtype.Text `json:"profile_key_id"`
+}
+
+func (q *Queries) UpdateAccountProfile(ctx context.Context, arg UpdateAccountProfileParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountProfile,
+		arg.AccountID,
+		arg.Version,
+		arg.Profile,
+		arg.ProfileKeyID,
+	)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.DocumentNumber,
+		&i.CreatedAt,
+		&i.DocumentKeyID,
+		&i.DocumentNumberIndex,
+		&i.BlockedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Profile,
+		&i.ProfileKeyID,
+	)
 	return i, err
 }
@@ -9,9 +9,61 @@ import (
 )
 
 type Account struct {
-	AccountID      int64              `json:"account_id"`
-	DocumentNumber string             `json:"document_number"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	AccountID           int64              `json:"account_id"`
+	DocumentNumber      []byte             `json:"document_number"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	DocumentKeyID       string             `json:"document_key_id"`
+	DocumentNumberIndex []byte             `json:"document_number_index"`
+	BlockedAt           pgtype.Timestamptz `json:"blocked_at"`
+	Version             int32              `json:"version"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	Profile             []byte             `json:"profile"`
+	ProfileKeyID        pgtype.Text        `json:"profile_key_id"`
+}
+
+type ApiKey struct {
+	KeyID       int64              `json:"key_id"`
+	Name        string             `json:"name"`
+	KeyPrefix   string             `json:"key_prefix"`
+	KeyHash     string             `json:"key_hash"`
+	Scopes      []string           `json:"scopes"`
+	RotatedFrom pgtype.Int8        `json:"rotated_from"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type AuditLog struct {
+	AuditID      int64              `json:"audit_id"`
+	Actor        string             `json:"actor"`
+	Action       string             `json:"action"`
+	ResourceType string             `json:"resource_type"`
+	ResourceID   string             `json:"resource_id"`
+	BeforeState  []byte             `json:"before_state"`
+	AfterState   []byte             `json:"after_state"`
+	Diff         []byte             `json:"diff"`
+	RequestID    string             `json:"request_id"`
+	SourceIp     string             `json:"source_ip"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	PrevHash     string             `json:"prev_hash"`
+	Hash         string             `json:"hash"`
+}
+
+type ImportCheckpoint struct {
+	FileHash  string             `json:"file_hash"`
+	FileName  string             `json:"file_name"`
+	Line      int64              `json:"line"`
+	Posted    int64              `json:"posted"`
+	Rejected  int64              `json:"rejected"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ImportedReference struct {
+	Source    string             `json:"source"`
+	Reference string             `json:"reference"`
+	FileHash  string             `json:"file_hash"`
+	Line      int64              `json:"line"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type OperationType struct {
@@ -19,6 +71,23 @@ type OperationType struct {
 	Description     string `json:"description"`
 }
 
+type OutboxEvent struct {
+	EventID       int64              `json:"event_id"`
+	EventType     string             `json:"event_type"`
+	AggregateType string             `json:"aggregate_type"`
+	AggregateID   int64              `json:"aggregate_id"`
+	AccountID     int64              `json:"account_id"`
+	Payload       []byte             `json:"payload"`
+	DispatchedAt  pgtype.Timestamptz `json:"dispatched_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	CreatedXid    pgtype.Uint64      `json:"created_xid"`
+}
+
+type SchemaMigration struct {
+	Version int64 `json:"version"`
+	Dirty   bool  `json:"dirty"`
+}
+
 type Transaction struct {
 	TransactionID   int64              `json:"transaction_id"`
 	AccountID       int64              `json:"account_id"`
@@ -27,3 +96,26 @@ type Transaction struct {
 	Balance         pgtype.Numeric     `json:"balance"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 }
+
+type WebhookDelivery struct {
+	DeliveryID     int64              `json:"delivery_id"`
+	EventID        int64              `json:"event_id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastStatusCode pgtype.Int4        `json:"last_status_code"`
+	LastError      pgtype.Text        `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	SubscriptionID int64              `json:"subscription_id"`
+	Url            string             `json:"url"`
+	Secret         string             `json:"secret"`
+	EventTypes     []string           `json:"event_types"`
+	Active         bool               `json:"active"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
@@ -9,13 +9,54 @@ import (
 )
 
 type Querier interface {
-	CreateAccount(ctx context.Context, documentNumber string) (Account, error)
+	AcquireAuditChainLock(ctx context.Context, lockKey int64) error
+	AcquireMigrationLock(ctx context.Context, lockKey int64) error
+	BlockAccount(ctx context.Context, accountID int64) (Account, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
+	ClaimImportedReference(ctx context.Context, arg ClaimImportedReferenceParams) (int64, error)
+	CountOperationTypes(ctx context.Context, operationTypeIds []int32) (int64, error)
+	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) (AuditLog, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
+	CreateSchemaMigrationsTable(ctx context.Context) error
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	DeclareTransactionExportCursor(ctx context.Context, arg DeclareTransactionExportCursorParams) error
+	DeleteSchemaVersionsFrom(ctx context.Context, version int64) error
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID int64) (int64, error)
+	FetchTransactionExportCursor(ctx context.Context) ([]FetchTransactionExportCursorRow, error)
 	GetAccountByID(ctx context.Context, accountID int64) (Account, error)
+	GetAccountForUpdate(ctx context.Context, accountID int64) (Account, error)
+	GetActiveApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	GetAllTransactionById(ctx context.Context, accountID int64) ([]Transaction, error)
+	GetLatestAccountOutboxEventID(ctx context.Context, accountID int64) (int64, error)
+	GetLatestAuditHash(ctx context.Context) (string, error)
+	GetSchemaVersion(ctx context.Context) (SchemaMigration, error)
 	GetTransaction(ctx context.Context, transactionID int64) (Transaction, error)
+	GetWebhookSubscriptionByID(ctx context.Context, subscriptionID int64) (WebhookSubscription, error)
+	ListAccountOutboxEventsAfter(ctx context.Context, arg ListAccountOutboxEventsAfterParams) ([]OutboxEvent, error)
+	ListAccountsToReencrypt(ctx context.Context, arg ListAccountsToReencryptParams) ([]Account, error)
+	ListApiKeys(ctx context.Context) ([]ApiKey, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error)
 	ListTransactionsByAccount(ctx context.Context, accountID int64) ([]Transaction, error)
+	ListUndispatchedOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	ListWebhookDeliveriesBySubscription(ctx context.Context, arg ListWebhookDeliveriesBySubscriptionParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	LockImportCheckpoint(ctx context.Context, fileHash string) (ImportCheckpoint, error)
+	MarkOutboxEventDispatched(ctx context.Context, eventID int64) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error
+	ReleaseMigrationLock(ctx context.Context, lockKey int64) error
+	ResealAccount(ctx context.Context, arg ResealAccountParams) (int64, error)
+	RevokeApiKey(ctx context.Context, keyID int64) (ApiKey, error)
+	SetSchemaVersion(ctx context.Context, arg SetSchemaVersionParams) error
+	StartImportCheckpoint(ctx context.Context, arg StartImportCheckpointParams) (ImportCheckpoint, error)
+	UpdateAccountProfile(ctx context.Context, arg UpdateAccountProfileParams) (Account, error)
+	UpdateImportCheckpoint(ctx context.Context, arg UpdateImportCheckpointParams) error
 	UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
 }
 
 var _ Querier = (*Queries)(nil)
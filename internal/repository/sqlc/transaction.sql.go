@@ -43,11 +43,75 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 	return i, err
 }
 
+const declareTransactionExportCursor = `-- name: DeclareTransactionExportCursor :exec
+DECLARE transaction_export NO SCROLL CURSOR FOR
+SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, t.balance, t.created_at, o.description
+FROM transactions t
+         JOIN operation_types o ON o.operation_type_id = t.operation_type_id
+WHERE t.account_id = $1
+  AND ($2::TIMESTAMPTZ IS NULL OR t.created_at >= $2)
+  AND ($3::TIMESTAMPTZ IS NULL OR t.created_at < $3)
+ORDER BY t.created_at ASC, t.transaction_id ASC
+`
+
+type DeclareTransactionExportCursorParams struct {
+	AccountID int64              `json:"account_id"`
+	FromTime  pgtype.Timestamptz `json:"from_time"`
+	ToTime    pgtype.Timestamptz `json:"to_time"`
+}
+
+func (q *Queries) DeclareTransactionExportCursor(ctx context.Context, arg DeclareTransactionExportCursorParams) error {
+	_, err := q.db.Exec(ctx, declareTransactionExportCursor, arg.AccountID, arg.FromTime, arg.ToTime)
+	return err
+}
+
+const fetchTransactionExportCursor = `-- name: FetchTransactionExportCursor :many
+FETCH FORWARD 500 FROM transaction_export
+`
+
+type FetchTransactionExportCursorRow struct {
+	TransactionID   int64              `json:"transaction_id"`
+	AccountID       int64              `json:"account_id"`
+	OperationTypeID int64              `json:"operation_type_id"`
+	Amount          pgtype.Numeric     `json:"amount"`
+	Balance         pgtype.Numeric     `json:"balance"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Description     string             `json:"description"`
+}
+
+func (q *Queries) FetchTransactionExportCursor(ctx context.Context) ([]FetchTransactionExportCursorRow, error) {
+	rows, err := q.db.Query(ctx, fetchTransactionExportCursor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FetchTransactionExportCursorRow
+	for rows.Next() {
+		var i FetchTransactionExportCursorRow
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.AccountID,
+			&i.OperationTypeID,
+			&i.Amount,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllTransactionById = `-- name: GetAllTransactionById :many
 SELECT transaction_id, account_id, operation_type_id, amount, balance, created_at
 FROM transactions
 WHERE account_id = $1
-ORDER BY created_at ASC
+ORDER BY created_at ASC, transaction_id ASC
 `
 
 func (q *Queries) GetAllTransactionById(ctx context.Context, accountID int64) ([]Transaction, error) {
@@ -99,7 +163,7 @@ func (q *Queries) GetTransaction(ctx context.Context, transactionID int64) (Tran
 const listTransactionsByAccount = `-- name: ListTransactionsByAccount :many
 SELECT transaction_id, account_id, operation_type_id, amount, balance, created_at FROM transactions
 WHERE account_id = $1
-ORDER BY created_at DESC
+ORDER BY created_at DESC, transaction_id DESC
 `
 
 func (q *Queries) ListTransactionsByAccount(ctx context.Context, accountID int64) ([]Transaction, error) {
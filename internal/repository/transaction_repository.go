@@ -4,21 +4,28 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
 	"github.com/credit-card-api/internal/repository/sqlc"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	logger "github.com/sirupsen/logrus"
 )
 
 type TransactionRepository interface {
 	Create(ctx context.Context, transactionParam domain.CreateTransactionParam) (*domain.Transaction, error)
 	GetAllTransactions(ctx context.Context, accountId int64) ([]domain.Transaction, error)
 	UpdateTransactionById(ctx context.Context, transactionId int64, balance float64) error
+	// ExportTransactions must run inside a transaction. It calls fn with every transaction of filter, oldest first,
+	// read through a server-side cursor a page at a time, and stops at the first error fn returns.
+	ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error
 }
 
+// transactionExportFetchSize is the page of the FETCH in FetchTransactionExportCursor.
+const transactionExportFetchSize = 500
+
 type transactionRepository struct {
 	querier sqlc.Querier
 }
@@ -36,10 +43,10 @@ func (tr *transactionRepository) Create(ctx context.Context, transactionParam do
 	})
 
 	if err != nil {
-		logger.Errorf("error while create transaction: %s", err.Error())
+		logging.FromContext(ctx).Errorf("error while create transaction: %s", err.Error())
 		return nil, err
 	}
-	logger.Info("transaction created successfully in db.")
+	logging.FromContext(ctx).Info("transaction created successfully in db.")
 	return mapToDomainTransaction(transaction), nil
 }
 
@@ -47,7 +54,7 @@ func (tr *transactionRepository) GetAllTransactions(ctx context.Context, account
 	var transactionList []domain.Transaction
 	transactions, err := tr.getQuerier(ctx).GetAllTransactionById(ctx, accountId)
 	if err != nil {
-		logger.Errorf("error while fetch all transactions: %s", err.Error())
+		logging.FromContext(ctx).Errorf("error while fetch all transactions: %s", err.Error())
 		return nil, err
 	}
 	for _, tx := range transactions {
@@ -62,12 +69,47 @@ func (tr *transactionRepository) UpdateTransactionById(ctx context.Context, tran
 		Balance:       float64ToNumeric(balance),
 	})
 	if err != nil {
-		logger.Errorf("error while fetch all transactions: %s", err.Error())
+		logging.FromContext(ctx).Errorf("error while fetch all transactions: %s", err.Error())
 		return err
 	}
 	return nil
 }
 
+func (tr *transactionRepository) ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	if !ok {
+		return errors.New("ExportTransactions must run inside a transaction")
+	}
+	querier := sqlc.New(tx)
+	params := sqlc.DeclareTransactionExportCursorParams{AccountID: filter.AccountId}
+	if filter.From != nil {
+		params.FromTime = pgtype.Timestamptz{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		params.ToTime = pgtype.Timestamptz{Time: *filter.To, Valid: true}
+	}
+	if err := querier.DeclareTransactionExportCursor(ctx, params); err != nil {
+		logging.FromContext(ctx).Errorf("error while declare transaction export cursor of accountId: %d, error: %s", filter.AccountId, err.Error())
+		return err
+	}
+
+	for {
+		rows, err := querier.FetchTransactionExportCursor(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("error while fetch transaction export cursor of accountId: %d, error: %s", filter.AccountId, err.Error())
+			return err
+		}
+		for _, row := range rows {
+			if err := fn(mapToDomainExportedTransaction(row)); err != nil {
+				return err
+			}
+		}
+		if len(rows) < transactionExportFetchSize {
+			return nil
+		}
+	}
+}
+
 func float64ToNumeric(val float64) pgtype.Numeric {
 	var n pgtype.Numeric
 	err := n.Scan(fmt.Sprintf("%.2f", val))
@@ -96,6 +138,20 @@ func mapToDomainTransaction(transaction sqlc.Transaction) *domain.Transaction {
 	}
 }
 
+func mapToDomainExportedTransaction(row sqlc.FetchTransactionExportCursorRow) domain.ExportedTransaction {
+	return domain.ExportedTransaction{
+		Transaction: domain.Transaction{
+			Id:              row.TransactionID,
+			AccountId:       row.AccountID,
+			OperationTypeId: row.OperationTypeID,
+			Amount:          numericToFloat64(row.Amount),
+			Balance:         numericToFloat64(row.Balance),
+			CreatedAt:       row.CreatedAt.Time,
+		},
+		OperationDescription: row.Description,
+	}
+}
+
 func (tr *transactionRepository) getQuerier(ctx context.Context) sqlc.Querier {
 	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
 		return sqlc.New(tx)
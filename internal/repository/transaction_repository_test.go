@@ -69,3 +69,15 @@ func (suite *TransactionRepositoryTestSuite) TestTransactionRepository_Create_Re
 	suite.Nil(res)
 
 }
+
+func (suite *TransactionRepositoryTestSuite) TestTransactionRepository_ExportTransactions_Requires_Transaction() {
+	called := false
+
+	err := suite.transactionRepository.ExportTransactions(suite.context, domain.TransactionExportFilter{AccountId: 1}, func(domain.ExportedTransaction) error {
+		called = true
+		return nil
+	})
+
+	suite.EqualError(err, "ExportTransactions must run inside a transaction")
+	suite.False(called)
+}
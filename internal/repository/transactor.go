@@ -1,15 +1,25 @@
 package repository
 
+//go:generate mockgen -source=transactor.go -destination=mocks/mock_transactor.go -package=mocks
+
 import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Private key to avoid context collisions
 type txKey struct{}
 
+type afterCommitKey struct{}
+
+// afterCommit holds the callbacks registered within the outermost transaction.
+type afterCommit struct {
+	callbacks []func()
+}
+
 type Transactor interface {
 	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -22,18 +32,53 @@ func NewTransactor(pool *pgxpool.Pool) Transactor {
 	return &transactor{pool: pool}
 }
 
+// WithinTransaction joins the transaction already carried by ctx, so services can call each other
+// without opening independent transactions.
 func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := t.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
 
 	defer tx.Rollback(ctx)
-	ctxWithTx := context.WithValue(ctx, txKey{}, tx)
+	ctxWithTx, committed := WithAfterCommit(context.WithValue(ctx, txKey{}, tx))
 
 	if err := fn(ctxWithTx); err != nil {
 		return err
 	}
 
-	return tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	committed()
+	return nil
+}
+
+// AfterCommit runs fn once the outermost transaction carried by ctx has committed, and never when it rolls back.
+// Without a transaction fn runs right away.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommit); ok {
+		hooks.callbacks = append(hooks.callbacks, fn)
+		return
+	}
+	fn()
+}
+
+// WithAfterCommit is for transactors: it returns ctx ready to collect the AfterCommit callbacks of a new outermost
+// transaction, and the function that runs them once it has committed. Within a transaction that already collects
+// them, ctx is returned as is and the function does nothing.
+func WithAfterCommit(ctx context.Context) (context.Context, func()) {
+	if _, ok := ctx.Value(afterCommitKey{}).(*afterCommit); ok {
+		return ctx, func() {}
+	}
+	hooks := &afterCommit{}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), func() {
+		for _, callback := range hooks.callbacks {
+			callback()
+		}
+	}
 }
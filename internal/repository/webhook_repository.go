@@ -0,0 +1,223 @@
+package repository
+
+//go:generate mockgen -source=webhook_repository.go -destination=mocks/mock_webhook_repository.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscriptionParam domain.CreateWebhookSubscriptionParam) (*domain.WebhookSubscription, error)
+	GetSubscriptionById(ctx context.Context, id int64) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscriptionParam domain.UpdateWebhookSubscriptionParam) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	CreateDeliveries(ctx context.Context, eventId int64, eventType string) (int64, error)
+	ClaimDueDeliveries(ctx context.Context, lease time.Duration, batchSize int32) ([]domain.PendingDelivery, error)
+	MarkDeliverySucceeded(ctx context.Context, deliveryId int64, statusCode int32) error
+	MarkDeliveryFailed(ctx context.Context, failureParam domain.DeliveryFailureParam) error
+	ListDeliveries(ctx context.Context, subscriptionId int64, status string, limit int32) ([]domain.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	querier sqlc.Querier
+}
+
+func NewWebhookRepository(querier sqlc.Querier) WebhookRepository {
+	return &webhookRepository{querier: querier}
+}
+
+func (wr *webhookRepository) CreateSubscription(ctx context.Context, subscriptionParam domain.CreateWebhookSubscriptionParam) (*domain.WebhookSubscription, error) {
+	subscription, err := wr.getQuerier(ctx).CreateWebhookSubscription(ctx, sqlc.CreateWebhookSubscriptionParams{
+		Url:        subscriptionParam.Url,
+		Secret:     subscriptionParam.Secret,
+		EventTypes: subscriptionParam.EventTypes,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while create webhook subscription: %s", err.Error())
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("webhook subscription created successfully in db.")
+	return mapToDomainWebhookSubscription(subscription), nil
+}
+
+func (wr *webhookRepository) GetSubscriptionById(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	subscription, err := wr.getQuerier(ctx).GetWebhookSubscriptionByID(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch webhook subscription by id:%d, error: %s", id, err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return mapToDomainWebhookSubscription(subscription), nil
+}
+
+func (wr *webhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptionList []domain.WebhookSubscription
+	subscriptions, err := wr.getQuerier(ctx).ListWebhookSubscriptions(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch webhook subscriptions: %s", err.Error())
+		return nil, err
+	}
+	for _, subscription := range subscriptions {
+		subscriptionList = append(subscriptionList, *mapToDomainWebhookSubscription(subscription))
+	}
+	return subscriptionList, nil
+}
+
+func (wr *webhookRepository) UpdateSubscription(ctx context.Context, subscriptionParam domain.UpdateWebhookSubscriptionParam) (*domain.WebhookSubscription, error) {
+	subscription, err := wr.getQuerier(ctx).UpdateWebhookSubscription(ctx, sqlc.UpdateWebhookSubscriptionParams{
+		SubscriptionID: subscriptionParam.Id,
+		Url:            subscriptionParam.Url,
+		EventTypes:     subscriptionParam.EventTypes,
+		Active:         subscriptionParam.Active,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while update webhook subscription id:%d, error: %s", subscriptionParam.Id, err.Error())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return mapToDomainWebhookSubscription(subscription), nil
+}
+
+func (wr *webhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	rows, err := wr.getQuerier(ctx).DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while delete webhook subscription id:%d, error: %s", id, err.Error())
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (wr *webhookRepository) CreateDeliveries(ctx context.Context, eventId int64, eventType string) (int64, error) {
+	rows, err := wr.getQuerier(ctx).CreateWebhookDeliveries(ctx, sqlc.CreateWebhookDeliveriesParams{
+		EventID:   eventId,
+		EventType: eventType,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while create webhook deliveries for event id:%d, error: %s", eventId, err.Error())
+		return 0, err
+	}
+	return rows, nil
+}
+
+func (wr *webhookRepository) ClaimDueDeliveries(ctx context.Context, lease time.Duration, batchSize int32) ([]domain.PendingDelivery, error) {
+	var deliveryList []domain.PendingDelivery
+	deliveries, err := wr.getQuerier(ctx).ClaimDueWebhookDeliveries(ctx, sqlc.ClaimDueWebhookDeliveriesParams{
+		Lease:     pgtype.Interval{Microseconds: lease.Microseconds(), Valid: true},
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while claim due webhook deliveries: %s", err.Error())
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		deliveryList = append(deliveryList, domain.PendingDelivery{
+			Id:             delivery.DeliveryID,
+			Attempts:       delivery.Attempts,
+			EventId:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			EventCreatedAt: delivery.CreatedAt.Time,
+			SubscriptionId: delivery.SubscriptionID,
+			Url:            delivery.Url,
+			Secret:         delivery.Secret,
+		})
+	}
+	return deliveryList, nil
+}
+
+func (wr *webhookRepository) MarkDeliverySucceeded(ctx context.Context, deliveryId int64, statusCode int32) error {
+	err := wr.getQuerier(ctx).MarkWebhookDeliverySucceeded(ctx, sqlc.MarkWebhookDeliverySucceededParams{
+		DeliveryID:     deliveryId,
+		LastStatusCode: pgtype.Int4{Int32: statusCode, Valid: true},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while mark webhook delivery %d as delivered: %s", deliveryId, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (wr *webhookRepository) MarkDeliveryFailed(ctx context.Context, failureParam domain.DeliveryFailureParam) error {
+	err := wr.getQuerier(ctx).MarkWebhookDeliveryFailed(ctx, sqlc.MarkWebhookDeliveryFailedParams{
+		DeliveryID:     failureParam.Id,
+		Status:         failureParam.Status,
+		LastStatusCode: pgtype.Int4{Int32: failureParam.LastStatusCode, Valid: failureParam.LastStatusCode != 0},
+		LastError:      pgtype.Text{String: failureParam.LastError, Valid: true},
+		NextAttemptAt:  pgtype.Timestamptz{Time: failureParam.NextAttemptAt, Valid: true},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while mark webhook delivery %d as failed: %s", failureParam.Id, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (wr *webhookRepository) ListDeliveries(ctx context.Context, subscriptionId int64, status string, limit int32) ([]domain.WebhookDelivery, error) {
+	var deliveryList []domain.WebhookDelivery
+	deliveries, err := wr.getQuerier(ctx).ListWebhookDeliveriesBySubscription(ctx, sqlc.ListWebhookDeliveriesBySubscriptionParams{
+		SubscriptionID: subscriptionId,
+		Status:         pgtype.Text{String: status, Valid: status != ""},
+		RowLimit:       limit,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while fetch webhook deliveries for subscription id:%d, error: %s", subscriptionId, err.Error())
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		deliveryList = append(deliveryList, *mapToDomainWebhookDelivery(delivery))
+	}
+	return deliveryList, nil
+}
+
+func mapToDomainWebhookSubscription(subscription sqlc.WebhookSubscription) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		Id:         subscription.SubscriptionID,
+		Url:        subscription.Url,
+		Secret:     subscription.Secret,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.Time,
+		UpdatedAt:  subscription.UpdatedAt.Time,
+	}
+}
+
+func mapToDomainWebhookDelivery(delivery sqlc.WebhookDelivery) *domain.WebhookDelivery {
+	webhookDelivery := &domain.WebhookDelivery{
+		Id:             delivery.DeliveryID,
+		EventId:        delivery.EventID,
+		SubscriptionId: delivery.SubscriptionID,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.Time,
+		LastStatusCode: delivery.LastStatusCode.Int32,
+		LastError:      delivery.LastError.String,
+		CreatedAt:      delivery.CreatedAt.Time,
+	}
+	if delivery.DeliveredAt.Valid {
+		webhookDelivery.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+	return webhookDelivery
+}
+
+func (wr *webhookRepository) getQuerier(ctx context.Context) sqlc.Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return sqlc.New(tx)
+	}
+	return wr.querier
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookRepositoryTestSuite struct {
+	suite.Suite
+	context           context.Context
+	mockController    *gomock.Controller
+	mockQuerier       *mocks.MockQuerier
+	webhookRepository WebhookRepository
+}
+
+func TestWebhookRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookRepositoryTestSuite))
+}
+
+func (suite *WebhookRepositoryTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockQuerier = mocks.NewMockQuerier(suite.mockController)
+	suite.webhookRepository = NewWebhookRepository(suite.mockQuerier)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestWebhookRepository_GetSubscriptionById_NotFound() {
+	suite.mockQuerier.EXPECT().GetWebhookSubscriptionByID(suite.context, int64(404)).Return(sqlc.WebhookSubscription{}, pgx.ErrNoRows)
+
+	res, err := suite.webhookRepository.GetSubscriptionById(suite.context, 404)
+
+	suite.Nil(res)
+	suite.ErrorIs(err, domain.ErrWebhookNotFound)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestWebhookRepository_DeleteSubscription_NotFound() {
+	suite.mockQuerier.EXPECT().DeleteWebhookSubscription(suite.context, int64(404)).Return(int64(0), nil)
+
+	err := suite.webhookRepository.DeleteSubscription(suite.context, 404)
+
+	suite.ErrorIs(err, domain.ErrWebhookNotFound)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestWebhookRepository_ClaimDueDeliveries() {
+	params := sqlc.ClaimDueWebhookDeliveriesParams{
+		Lease:     pgtype.Interval{Microseconds: time.Minute.Microseconds(), Valid: true},
+		BatchSize: 10,
+	}
+	rows := []sqlc.ClaimDueWebhookDeliveriesRow{{
+		DeliveryID:     7,
+		Attempts:       2,
+		EventID:        3,
+		EventType:      domain.EventTransactionCreated,
+		Payload:        []byte(`{"transaction_id":3}`),
+		SubscriptionID: 1,
+		Url:            "https://example.com/hooks",
+		Secret:         "whsec_test",
+	}}
+
+	suite.mockQuerier.EXPECT().ClaimDueWebhookDeliveries(suite.context, params).Return(rows, nil)
+
+	res, err := suite.webhookRepository.ClaimDueDeliveries(suite.context, time.Minute, 10)
+
+	suite.NoError(err)
+	suite.Len(res, 1)
+	suite.Equal(int64(7), res[0].Id)
+	suite.Equal(int32(2), res[0].Attempts)
+	suite.Equal("https://example.com/hooks", res[0].Url)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestWebhookRepository_MarkDeliveryFailed_Without_StatusCode() {
+	nextAttemptAt := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	params := sqlc.MarkWebhookDeliveryFailedParams{
+		DeliveryID:     7,
+		Status:         domain.DeliveryStatusPending,
+		LastStatusCode: pgtype.Int4{},
+		LastError:      pgtype.Text{String: "connection refused", Valid: true},
+		NextAttemptAt:  pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+	}
+
+	suite.mockQuerier.EXPECT().MarkWebhookDeliveryFailed(suite.context, params).Return(nil)
+
+	err := suite.webhookRepository.MarkDeliveryFailed(suite.context, domain.DeliveryFailureParam{
+		Id:            7,
+		Status:        domain.DeliveryStatusPending,
+		LastError:     "connection refused",
+		NextAttemptAt: nextAttemptAt,
+	})
+
+	suite.NoError(err)
+}
+
+func (suite *WebhookRepositoryTestSuite) TestWebhookRepository_ListDeliveries_Returns_Database_Error() {
+	suite.mockQuerier.EXPECT().ListWebhookDeliveriesBySubscription(suite.context, gomock.Any()).Return(nil, errors.New("failed to fetch"))
+
+	res, err := suite.webhookRepository.ListDeliveries(suite.context, 1, "", 100)
+
+	suite.Error(err)
+	suite.Nil(res)
+}
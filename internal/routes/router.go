@@ -1,32 +1,165 @@
 package routes
 
 import (
+	"net/http"
+
+	"github.com/credit-card-api/internal/audit"
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/config"
 	"github.com/credit-card-api/internal/controllers"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/ratelimit"
 	"github.com/credit-card-api/internal/repository"
-	"github.com/credit-card-api/internal/repository/sqlc"
 	"github.com/credit-card-api/internal/services"
+	"github.com/credit-card-api/internal/tracing"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func RegisterRoutes(queries *sqlc.Queries) *gin.Engine {
-	router := gin.Default()
+// Dependencies are the infrastructure the routes are built on.
+type Dependencies struct {
+	// Repositories is the storage backend, Postgres or in memory.
+	Repositories repository.Repositories
+	// TokenAuthenticator validates end-user bearer tokens, nil disables them.
+	TokenAuthenticator auth.Authenticator
+	// Shutdown is closed as soon as a shutdown signal arrives, before the server drains.
+	Shutdown       <-chan struct{}
+	Metrics        metrics.Recorder
+	MetricsHandler http.Handler
+}
+
+// RegisterRoutes wires the API.
+func RegisterRoutes(cfg *config.Config, deps Dependencies) *gin.Engine {
+	repositories, transactor := deps.Repositories, deps.Repositories.Transactor
+	router := gin.New()
+	// Lets services reach values the middlewares attach to the request context through *gin.Context.
+	router.ContextWithFallback = true
+	router.Use(gin.Recovery(), tracing.Middleware(), logging.Middleware(), metrics.Middleware(deps.Metrics))
+
+	outboxRepository := tracing.WrapOutboxRepository(repositories.Outbox)
+
+	auditRepository := tracing.WrapAuditRepository(repositories.Audit)
+	auditService := services.NewAuditService(auditRepository, transactor)
+	auditController := controllers.NewAuditController(auditService)
 
-	accountRepository := repository.NewAccountRepository(queries)
-	accountService := services.NewAccountService(accountRepository)
+	apiKeyRepository := tracing.WrapApiKeyRepository(repositories.ApiKeys)
+	apiKeyService := services.NewApiKeyService(apiKeyRepository, transactor, auditService, cfg.Auth.AdminApiKey)
+	apiKeyController := controllers.NewApiKeyController(apiKeyService)
+
+	accountRepository := tracing.WrapAccountRepository(repositories.Accounts)
+	accountService := tracing.WrapAccountService(services.NewAccountService(accountRepository, outboxRepository, transactor, auditService, deps.Metrics))
 	accountController := controllers.NewAccountController(accountService)
 
-	transactionRepository := repository.NewTransactionRepository(queries)
-	transactionService := services.NewTransactionService(transactionRepository, accountRepository)
-	transactionController := controllers.NewTransactionController(transactionService)
+	transactionRepository := tracing.WrapTransactionRepository(repositories.Transactions)
+	transactionService := tracing.WrapTransactionService(services.NewTransactionService(transactionRepository, accountRepository, outboxRepository, transactor, auditService, deps.Metrics))
+	transactionController := controllers.NewTransactionController(transactionService, cfg.TransactionBatch)
+
+	transactionExportService := services.NewTransactionExportService(accountRepository, transactionRepository, transactor)
+	transactionExportController := controllers.NewTransactionExportController(transactionExportService, cfg.Export)
+
+	statementService := services.NewStatementService(accountRepository, transactionRepository, transactor, cfg.Statements)
+	statementController := controllers.NewStatementController(statementService)
+
+	transactionStreamService := services.NewTransactionStreamService(accountRepository, outboxRepository)
+	transactionStreamController := controllers.NewTransactionStreamController(transactionStreamService, cfg.Stream, deps.Shutdown)
+
+	webhookRepository := tracing.WrapWebhookRepository(repositories.Webhooks)
+	webhookService := services.NewWebhookService(webhookRepository, transactor, auditService)
+	webhookController := controllers.NewWebhookController(webhookService)
+
+	healthRepository := repositories.Health
+	healthService := services.NewHealthService(healthRepository, deps.Shutdown)
+	healthController := controllers.NewHealthController(healthService)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Probes and metrics are neither authenticated nor rate limited.
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+	router.GET("/metrics", gin.WrapH(deps.MetricsHandler))
+
+	rateLimitStore := ratelimit.NewMemoryStore()
+	rateLimits := cfg.RateLimit
+	auditMiddleware := audit.Middleware(auditService, auth.Actor)
 
+	// Rejected and throttled requests stop before the audit middleware and are not recorded.
 	routerGroup := router.Group("/api/credit-card-api/v1")
-	routerGroup.POST("/accounts", accountController.CreateAccount)
-	routerGroup.GET("/accounts/:accountId", accountController.GetAccount)
-	routerGroup.POST("/transactions", transactionController.CreateTransaction)
+	routerGroup.Use(
+		ratelimit.Middleware(rateLimitStore, ratelimit.Rule{Name: "ip", Limit: rateLimits.PerClientIP, Key: ratelimit.ByClientIP}),
+		auth.Middleware(apiKeyService, deps.TokenAuthenticator),
+	)
+
+	accountReadGroup := routerGroup.Group("",
+		ratelimit.Middleware(rateLimitStore, ratelimit.Rule{Name: "account-reads", Limit: rateLimits.AccountReads, Key: ratelimit.ByPrincipal}),
+		auditMiddleware,
+		auth.RequireScope(domain.ScopeAccountsRead),
+	)
+	accountReadGroup.GET("/accounts/:accountId", accountController.GetAccount)
+	accountReadGroup.GET("/accounts/:accountId/transactions/stream", transactionStreamController.StreamTransactions)
+
+	// An export streams a whole history, and a statement reads and renders a cycle of it, so both count against a
+	// bucket of their own rather than the account reads.
+	transactionExportGroup := routerGroup.Group("",
+		ratelimit.Middleware(rateLimitStore, ratelimit.Rule{Name: "transaction-exports", Limit: rateLimits.TransactionExports, Key: ratelimit.ByPrincipal}),
+		auditMiddleware,
+		auth.RequireScope(domain.ScopeAccountsRead),
+	)
+	transactionExportGroup.GET("/accounts/:accountId/transactions/export", transactionExportController.ExportTransactions)
+	transactionExportGroup.GET("/accounts/:accountId/statements/:statementFile", statementController.GetStatementPDF)
+
+	accountWriteGroup := routerGroup.Group("",
+		ratelimit.Middleware(rateLimitStore, ratelimit.Rule{Name: "account-writes", Limit: rateLimits.AccountWrites, Key: ratelimit.ByPrincipal}),
+		auditMiddleware,
+		auth.RequireScope(domain.ScopeAccountsWrite),
+	)
+	accountWriteGroup.POST("/accounts", accountController.CreateAccount)
+	accountWriteGroup.PATCH("/accounts/:accountId", accountController.UpdateAccount)
+
+	transactionGroup := routerGroup.Group("",
+		ratelimit.Middleware(rateLimitStore,
+			ratelimit.Rule{Name: "transactions", Limit: rateLimits.Transactions, Key: ratelimit.ByPrincipal},
+			ratelimit.Rule{Name: "account-transactions", Limit: rateLimits.PerAccountTxns, Key: ratelimit.ByAccount},
+		),
+		auditMiddleware,
+		auth.RequireScope(domain.ScopeTransactionsWrite),
+	)
+	transactionGroup.POST("/transactions", transactionController.CreateTransaction)
+
+	// A batch counts once against its own bucket, per item against the transactions bucket it shares with
+	// POST /transactions, and once against the bucket of each account it targets.
+	transactionBatchGroup := routerGroup.Group("",
+		ratelimit.Middleware(rateLimitStore,
+			ratelimit.Rule{Name: "transaction-batches", Limit: rateLimits.TransactionBatches, Key: ratelimit.ByPrincipal},
+			ratelimit.Rule{Name: "transactions", Limit: rateLimits.Transactions, Cost: ratelimit.PerBatchItem(ratelimit.ByPrincipal)},
+			ratelimit.Rule{Name: "account-transactions", Limit: rateLimits.PerAccountTxns, Cost: ratelimit.ByBatchAccount},
+		),
+		auditMiddleware,
+		auth.RequireScope(domain.ScopeTransactionsWrite),
+	)
+	// The colon is escaped: unescaped, gin reads :batch as a path param and routes /transactionsfoo here.
+	transactionBatchGroup.POST("/transactions\\:batch", transactionController.CreateTransactionBatch)
+
+	adminGroup := routerGroup.Group("",
+		ratelimit.Middleware(rateLimitStore, ratelimit.Rule{Name: "admin", Limit: rateLimits.Admin, Key: ratelimit.ByPrincipal}),
+		auditMiddleware,
+		auth.RequireScope(domain.ScopeAdmin),
+	)
+	adminGroup.POST("/webhooks", webhookController.CreateWebhook)
+	adminGroup.GET("/webhooks", webhookController.ListWebhooks)
+	adminGroup.GET("/webhooks/:webhookId", webhookController.GetWebhook)
+	adminGroup.PATCH("/webhooks/:webhookId", webhookController.UpdateWebhook)
+	adminGroup.DELETE("/webhooks/:webhookId", webhookController.DeleteWebhook)
+	adminGroup.GET("/webhooks/:webhookId/deliveries", webhookController.ListWebhookDeliveries)
+
+	adminGroup.GET("/audit-logs", auditController.ListAuditLogs)
+	adminGroup.GET("/audit-logs/verify", auditController.VerifyAuditLogs)
+
+	adminGroup.POST("/api-keys", apiKeyController.IssueApiKey)
+	adminGroup.GET("/api-keys", apiKeyController.ListApiKeys)
+	adminGroup.POST("/api-keys/:apiKeyId/rotate", apiKeyController.RotateApiKey)
+	adminGroup.DELETE("/api-keys/:apiKeyId", apiKeyController.RevokeApiKey)
 
 	return router
 }
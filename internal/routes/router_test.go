@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/credit-card-api/internal/config"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/repository/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter() *gin.Engine {
+	cfg := config.Default()
+	recorder := metrics.NewPrometheus(prometheus.NewRegistry())
+	return RegisterRoutes(&cfg, Dependencies{
+		Repositories:   memory.NewRepositories(),
+		Shutdown:       make(chan struct{}),
+		Metrics:        recorder,
+		MetricsHandler: recorder.Handler(),
+	})
+}
+
+func TestRegisterRoutes_Transaction_Batch_Path_Is_Literal(t *testing.T) {
+	router := newTestRouter()
+	post := func(path string) int {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/credit-card-api/v1"+path, nil))
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post("/transactions:batch"))
+	assert.Equal(t, http.StatusNotFound, post("/transactionsfoo"))
+	assert.Equal(t, http.StatusNotFound, post("/transactions:other"))
+}
@@ -4,34 +4,227 @@ package services
 
 import (
 	"context"
+	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/metrics"
 	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/redact"
 	"github.com/credit-card-api/internal/repository"
-	logger "github.com/sirupsen/logrus"
 )
 
 type AccountService interface {
 	RegisterAccount(ctx context.Context, request models.CreateAccountRequest) (*domain.Account, error)
 	GetAccount(ctx context.Context, id int64) (*domain.Account, error)
+	// BlockAccount stops the account from taking new transactions. Blocking it again changes nothing.
+	BlockAccount(ctx context.Context, id int64) (*domain.Account, error)
+	// UpdateAccount applies request to the holder profile, provided the account is still at version. A request
+	// that changes nothing leaves the version as it is.
+	UpdateAccount(ctx context.Context, id int64, version int32, request models.UpdateAccountRequest) (*domain.Account, error)
 }
 
 type accountService struct {
 	accountRepository repository.AccountRepository
+	outboxRepository  repository.OutboxRepository
+	transactor        repository.Transactor
+	auditService      AuditService
+	recorder          metrics.Recorder
 }
 
-func NewAccountService(accountRepository repository.AccountRepository) AccountService {
-	return &accountService{accountRepository: accountRepository}
+func NewAccountService(accountRepository repository.AccountRepository, outboxRepository repository.OutboxRepository, transactor repository.Transactor, auditService AuditService, recorder metrics.Recorder) AccountService {
+	return &accountService{accountRepository: accountRepository, outboxRepository: outboxRepository, transactor: transactor, auditService: auditService, recorder: recorder}
 }
 
 func (as *accountService) RegisterAccount(ctx context.Context, request models.CreateAccountRequest) (*domain.Account, error) {
-	logger.Infof("Started to create account with documentNumber: %s", request.DocumentNumber)
+	logging.FromContext(ctx).Infof("Started to create account with documentNumber: %s", redact.Mask(request.DocumentNumber))
 	accountParam := domain.CreateAccountParam{DocumentNumber: request.DocumentNumber}
-	return as.accountRepository.Create(ctx, accountParam)
 
+	var account *domain.Account
+	err := as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var createErr error
+		account, createErr = as.accountRepository.Create(txCtx, accountParam)
+		if createErr != nil {
+			return createErr
+		}
+
+		// Events and audit entries outlive the encrypted column and cannot be re-encrypted, so they only get the
+		// masked document number.
+		payload := domain.AccountCreatedPayload{
+			AccountId:      account.Id,
+			DocumentNumber: redact.Mask(account.DocumentNumber),
+			CreatedAt:      account.CreatedAt,
+		}
+		_, eventErr := as.outboxRepository.Create(txCtx, domain.CreateOutboxEventParam{
+			EventType:     domain.EventAccountCreated,
+			AggregateType: domain.AggregateAccount,
+			AggregateId:   account.Id,
+			AccountId:     account.Id,
+			Payload:       payload,
+		})
+		if eventErr != nil {
+			return eventErr
+		}
+
+		return as.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionAccountCreate,
+			ResourceType: domain.AuditResourceAccount,
+			ResourceId:   strconv.FormatInt(account.Id, 10),
+			After:        payload,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	as.recorder.AccountCreated()
+	return account, nil
 }
 
 func (as *accountService) GetAccount(ctx context.Context, id int64) (*domain.Account, error) {
-	logger.Infof("Started to get account by id: %d", id)
+	logging.FromContext(ctx).Infof("Started to get account by id: %d", id)
 	return as.accountRepository.GetById(ctx, id)
 }
+
+func (as *accountService) BlockAccount(ctx context.Context, id int64) (*domain.Account, error) {
+	logging.FromContext(ctx).Infof("Started to block account by id: %d", id)
+	var account *domain.Account
+	err := as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		current, getErr := as.accountRepository.GetById(txCtx, id)
+		if getErr != nil {
+			return getErr
+		}
+		if current.BlockedAt != nil {
+			account = current
+			return nil
+		}
+
+		var blockErr error
+		account, blockErr = as.accountRepository.Block(txCtx, id)
+		if blockErr != nil {
+			return blockErr
+		}
+
+		payload := domain.AccountBlockedPayload{AccountId: account.Id, BlockedAt: *account.BlockedAt}
+		_, eventErr := as.outboxRepository.Create(txCtx, domain.CreateOutboxEventParam{
+			EventType:     domain.EventAccountBlocked,
+			AggregateType: domain.AggregateAccount,
+			AggregateId:   account.Id,
+			AccountId:     account.Id,
+			Payload:       payload,
+		})
+		if eventErr != nil {
+			return eventErr
+		}
+
+		return as.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionAccountBlock,
+			ResourceType: domain.AuditResourceAccount,
+			ResourceId:   strconv.FormatInt(account.Id, 10),
+			Before:       map[string]any{"blocked_at": nil},
+			After:        payload,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (as *accountService) UpdateAccount(ctx context.Context, id int64, version int32, request models.UpdateAccountRequest) (*domain.Account, error) {
+	logging.FromContext(ctx).Infof("Started to update account by id: %d at version: %d", id, version)
+	var account *domain.Account
+	err := as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		current, getErr := as.accountRepository.GetById(txCtx, id)
+		if getErr != nil {
+			return getErr
+		}
+		if current.Version != version {
+			return domain.ErrAccountVersionMismatch
+		}
+
+		profile, mergeErr := mergeProfile(current.Profile, request)
+		if mergeErr != nil {
+			return mergeErr
+		}
+		if reflect.DeepEqual(profile, current.Profile) {
+			account = current
+			return nil
+		}
+
+		var updateErr error
+		account, updateErr = as.accountRepository.UpdateProfile(txCtx, domain.UpdateAccountProfileParam{Id: id, Version: version, Profile: profile})
+		if updateErr != nil {
+			return updateErr
+		}
+		return as.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionAccountUpdate,
+			ResourceType: domain.AuditResourceAccount,
+			ResourceId:   strconv.FormatInt(id, 10),
+			Before:       map[string]any{"version": current.Version},
+			After:        map[string]any{"version": account.Version, "changed_fields": changedProfileFields(current.Profile, account.Profile)},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// mergeProfile returns profile with the fields present in request: an empty string clears a field and the address
+// is replaced as a whole.
+func mergeProfile(profile domain.AccountProfile, request models.UpdateAccountRequest) (domain.AccountProfile, error) {
+	if request.FullName != nil {
+		profile.FullName = *request.FullName
+	}
+	if request.Email != nil {
+		profile.Email = *request.Email
+	}
+	if request.Phone != nil {
+		profile.Phone = *request.Phone
+	}
+	if address := request.Address; address != nil {
+		profile.Address = &domain.PostalAddress{
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		}
+	}
+	if request.BirthDate != nil {
+		profile.BirthDate = nil
+		if *request.BirthDate != "" {
+			birthDate, err := time.Parse(time.DateOnly, *request.BirthDate)
+			if err != nil {
+				return profile, err
+			}
+			profile.BirthDate = &birthDate
+		}
+	}
+	return profile, nil
+}
+
+// changedProfileFields names the profile fields that differ between before and after. The audit log keeps the names
+// only: it cannot be rewritten, so the personal data itself stays in the encrypted profile.
+func changedProfileFields(before, after domain.AccountProfile) []string {
+	var fields []string
+	if before.FullName != after.FullName {
+		fields = append(fields, "full_name")
+	}
+	if before.Email != after.Email {
+		fields = append(fields, "email")
+	}
+	if before.Phone != after.Phone {
+		fields = append(fields, "phone")
+	}
+	if !reflect.DeepEqual(before.Address, after.Address) {
+		fields = append(fields, "address")
+	}
+	if !reflect.DeepEqual(before.BirthDate, after.BirthDate) {
+		fields = append(fields, "birth_date")
+	}
+	return fields
+}
@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/credit-card-api/internal/domain"
+	metricsMocks "github.com/credit-card-api/internal/metrics/mocks"
 	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/redact/redacttest"
 	"github.com/credit-card-api/internal/repository/mocks"
+	serviceMocks "github.com/credit-card-api/internal/services/mocks"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -22,6 +26,10 @@ type AccountServiceTestSuite struct {
 	context               context.Context
 	mockController        *gomock.Controller
 	mockAccountRepository *mocks.MockAccountRepository
+	mockOutboxRepository  *mocks.MockOutboxRepository
+	mockTransactor        *mocks.MockTransactor
+	mockAuditService      *serviceMocks.MockAuditService
+	mockRecorder          *metricsMocks.MockRecorder
 	accountService        AccountService
 }
 
@@ -33,12 +41,18 @@ func (suite *AccountServiceTestSuite) SetupTest() {
 	suite.context = context.TODO()
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockAccountRepository = mocks.NewMockAccountRepository(suite.mockController)
-	suite.accountService = NewAccountService(suite.mockAccountRepository)
+	suite.mockOutboxRepository = mocks.NewMockOutboxRepository(suite.mockController)
+	suite.mockTransactor = mocks.NewMockTransactor(suite.mockController)
+	suite.mockAuditService = serviceMocks.NewMockAuditService(suite.mockController)
+	suite.mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runWithinTransaction).AnyTimes()
+	suite.mockRecorder = metricsMocks.NewMockRecorder(suite.mockController)
+	suite.accountService = NewAccountService(suite.mockAccountRepository, suite.mockOutboxRepository, suite.mockTransactor, suite.mockAuditService, suite.mockRecorder)
 	accountId = 1
 	documentNumber = "0123456789"
 }
 
 func (suite *AccountServiceTestSuite) TestCreateAccount_Success() {
+	redacttest.Capture(suite.T(), documentNumber)
 	requestPayload := models.CreateAccountRequest{DocumentNumber: documentNumber}
 
 	accountParam := domain.CreateAccountParam{
@@ -51,7 +65,27 @@ func (suite *AccountServiceTestSuite) TestCreateAccount_Success() {
 		CreatedAt:      time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
 	}
 
+	eventParam := domain.CreateOutboxEventParam{
+		EventType:     domain.EventAccountCreated,
+		AggregateType: domain.AggregateAccount,
+		AggregateId:   accountId,
+		AccountId:     accountId,
+		Payload: domain.AccountCreatedPayload{
+			AccountId:      accountId,
+			DocumentNumber: "******6789",
+			CreatedAt:      time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
 	suite.mockAccountRepository.EXPECT().Create(suite.context, accountParam).Return(expectedResponse, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, eventParam).Return(&domain.OutboxEvent{Id: 1}, nil).Times(1)
+	suite.mockAuditService.EXPECT().Record(suite.context, domain.AuditRecord{
+		Action:       domain.AuditActionAccountCreate,
+		ResourceType: domain.AuditResourceAccount,
+		ResourceId:   "1",
+		After:        eventParam.Payload,
+	}).Return(nil).Times(1)
+	suite.mockRecorder.EXPECT().AccountCreated().Times(1)
 
 	response, err := suite.accountService.RegisterAccount(suite.context, requestPayload)
 
@@ -59,6 +93,33 @@ func (suite *AccountServiceTestSuite) TestCreateAccount_Success() {
 	suite.Equal(expectedResponse, response)
 }
 
+func (suite *AccountServiceTestSuite) TestCreateAccount_When_AuditService_Returns_Error() {
+	requestPayload := models.CreateAccountRequest{DocumentNumber: documentNumber}
+	expectedErr := errors.New("failed to append audit entry")
+
+	suite.mockAccountRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Account{Id: accountId}, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{Id: 1}, nil).Times(1)
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).Return(expectedErr).Times(1)
+
+	response, err := suite.accountService.RegisterAccount(suite.context, requestPayload)
+
+	suite.Nil(response)
+	suite.Equal(expectedErr, err)
+}
+
+func (suite *AccountServiceTestSuite) TestCreateAccount_When_OutboxRepo_Returns_Error() {
+	requestPayload := models.CreateAccountRequest{DocumentNumber: documentNumber}
+	expectedErr := errors.New("failed to create outbox event")
+
+	suite.mockAccountRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Account{Id: accountId}, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(nil, expectedErr).Times(1)
+
+	response, err := suite.accountService.RegisterAccount(suite.context, requestPayload)
+
+	suite.Nil(response)
+	suite.Equal(expectedErr, err)
+}
+
 func (suite *AccountServiceTestSuite) TestCreateAccount_When_AccountRepo_Returns_ConflictError() {
 	request := models.CreateAccountRequest{
 		DocumentNumber: documentNumber,
@@ -104,6 +165,112 @@ func (suite *AccountServiceTestSuite) TestGetAccount_When_AccountRepo_Returns_Er
 	suite.Equal(expectedErr, err)
 }
 
+func (suite *AccountServiceTestSuite) TestBlockAccount_Success() {
+	blockedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	blocked := &domain.Account{Id: accountId, DocumentNumber: documentNumber, BlockedAt: &blockedAt}
+	eventParam := domain.CreateOutboxEventParam{
+		EventType:     domain.EventAccountBlocked,
+		AggregateType: domain.AggregateAccount,
+		AggregateId:   accountId,
+		AccountId:     accountId,
+		Payload:       domain.AccountBlockedPayload{AccountId: accountId, BlockedAt: blockedAt},
+	}
+
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, accountId).Return(&domain.Account{Id: accountId, DocumentNumber: documentNumber}, nil)
+	suite.mockAccountRepository.EXPECT().Block(suite.context, accountId).Return(blocked, nil)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, eventParam).Return(&domain.OutboxEvent{Id: 1}, nil)
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).DoAndReturn(func(_ context.Context, record domain.AuditRecord) error {
+		suite.Equal(domain.AuditActionAccountBlock, record.Action)
+		suite.Equal("1", record.ResourceId)
+		return nil
+	})
+
+	response, err := suite.accountService.BlockAccount(suite.context, accountId)
+
+	suite.Nil(err)
+	suite.Equal(blocked, response)
+}
+
+func (suite *AccountServiceTestSuite) TestBlockAccount_When_Already_Blocked_Changes_Nothing() {
+	blockedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	blocked := &domain.Account{Id: accountId, DocumentNumber: documentNumber, BlockedAt: &blockedAt}
+
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, accountId).Return(blocked, nil)
+
+	response, err := suite.accountService.BlockAccount(suite.context, accountId)
+
+	suite.Nil(err)
+	suite.Equal(blocked, response)
+}
+
+func (suite *AccountServiceTestSuite) TestBlockAccount_When_AccountRepo_Returns_NotFound() {
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, accountId).Return(nil, domain.ErrAccountNotFound)
+
+	response, err := suite.accountService.BlockAccount(suite.context, accountId)
+
+	suite.Nil(response)
+	suite.Equal(domain.ErrAccountNotFound, err)
+}
+
+func (suite *AccountServiceTestSuite) TestUpdateAccount_Success() {
+	fullName, email, birthDate := "Maria da Silva", "", "1990-05-17"
+	request := models.UpdateAccountRequest{
+		FullName:  &fullName,
+		Email:     &email,
+		Address:   &models.PostalAddress{Line1: "Av. Paulista, 1000", City: "São Paulo", State: "SP", PostalCode: "01310-100", Country: "BR"},
+		BirthDate: &birthDate,
+	}
+	current := &domain.Account{Id: accountId, DocumentNumber: documentNumber, Profile: domain.AccountProfile{Email: "old@example.com", Phone: "+5511987654321"}, Version: 3}
+	expectedBirthDate := time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC)
+	expectedProfile := domain.AccountProfile{
+		FullName:  fullName,
+		Phone:     "+5511987654321",
+		Address:   &domain.PostalAddress{Line1: "Av. Paulista, 1000", City: "São Paulo", State: "SP", PostalCode: "01310-100", Country: "BR"},
+		BirthDate: &expectedBirthDate,
+	}
+	updated := &domain.Account{Id: accountId, DocumentNumber: documentNumber, Profile: expectedProfile, Version: 4}
+
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, accountId).Return(current, nil)
+	suite.mockAccountRepository.EXPECT().UpdateProfile(suite.context, domain.UpdateAccountProfileParam{Id: accountId, Version: 3, Profile: expectedProfile}).Return(updated, nil)
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).DoAndReturn(func(_ context.Context, record domain.AuditRecord) error {
+		suite.Equal(domain.AuditActionAccountUpdate, record.Action)
+		suite.Equal("1", record.ResourceId)
+		suite.Equal(map[string]any{"version": int32(3)}, record.Before)
+		suite.Equal(map[string]any{"version": int32(4), "changed_fields": []string{"full_name", "email", "address", "birth_date"}}, record.After)
+		return nil
+	})
+
+	response, err := suite.accountService.UpdateAccount(suite.context, accountId, 3, request)
+
+	suite.Nil(err)
+	suite.Equal(updated, response)
+}
+
+func (suite *AccountServiceTestSuite) TestUpdateAccount_When_Version_Is_Stale() {
+	fullName := "Maria da Silva"
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, accountId).Return(&domain.Account{Id: accountId, Version: 4}, nil)
+
+	response, err := suite.accountService.UpdateAccount(suite.context, accountId, 3, models.UpdateAccountRequest{FullName: &fullName})
+
+	suite.Nil(response)
+	suite.Equal(domain.ErrAccountVersionMismatch, err)
+}
+
+func (suite *AccountServiceTestSuite) TestUpdateAccount_Without_Changes_Keeps_Version() {
+	fullName := "Maria da Silva"
+	current := &domain.Account{Id: accountId, Profile: domain.AccountProfile{FullName: fullName}, Version: 3}
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, accountId).Return(current, nil)
+
+	response, err := suite.accountService.UpdateAccount(suite.context, accountId, 3, models.UpdateAccountRequest{FullName: &fullName})
+
+	suite.Nil(err)
+	suite.Equal(current, response)
+}
+
 func (suite *AccountServiceTestSuite) TearDownTest() {
 	suite.mockController.Finish()
 }
+
+func runWithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
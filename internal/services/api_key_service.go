@@ -0,0 +1,191 @@
+package services
+
+//go:generate mockgen -source=api_key_service.go -destination=mocks/mock_api_key_service.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strconv"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository"
+)
+
+const (
+	apiKeyPrefix       = "cck_"
+	apiKeySecretBytes  = 32
+	apiKeyDisplayChars = 12
+
+	// bootstrapAdminSubject is the audit actor of the ADMIN_API_KEY, used to issue the first stored keys.
+	bootstrapAdminSubject = "bootstrap-admin"
+)
+
+type ApiKeyService interface {
+	IssueKey(ctx context.Context, request models.CreateApiKeyRequest) (*domain.IssuedApiKey, error)
+	ListKeys(ctx context.Context) ([]domain.ApiKey, error)
+	// RotateKey revokes the key and issues a new one with the same name and scopes.
+	RotateKey(ctx context.Context, id int64) (*domain.IssuedApiKey, error)
+	RevokeKey(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, key string) (*domain.Principal, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepository repository.ApiKeyRepository
+	transactor       repository.Transactor
+	auditService     AuditService
+	bootstrapKeyHash string
+}
+
+// NewApiKeyService accepts an optional bootstrap admin key that is never stored, an empty key disables it.
+func NewApiKeyService(apiKeyRepository repository.ApiKeyRepository, transactor repository.Transactor, auditService AuditService, bootstrapKey string) ApiKeyService {
+	service := &apiKeyService{apiKeyRepository: apiKeyRepository, transactor: transactor, auditService: auditService}
+	if bootstrapKey != "" {
+		service.bootstrapKeyHash = hashApiKey(bootstrapKey)
+	}
+	return service
+}
+
+func (as *apiKeyService) IssueKey(ctx context.Context, request models.CreateApiKeyRequest) (*domain.IssuedApiKey, error) {
+	logging.FromContext(ctx).Infof("Started to issue api key with name: %s", request.Name)
+	var issued *domain.IssuedApiKey
+	err := as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var issueErr error
+		issued, issueErr = as.issue(txCtx, request.Name, request.Scopes, nil)
+		if issueErr != nil {
+			return issueErr
+		}
+		return as.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionApiKeyIssue,
+			ResourceType: domain.AuditResourceApiKey,
+			ResourceId:   strconv.FormatInt(issued.Id, 10),
+			After:        apiKeySnapshot(issued.ApiKey),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+func (as *apiKeyService) ListKeys(ctx context.Context) ([]domain.ApiKey, error) {
+	logging.FromContext(ctx).Info("Started to list api keys")
+	return as.apiKeyRepository.List(ctx)
+}
+
+func (as *apiKeyService) RotateKey(ctx context.Context, id int64) (*domain.IssuedApiKey, error) {
+	logging.FromContext(ctx).Infof("Started to rotate api key by id: %d", id)
+	var issued *domain.IssuedApiKey
+	err := as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		revoked, revokeErr := as.apiKeyRepository.Revoke(txCtx, id)
+		if revokeErr != nil {
+			return revokeErr
+		}
+
+		var issueErr error
+		issued, issueErr = as.issue(txCtx, revoked.Name, revoked.Scopes, &revoked.Id)
+		if issueErr != nil {
+			return issueErr
+		}
+		return as.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionApiKeyRotate,
+			ResourceType: domain.AuditResourceApiKey,
+			ResourceId:   strconv.FormatInt(issued.Id, 10),
+			Before:       apiKeySnapshot(*revoked),
+			After:        apiKeySnapshot(issued.ApiKey),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+func (as *apiKeyService) RevokeKey(ctx context.Context, id int64) error {
+	logging.FromContext(ctx).Infof("Started to revoke api key by id: %d", id)
+	return as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		revoked, revokeErr := as.apiKeyRepository.Revoke(txCtx, id)
+		if revokeErr != nil {
+			return revokeErr
+		}
+		before := *revoked
+		before.RevokedAt = nil
+		return as.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionApiKeyRevoke,
+			ResourceType: domain.AuditResourceApiKey,
+			ResourceId:   strconv.FormatInt(id, 10),
+			Before:       apiKeySnapshot(before),
+			After:        apiKeySnapshot(*revoked),
+		})
+	})
+}
+
+func (as *apiKeyService) Authenticate(ctx context.Context, key string) (*domain.Principal, error) {
+	if key == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	hash := hashApiKey(key)
+	if as.bootstrapKeyHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(as.bootstrapKeyHash)) == 1 {
+		return &domain.Principal{Subject: bootstrapAdminSubject, Scopes: []string{domain.ScopeAdmin}}, nil
+	}
+
+	apiKey, err := as.apiKeyRepository.GetActiveByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, domain.ErrApiKeyNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	return domain.NewApiKeyPrincipal(*apiKey), nil
+}
+
+func (as *apiKeyService) issue(ctx context.Context, name string, scopes []string, rotatedFrom *int64) (*domain.IssuedApiKey, error) {
+	key, err := generateApiKey()
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while generate api key: %s", err.Error())
+		return nil, domain.ErrInternal
+	}
+
+	apiKey, err := as.apiKeyRepository.Create(ctx, domain.CreateApiKeyParam{
+		Name:        name,
+		Prefix:      key[:apiKeyDisplayChars],
+		Hash:        hashApiKey(key),
+		Scopes:      scopes,
+		RotatedFrom: rotatedFrom,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.IssuedApiKey{ApiKey: *apiKey, Key: key}, nil
+}
+
+// apiKeySnapshot is the audited state of an api key, only its display prefix is kept.
+func apiKeySnapshot(apiKey domain.ApiKey) map[string]any {
+	return map[string]any{
+		"key_id":       apiKey.Id,
+		"name":         apiKey.Name,
+		"prefix":       apiKey.Prefix,
+		"scopes":       apiKey.Scopes,
+		"rotated_from": apiKey.RotatedFrom,
+		"revoked_at":   apiKey.RevokedAt,
+	}
+}
+
+func generateApiKey() (string, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// hashApiKey is a plain SHA-256: keys carry 256 bits of entropy, so a slow password hash buys nothing.
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository/mocks"
+	serviceMocks "github.com/credit-card-api/internal/services/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const testBootstrapKey = "bootstrap-secret"
+
+type ApiKeyServiceTestSuite struct {
+	suite.Suite
+	context              context.Context
+	mockController       *gomock.Controller
+	mockApiKeyRepository *mocks.MockApiKeyRepository
+	mockTransactor       *mocks.MockTransactor
+	mockAuditService     *serviceMocks.MockAuditService
+	apiKeyService        ApiKeyService
+}
+
+func TestApiKeyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeyServiceTestSuite))
+}
+
+func (suite *ApiKeyServiceTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockApiKeyRepository = mocks.NewMockApiKeyRepository(suite.mockController)
+	suite.mockTransactor = mocks.NewMockTransactor(suite.mockController)
+	suite.mockAuditService = serviceMocks.NewMockAuditService(suite.mockController)
+	suite.mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runWithinTransaction).AnyTimes()
+	suite.apiKeyService = NewApiKeyService(suite.mockApiKeyRepository, suite.mockTransactor, suite.mockAuditService, testBootstrapKey)
+}
+
+func (suite *ApiKeyServiceTestSuite) TestIssueKey_Stores_Hash_Only() {
+	request := models.CreateApiKeyRequest{Name: "billing-service", Scopes: []string{domain.ScopeAccountsRead}}
+	var storedHash string
+
+	suite.mockApiKeyRepository.EXPECT().Create(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, param domain.CreateApiKeyParam) (*domain.ApiKey, error) {
+			storedHash = param.Hash
+			suite.Equal(request.Name, param.Name)
+			suite.Equal(request.Scopes, param.Scopes)
+			suite.Len(param.Hash, 64)
+			suite.Len(param.Prefix, apiKeyDisplayChars)
+			suite.Nil(param.RotatedFrom)
+			return &domain.ApiKey{Id: 1, Name: param.Name, Prefix: param.Prefix, Scopes: param.Scopes}, nil
+		})
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, record domain.AuditRecord) error {
+			suite.Equal(domain.AuditActionApiKeyIssue, record.Action)
+			suite.NotContains(record.After, "hash")
+			return nil
+		})
+
+	issued, err := suite.apiKeyService.IssueKey(suite.context, request)
+
+	suite.Nil(err)
+	suite.True(strings.HasPrefix(issued.Key, apiKeyPrefix))
+	suite.True(strings.HasPrefix(issued.Key, issued.Prefix))
+	suite.Equal(hashApiKey(issued.Key), storedHash)
+	suite.NotEqual(issued.Key, storedHash)
+}
+
+func (suite *ApiKeyServiceTestSuite) TestRotateKey_Revokes_And_Issues_With_Same_Scopes() {
+	revoked := &domain.ApiKey{Id: 1, Name: "billing-service", Scopes: []string{domain.ScopeTransactionsWrite}}
+
+	gomock.InOrder(
+		suite.mockApiKeyRepository.EXPECT().Revoke(suite.context, int64(1)).Return(revoked, nil),
+		suite.mockApiKeyRepository.EXPECT().Create(suite.context, gomock.Any()).
+			DoAndReturn(func(_ context.Context, param domain.CreateApiKeyParam) (*domain.ApiKey, error) {
+				suite.Equal(revoked.Name, param.Name)
+				suite.Equal(revoked.Scopes, param.Scopes)
+				suite.Equal(int64(1), *param.RotatedFrom)
+				return &domain.ApiKey{Id: 2, Name: param.Name, Scopes: param.Scopes, RotatedFrom: param.RotatedFrom}, nil
+			}),
+	)
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).Return(nil)
+
+	issued, err := suite.apiKeyService.RotateKey(suite.context, 1)
+
+	suite.Nil(err)
+	suite.Equal(int64(2), issued.Id)
+	suite.NotEmpty(issued.Key)
+}
+
+func (suite *ApiKeyServiceTestSuite) TestRevokeKey_When_NotFound_Returns_Error() {
+	suite.mockApiKeyRepository.EXPECT().Revoke(suite.context, int64(404)).Return(nil, domain.ErrApiKeyNotFound)
+
+	err := suite.apiKeyService.RevokeKey(suite.context, 404)
+
+	suite.Equal(domain.ErrApiKeyNotFound, err)
+}
+
+func (suite *ApiKeyServiceTestSuite) TestAuthenticate_Stored_Key() {
+	key := "cck_0123456789abcdef"
+	suite.mockApiKeyRepository.EXPECT().GetActiveByHash(suite.context, hashApiKey(key)).
+		Return(&domain.ApiKey{Id: 3, Scopes: []string{domain.ScopeAccountsRead}}, nil)
+
+	principal, err := suite.apiKeyService.Authenticate(suite.context, key)
+
+	suite.Nil(err)
+	suite.Equal("api-key:3", principal.Subject)
+	suite.True(principal.HasScope(domain.ScopeAccountsRead))
+	suite.False(principal.HasScope(domain.ScopeAccountsWrite))
+}
+
+func (suite *ApiKeyServiceTestSuite) TestAuthenticate_Bootstrap_Key_Is_Admin() {
+	principal, err := suite.apiKeyService.Authenticate(suite.context, testBootstrapKey)
+
+	suite.Nil(err)
+	suite.Equal(bootstrapAdminSubject, principal.Subject)
+	suite.True(principal.HasScope(domain.ScopeTransactionsWrite))
+}
+
+func (suite *ApiKeyServiceTestSuite) TestAuthenticate_Unknown_Or_Missing_Key() {
+	suite.mockApiKeyRepository.EXPECT().GetActiveByHash(suite.context, gomock.Any()).Return(nil, domain.ErrApiKeyNotFound)
+
+	_, unknownErr := suite.apiKeyService.Authenticate(suite.context, "cck_revoked")
+	_, missingErr := suite.apiKeyService.Authenticate(suite.context, "")
+
+	suite.Equal(domain.ErrUnauthorized, unknownErr)
+	suite.Equal(domain.ErrUnauthorized, missingErr)
+}
+
+func (suite *ApiKeyServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,131 @@
+package services
+
+//go:generate mockgen -source=audit_service.go -destination=mocks/mock_audit_service.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/credit-card-api/internal/audit"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository"
+)
+
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 500
+)
+
+type AuditService interface {
+	// Record appends an entry to the audit chain. Called with a transactional context, the entry
+	// commits or rolls back together with the change it describes.
+	Record(ctx context.Context, record domain.AuditRecord) error
+	ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+	VerifyChain(ctx context.Context) (*domain.AuditVerification, error)
+}
+
+type auditService struct {
+	auditRepository repository.AuditRepository
+	transactor      repository.Transactor
+	now             func() time.Time
+}
+
+func NewAuditService(auditRepository repository.AuditRepository, transactor repository.Transactor) AuditService {
+	return &auditService{auditRepository: auditRepository, transactor: transactor, now: time.Now}
+}
+
+func (as *auditService) Record(ctx context.Context, record domain.AuditRecord) error {
+	metadata := audit.FromContext(ctx)
+
+	before, err := audit.Snapshot(record.Before)
+	if err != nil {
+		return err
+	}
+	after, err := audit.Snapshot(record.After)
+	if err != nil {
+		return err
+	}
+	diff, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	entry := domain.AuditEntry{
+		Actor:        metadata.Actor,
+		Action:       record.Action,
+		ResourceType: record.ResourceType,
+		ResourceId:   record.ResourceId,
+		Before:       before,
+		After:        after,
+		Diff:         diff,
+		RequestId:    metadata.RequestId,
+		SourceIp:     metadata.SourceIp,
+		// Postgres keeps microseconds, the hash has to be computed on the value that is stored.
+		CreatedAt: as.now().UTC().Truncate(time.Microsecond),
+	}
+
+	err = as.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		prevHash, lockErr := as.auditRepository.LockChainHead(txCtx)
+		if lockErr != nil {
+			return lockErr
+		}
+		if prevHash == "" {
+			prevHash = audit.GenesisHash
+		}
+		entry.PrevHash = prevHash
+		entry.Hash = audit.ComputeHash(entry)
+
+		_, createErr := as.auditRepository.Create(txCtx, entry)
+		return createErr
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while record audit entry %s on %s %s: %s", record.Action, record.ResourceType, record.ResourceId, err.Error())
+		return err
+	}
+	// An outer transaction can still roll the entry back, the request only counts as recorded once it commits.
+	repository.AfterCommit(ctx, metadata.MarkRecorded)
+	return nil
+}
+
+func (as *auditService) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	logging.FromContext(ctx).Info("Started to list audit entries")
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+	return as.auditRepository.List(ctx, filter)
+}
+
+// VerifyChain walks the whole log in insertion order and recomputes every hash. Any edited,
+// removed or re-ordered entry breaks the chain from that point on.
+func (as *auditService) VerifyChain(ctx context.Context) (*domain.AuditVerification, error) {
+	logging.FromContext(ctx).Info("Started to verify audit chain")
+	verification := &domain.AuditVerification{Valid: true, LastHash: audit.GenesisHash}
+
+	var afterId int64
+	for {
+		entries, err := as.auditRepository.List(ctx, domain.AuditFilter{AfterId: afterId, Limit: maxAuditPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			verification.EntriesChecked++
+			if entry.PrevHash != verification.LastHash || audit.ComputeHash(entry) != entry.Hash {
+				logging.FromContext(ctx).Errorf("audit chain broken at entry id: %d", entry.Id)
+				verification.Valid = false
+				verification.FirstInvalidId = entry.Id
+				return verification, nil
+			}
+			verification.LastHash = entry.Hash
+			afterId = entry.Id
+		}
+
+		if len(entries) < maxAuditPageSize {
+			return verification, nil
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/audit"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AuditServiceTestSuite struct {
+	suite.Suite
+	context             context.Context
+	mockController      *gomock.Controller
+	mockAuditRepository *mocks.MockAuditRepository
+	mockTransactor      *mocks.MockTransactor
+	auditService        *auditService
+}
+
+func TestAuditServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
+
+func (suite *AuditServiceTestSuite) SetupTest() {
+	suite.context = audit.WithMetadata(context.TODO(), &audit.Metadata{Actor: audit.AnonymousActor, RequestId: "req-1", SourceIp: "10.0.0.1"})
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockAuditRepository = mocks.NewMockAuditRepository(suite.mockController)
+	suite.mockTransactor = mocks.NewMockTransactor(suite.mockController)
+	suite.mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runWithinTransaction).AnyTimes()
+	suite.auditService = NewAuditService(suite.mockAuditRepository, suite.mockTransactor).(*auditService)
+	suite.auditService.now = func() time.Time { return time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC) }
+}
+
+func (suite *AuditServiceTestSuite) TestRecord_Chains_First_Entry_To_Genesis() {
+	suite.mockAuditRepository.EXPECT().LockChainHead(suite.context).Return("", nil)
+	suite.mockAuditRepository.EXPECT().Create(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry domain.AuditEntry) (*domain.AuditEntry, error) {
+			suite.Equal(audit.GenesisHash, entry.PrevHash)
+			suite.Equal(audit.ComputeHash(entry), entry.Hash)
+			suite.Equal(audit.AnonymousActor, entry.Actor)
+			suite.Equal("req-1", entry.RequestId)
+			suite.Equal("10.0.0.1", entry.SourceIp)
+			suite.JSONEq(`{"active":{"from":true,"to":false}}`, string(entry.Diff))
+			return &entry, nil
+		})
+
+	err := suite.auditService.Record(suite.context, domain.AuditRecord{
+		Action:       domain.AuditActionWebhookUpdate,
+		ResourceType: domain.AuditResourceWebhookSubscription,
+		ResourceId:   "1",
+		Before:       map[string]any{"active": true},
+		After:        map[string]any{"active": false},
+	})
+
+	suite.NoError(err)
+	suite.True(audit.FromContext(suite.context).Recorded())
+}
+
+func (suite *AuditServiceTestSuite) TestRecord_Chains_To_Latest_Hash() {
+	latestHash := "ab" + audit.GenesisHash[2:]
+	suite.mockAuditRepository.EXPECT().LockChainHead(suite.context).Return(latestHash, nil)
+	suite.mockAuditRepository.EXPECT().Create(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry domain.AuditEntry) (*domain.AuditEntry, error) {
+			suite.Equal(latestHash, entry.PrevHash)
+			return &entry, nil
+		})
+
+	err := suite.auditService.Record(suite.context, domain.AuditRecord{Action: domain.AuditActionAccountCreate})
+
+	suite.NoError(err)
+}
+
+func (suite *AuditServiceTestSuite) TestListEntries_Caps_Page_Size() {
+	suite.mockAuditRepository.EXPECT().List(suite.context, domain.AuditFilter{Actor: "anonymous", Limit: maxAuditPageSize}).Return(nil, nil)
+
+	_, err := suite.auditService.ListEntries(suite.context, domain.AuditFilter{Actor: "anonymous", Limit: 10000})
+
+	suite.NoError(err)
+}
+
+func (suite *AuditServiceTestSuite) TestVerifyChain_Valid() {
+	entries := suite.buildChain(3)
+	suite.mockAuditRepository.EXPECT().List(suite.context, domain.AuditFilter{Limit: maxAuditPageSize}).Return(entries, nil)
+
+	verification, err := suite.auditService.VerifyChain(suite.context)
+
+	suite.NoError(err)
+	suite.True(verification.Valid)
+	suite.Equal(int64(3), verification.EntriesChecked)
+	suite.Equal(entries[2].Hash, verification.LastHash)
+}
+
+func (suite *AuditServiceTestSuite) TestVerifyChain_Detects_Tampered_Entry() {
+	entries := suite.buildChain(3)
+	entries[1].After = json.RawMessage(`{"balance":1000000}`)
+	suite.mockAuditRepository.EXPECT().List(suite.context, domain.AuditFilter{Limit: maxAuditPageSize}).Return(entries, nil)
+
+	verification, err := suite.auditService.VerifyChain(suite.context)
+
+	suite.NoError(err)
+	suite.False(verification.Valid)
+	suite.Equal(int64(2), verification.FirstInvalidId)
+}
+
+func (suite *AuditServiceTestSuite) TestVerifyChain_Detects_Removed_Entry() {
+	entries := suite.buildChain(3)
+	entries = append(entries[:1], entries[2])
+	suite.mockAuditRepository.EXPECT().List(suite.context, domain.AuditFilter{Limit: maxAuditPageSize}).Return(entries, nil)
+
+	verification, err := suite.auditService.VerifyChain(suite.context)
+
+	suite.NoError(err)
+	suite.False(verification.Valid)
+	suite.Equal(int64(3), verification.FirstInvalidId)
+}
+
+func (suite *AuditServiceTestSuite) buildChain(size int) []domain.AuditEntry {
+	entries := make([]domain.AuditEntry, 0, size)
+	prevHash := audit.GenesisHash
+	for i := 1; i <= size; i++ {
+		entry := domain.AuditEntry{
+			Id:           int64(i),
+			Actor:        audit.AnonymousActor,
+			Action:       domain.AuditActionTransactionBalanceUpdate,
+			ResourceType: domain.AuditResourceTransaction,
+			ResourceId:   "1",
+			After:        json.RawMessage(`{"balance":-50}`),
+			CreatedAt:    time.Date(2026, time.January, 1, 10, 0, i, 0, time.UTC),
+			PrevHash:     prevHash,
+		}
+		entry.Hash = audit.ComputeHash(entry)
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (suite *AuditServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,121 @@
+package services
+
+//go:generate mockgen -source=health_service.go -destination=mocks/mock_health_service.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository"
+)
+
+// readinessTimeout bounds every readiness check, so a stuck database fails the probe instead of hanging it.
+const readinessTimeout = 2 * time.Second
+
+type HealthService interface {
+	// Liveness reports whether the process is able to serve requests at all; it does not touch dependencies.
+	Liveness(ctx context.Context) domain.HealthReport
+	// Readiness reports whether the instance should receive traffic.
+	Readiness(ctx context.Context) domain.HealthReport
+}
+
+type healthService struct {
+	healthRepository repository.HealthRepository
+	shutdown         <-chan struct{}
+	now              func() time.Time
+}
+
+// NewHealthService reports not ready once shutdown is closed.
+func NewHealthService(healthRepository repository.HealthRepository, shutdown <-chan struct{}) HealthService {
+	return &healthService{healthRepository: healthRepository, shutdown: shutdown, now: time.Now}
+}
+
+func (hs *healthService) Liveness(ctx context.Context) domain.HealthReport {
+	return domain.HealthReport{Status: domain.HealthStatusUp}
+}
+
+func (hs *healthService) Readiness(ctx context.Context) domain.HealthReport {
+	checks := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{name: domain.HealthCheckShutdown, run: hs.checkShutdown},
+		{name: domain.HealthCheckDatabase, run: hs.healthRepository.Ping},
+		{name: domain.HealthCheckSchemaVersion, run: hs.checkSchemaVersion},
+		{name: domain.HealthCheckOperationTypes, run: hs.checkOperationTypes},
+	}
+
+	report := domain.HealthReport{Status: domain.HealthStatusUp, Checks: make([]domain.HealthCheck, 0, len(checks))}
+	for _, check := range checks {
+		result := hs.run(ctx, check.name, check.run)
+		if result.Status != domain.HealthStatusUp {
+			report.Status = domain.HealthStatusDown
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+func (hs *healthService) run(ctx context.Context, name string, check func(ctx context.Context) error) domain.HealthCheck {
+	checkCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	startedAt := hs.now()
+	err := check(checkCtx)
+	result := domain.HealthCheck{Name: name, Status: domain.HealthStatusUp, Latency: hs.now().Sub(startedAt)}
+	if err != nil {
+		logging.FromContext(ctx).Warnf("readiness check %s failed: %s", name, err.Error())
+		result.Status = domain.HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (hs *healthService) checkShutdown(context.Context) error {
+	select {
+	case <-hs.shutdown:
+		return errors.New("server is shutting down")
+	default:
+		return nil
+	}
+}
+
+func (hs *healthService) checkSchemaVersion(ctx context.Context) error {
+	version, err := hs.healthRepository.GetSchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		return fmt.Errorf("no migration applied, expected version %d", domain.ExpectedSchemaVersion)
+	}
+	if version.Dirty {
+		return fmt.Errorf("migration version %d is dirty", version.Version)
+	}
+	// A newer version is expected while a rolling deploy runs this build next to the one that migrated.
+	if version.Version < domain.ExpectedSchemaVersion {
+		return fmt.Errorf("migration version %d applied, expected version %d or later", version.Version, domain.ExpectedSchemaVersion)
+	}
+	return nil
+}
+
+func (hs *healthService) checkOperationTypes(ctx context.Context) error {
+	ids := make([]int64, 0, len(domain.ValidOperations))
+	for id := range domain.ValidOperations {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	count, err := hs.healthRepository.CountOperationTypes(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if count != int64(len(ids)) {
+		return fmt.Errorf("%d of %d operation types are seeded", count, len(ids))
+	}
+	return nil
+}
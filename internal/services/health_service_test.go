@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type HealthServiceTestSuite struct {
+	suite.Suite
+	context              context.Context
+	mockController       *gomock.Controller
+	mockHealthRepository *mocks.MockHealthRepository
+	shutdown             chan struct{}
+	healthService        HealthService
+}
+
+func TestHealthServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthServiceTestSuite))
+}
+
+func (suite *HealthServiceTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockHealthRepository = mocks.NewMockHealthRepository(suite.mockController)
+	suite.shutdown = make(chan struct{})
+	suite.healthService = NewHealthService(suite.mockHealthRepository, suite.shutdown)
+
+	// Every call of now advances the clock by a millisecond, so each check takes exactly 1ms.
+	clock := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+	suite.healthService.(*healthService).now = func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+}
+
+func (suite *HealthServiceTestSuite) expectHealthyDatabase() {
+	suite.mockHealthRepository.EXPECT().Ping(gomock.Any()).Return(nil)
+	suite.mockHealthRepository.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: domain.ExpectedSchemaVersion}, nil)
+	suite.mockHealthRepository.EXPECT().CountOperationTypes(gomock.Any(), []int64{1, 2, 3, 4}).Return(int64(4), nil)
+}
+
+func (suite *HealthServiceTestSuite) TestReadiness_When_All_Checks_Pass() {
+	suite.expectHealthyDatabase()
+
+	report := suite.healthService.Readiness(suite.context)
+
+	suite.Equal(domain.HealthStatusUp, report.Status)
+	suite.Equal([]domain.HealthCheck{
+		{Name: domain.HealthCheckShutdown, Status: domain.HealthStatusUp, Latency: time.Millisecond},
+		{Name: domain.HealthCheckDatabase, Status: domain.HealthStatusUp, Latency: time.Millisecond},
+		{Name: domain.HealthCheckSchemaVersion, Status: domain.HealthStatusUp, Latency: time.Millisecond},
+		{Name: domain.HealthCheckOperationTypes, Status: domain.HealthStatusUp, Latency: time.Millisecond},
+	}, report.Checks)
+}
+
+func (suite *HealthServiceTestSuite) TestReadiness_Fails_During_Shutdown() {
+	suite.expectHealthyDatabase()
+	close(suite.shutdown)
+
+	report := suite.healthService.Readiness(suite.context)
+
+	suite.Equal(domain.HealthStatusDown, report.Status)
+	suite.Equal(domain.HealthStatusDown, report.Checks[0].Status)
+	suite.Equal("server is shutting down", report.Checks[0].Error)
+}
+
+func (suite *HealthServiceTestSuite) TestReadiness_Reports_Each_Failing_Check() {
+	suite.mockHealthRepository.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+	suite.mockHealthRepository.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: domain.ExpectedSchemaVersion - 1, Dirty: false}, nil)
+	suite.mockHealthRepository.EXPECT().CountOperationTypes(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+
+	report := suite.healthService.Readiness(suite.context)
+
+	suite.Equal(domain.HealthStatusDown, report.Status)
+	suite.Equal(domain.HealthStatusUp, report.Checks[0].Status)
+	suite.Equal("connection refused", report.Checks[1].Error)
+	suite.Equal(fmt.Sprintf("migration version %d applied, expected version %d or later", domain.ExpectedSchemaVersion-1, domain.ExpectedSchemaVersion), report.Checks[2].Error)
+	suite.Equal("3 of 4 operation types are seeded", report.Checks[3].Error)
+}
+
+func (suite *HealthServiceTestSuite) TestReadiness_When_Migration_Missing_Or_Dirty() {
+	suite.mockHealthRepository.EXPECT().Ping(gomock.Any()).Return(nil).Times(2)
+	suite.mockHealthRepository.EXPECT().CountOperationTypes(gomock.Any(), gomock.Any()).Return(int64(4), nil).Times(2)
+	gomock.InOrder(
+		suite.mockHealthRepository.EXPECT().GetSchemaVersion(gomock.Any()).Return(nil, nil),
+		suite.mockHealthRepository.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: domain.ExpectedSchemaVersion, Dirty: true}, nil),
+	)
+
+	missing := suite.healthService.Readiness(suite.context)
+	dirty := suite.healthService.Readiness(suite.context)
+
+	suite.Equal(fmt.Sprintf("no migration applied, expected version %d", domain.ExpectedSchemaVersion), missing.Checks[2].Error)
+	suite.Equal(fmt.Sprintf("migration version %d is dirty", domain.ExpectedSchemaVersion), dirty.Checks[2].Error)
+}
+
+func (suite *HealthServiceTestSuite) TestReadiness_Accepts_Newer_Schema_Version() {
+	suite.mockHealthRepository.EXPECT().Ping(gomock.Any()).Return(nil)
+	suite.mockHealthRepository.EXPECT().GetSchemaVersion(gomock.Any()).Return(&domain.SchemaVersion{Version: domain.ExpectedSchemaVersion + 1}, nil)
+	suite.mockHealthRepository.EXPECT().CountOperationTypes(gomock.Any(), gomock.Any()).Return(int64(4), nil)
+
+	report := suite.healthService.Readiness(suite.context)
+
+	suite.Equal(domain.HealthStatusUp, report.Status)
+	suite.Equal(domain.HealthStatusUp, report.Checks[2].Status)
+}
+
+func (suite *HealthServiceTestSuite) TestLiveness_Does_Not_Touch_Dependencies() {
+	report := suite.healthService.Liveness(suite.context)
+
+	suite.Equal(domain.HealthReport{Status: domain.HealthStatusUp}, report)
+}
+
+func (suite *HealthServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/credit-card-api/internal/audit"
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/repository/memory"
+	"github.com/stretchr/testify/suite"
+)
+
+// MemoryStorageTestSuite runs the services over the memory repositories, for the behaviour that spans
+// several repositories and is awkward to script with mocks.
+type MemoryStorageTestSuite struct {
+	suite.Suite
+	context            context.Context
+	transactor         repository.Transactor
+	auditService       AuditService
+	accountService     AccountService
+	transactionService TransactionService
+	exportService      TransactionExportService
+}
+
+func TestMemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryStorageTestSuite))
+}
+
+func (suite *MemoryStorageTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	repositories := memory.NewRepositories()
+	suite.transactor = repositories.Transactor
+	suite.auditService = NewAuditService(repositories.Audit, repositories.Transactor)
+	suite.accountService = NewAccountService(repositories.Accounts, repositories.Outbox, repositories.Transactor, suite.auditService, metrics.Noop{})
+	suite.transactionService = NewTransactionService(repositories.Transactions, repositories.Accounts, repositories.Outbox, repositories.Transactor, suite.auditService, metrics.Noop{})
+	suite.exportService = NewTransactionExportService(repositories.Accounts, repositories.Transactions, repositories.Transactor)
+}
+
+func (suite *MemoryStorageTestSuite) TestRegisterAccount_Duplicate_Document_Number() {
+	_, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+	suite.Require().NoError(err)
+
+	account, duplicateErr := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+
+	suite.Nil(account)
+	suite.ErrorIs(duplicateErr, domain.ErrAccountAlreadyExist)
+	entries, listErr := suite.auditService.ListEntries(suite.context, domain.AuditFilter{Limit: 10})
+	suite.NoError(listErr)
+	suite.Len(entries, 1)
+}
+
+func (suite *MemoryStorageTestSuite) TestRecord_Marks_Request_Recorded_Only_Once_Committed() {
+	metadata := &audit.Metadata{Actor: "api-key:1"}
+	ctx := audit.WithMetadata(suite.context, metadata)
+	record := domain.AuditRecord{Action: domain.AuditActionAccountBlock, ResourceType: domain.AuditResourceAccount, ResourceId: "1"}
+
+	rollbackErr := suite.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		suite.Require().NoError(suite.auditService.Record(txCtx, record))
+		return errors.New("rollback")
+	})
+	suite.Error(rollbackErr)
+	suite.False(metadata.Recorded())
+
+	err := suite.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		suite.Require().NoError(suite.auditService.Record(txCtx, record))
+		suite.False(metadata.Recorded())
+		return nil
+	})
+	suite.NoError(err)
+	suite.True(metadata.Recorded())
+}
+
+func (suite *MemoryStorageTestSuite) TestCreateTransaction_Blocked_Account_Stores_Nothing() {
+	account, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+	suite.Require().NoError(err)
+	_, err = suite.accountService.BlockAccount(suite.context, account.Id)
+	suite.Require().NoError(err)
+
+	transaction, createErr := suite.transactionService.CreateTransaction(suite.context, models.TransactionRequest{AccountId: account.Id, OperationTypeId: 1, Amount: 50})
+
+	suite.Nil(transaction)
+	suite.ErrorIs(createErr, domain.ErrAccountBlocked)
+	transactions, listErr := suite.transactionService.ListTransactions(suite.context, account.Id)
+	suite.NoError(listErr)
+	suite.Empty(transactions)
+}
+
+func (suite *MemoryStorageTestSuite) TestCreateTransaction_Unknown_Account() {
+	transaction, err := suite.transactionService.CreateTransaction(suite.context, models.TransactionRequest{AccountId: 99, OperationTypeId: 1, Amount: 50})
+
+	suite.Nil(transaction)
+	suite.ErrorIs(err, domain.ErrTransactionAccountNotFound)
+}
+
+func (suite *MemoryStorageTestSuite) TestCreateTransaction_Voucher_Discharges_Purchase() {
+	account, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+	suite.Require().NoError(err)
+	purchase, err := suite.transactionService.CreateTransaction(suite.context, models.TransactionRequest{AccountId: account.Id, OperationTypeId: 1, Amount: 50})
+	suite.Require().NoError(err)
+
+	_, voucherErr := suite.transactionService.CreateTransaction(suite.context, models.TransactionRequest{AccountId: account.Id, OperationTypeId: 4, Amount: 60})
+
+	suite.NoError(voucherErr)
+	transactions, listErr := suite.transactionService.ListTransactions(suite.context, account.Id)
+	suite.NoError(listErr)
+	suite.Require().Len(transactions, 2)
+	suite.Equal(purchase.Id, transactions[0].Id)
+	suite.Equal(0.0, transactions[0].Balance)
+	verification, verifyErr := suite.auditService.VerifyChain(suite.context)
+	suite.NoError(verifyErr)
+	suite.True(verification.Valid)
+}
+
+func (suite *MemoryStorageTestSuite) TestUpdateAccount_Stale_Version_Is_Rejected() {
+	account, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+	suite.Require().NoError(err)
+	first, second := "Maria da Silva", "Maria Souza"
+
+	updated, updateErr := suite.accountService.UpdateAccount(suite.context, account.Id, account.Version, models.UpdateAccountRequest{FullName: &first})
+	_, staleErr := suite.accountService.UpdateAccount(suite.context, account.Id, account.Version, models.UpdateAccountRequest{FullName: &second})
+
+	suite.NoError(updateErr)
+	suite.Equal(account.Version+1, updated.Version)
+	suite.ErrorIs(staleErr, domain.ErrAccountVersionMismatch)
+	stored, getErr := suite.accountService.GetAccount(suite.context, account.Id)
+	suite.NoError(getErr)
+	suite.Equal(first, stored.Profile.FullName)
+	entries, listErr := suite.auditService.ListEntries(suite.context, domain.AuditFilter{Limit: 10})
+	suite.NoError(listErr)
+	actions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	suite.ElementsMatch([]string{domain.AuditActionAccountCreate, domain.AuditActionAccountUpdate}, actions)
+}
+
+func (suite *MemoryStorageTestSuite) TestCreateTransactions_All_Or_Nothing_Stores_Nothing_On_Failure() {
+	account, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+	suite.Require().NoError(err)
+
+	outcomes := suite.transactionService.CreateTransactions(suite.context, []models.TransactionRequest{
+		{AccountId: account.Id, OperationTypeId: 1, Amount: 50},
+		{AccountId: account.Id, OperationTypeId: 4, Amount: 60},
+		{AccountId: 99, OperationTypeId: 1, Amount: 10},
+	}, true)
+
+	suite.Equal(domain.BatchItemRolledBack, outcomes[0].Status)
+	suite.Equal(domain.BatchItemRolledBack, outcomes[1].Status)
+	suite.Equal(domain.BatchItemFailed, outcomes[2].Status)
+	suite.ErrorIs(outcomes[2].Err, domain.ErrTransactionAccountNotFound)
+	transactions, listErr := suite.transactionService.ListTransactions(suite.context, account.Id)
+	suite.NoError(listErr)
+	suite.Empty(transactions)
+	verification, verifyErr := suite.auditService.VerifyChain(suite.context)
+	suite.NoError(verifyErr)
+	suite.True(verification.Valid)
+}
+
+func (suite *MemoryStorageTestSuite) TestExportTransactions_Streams_Account_History_With_Descriptions() {
+	account, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "0123456789"})
+	suite.Require().NoError(err)
+	other, err := suite.accountService.RegisterAccount(suite.context, models.CreateAccountRequest{DocumentNumber: "9876543210"})
+	suite.Require().NoError(err)
+	for _, request := range []models.TransactionRequest{
+		{AccountId: account.Id, OperationTypeId: 1, Amount: 50},
+		{AccountId: other.Id, OperationTypeId: 3, Amount: 20},
+		{AccountId: account.Id, OperationTypeId: 4, Amount: 60},
+	} {
+		_, err = suite.transactionService.CreateTransaction(suite.context, request)
+		suite.Require().NoError(err)
+	}
+	stopErr := errors.New("client went away")
+
+	var exported []domain.ExportedTransaction
+	exportErr := suite.exportService.ExportTransactions(suite.context, domain.TransactionExportFilter{AccountId: account.Id}, func(transaction domain.ExportedTransaction) error {
+		exported = append(exported, transaction)
+		return nil
+	})
+	stoppedErr := suite.exportService.ExportTransactions(suite.context, domain.TransactionExportFilter{AccountId: account.Id}, func(domain.ExportedTransaction) error {
+		return stopErr
+	})
+
+	suite.NoError(exportErr)
+	suite.Require().Len(exported, 2)
+	suite.Equal("Normal Purchase", exported[0].OperationDescription)
+	suite.Equal(-50.0, exported[0].Amount)
+	suite.Equal("Credit Voucher", exported[1].OperationDescription)
+	suite.ErrorIs(stoppedErr, stopErr)
+}
@@ -42,6 +42,21 @@ func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
 	return m.recorder
 }
 
+// BlockAccount mocks base method.
+func (m *MockAccountService) BlockAccount(ctx context.Context, id int64) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAccount", ctx, id)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockAccount indicates an expected call of BlockAccount.
+func (mr *MockAccountServiceMockRecorder) BlockAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAccount", reflect.TypeOf((*MockAccountService)(nil).BlockAccount), ctx, id)
+}
+
 // GetAccount mocks base method.
 func (m *MockAccountService) GetAccount(ctx context.Context, id int64) (*domain.Account, error) {
 	m.ctrl.T.Helper()
@@ -71,3 +86,18 @@ func (mr *MockAccountServiceMockRecorder) RegisterAccount(ctx, request any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAccount", reflect.TypeOf((*MockAccountService)(nil).RegisterAccount), ctx, request)
 }
+
+// UpdateAccount mocks base method.
+func (m *MockAccountService) UpdateAccount(ctx context.Context, id int64, version int32, request models.UpdateAccountRequest) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", ctx, id, version, request)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockAccountServiceMockRecorder) UpdateAccount(ctx, id, version, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountService)(nil).UpdateAccount), ctx, id, version, request)
+}
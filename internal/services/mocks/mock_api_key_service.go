@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_key_service.go -destination=mocks/mock_api_key_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/credit-card-api/internal/domain"
+	models "github.com/credit-card-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApiKeyService is a mock of ApiKeyService interface.
+type MockApiKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApiKeyServiceMockRecorder is the mock recorder for MockApiKeyService.
+type MockApiKeyServiceMockRecorder struct {
+	mock *MockApiKeyService
+}
+
+// NewMockApiKeyService creates a new mock instance.
+func NewMockApiKeyService(ctrl *gomock.Controller) *MockApiKeyService {
+	mock := &MockApiKeyService{ctrl: ctrl}
+	mock.recorder = &MockApiKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyService) EXPECT() *MockApiKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockApiKeyService) Authenticate(ctx context.Context, key string) (*domain.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(*domain.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockApiKeyServiceMockRecorder) Authenticate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockApiKeyService)(nil).Authenticate), ctx, key)
+}
+
+// IssueKey mocks base method.
+func (m *MockApiKeyService) IssueKey(ctx context.Context, request models.CreateApiKeyRequest) (*domain.IssuedApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueKey", ctx, request)
+	ret0, _ := ret[0].(*domain.IssuedApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueKey indicates an expected call of IssueKey.
+func (mr *MockApiKeyServiceMockRecorder) IssueKey(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueKey", reflect.TypeOf((*MockApiKeyService)(nil).IssueKey), ctx, request)
+}
+
+// ListKeys mocks base method.
+func (m *MockApiKeyService) ListKeys(ctx context.Context) ([]domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", ctx)
+	ret0, _ := ret[0].([]domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockApiKeyServiceMockRecorder) ListKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockApiKeyService)(nil).ListKeys), ctx)
+}
+
+// RevokeKey mocks base method.
+func (m *MockApiKeyService) RevokeKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockApiKeyServiceMockRecorder) RevokeKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockApiKeyService)(nil).RevokeKey), ctx, id)
+}
+
+// RotateKey mocks base method.
+func (m *MockApiKeyService) RotateKey(ctx context.Context, id int64) (*domain.IssuedApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey", ctx, id)
+	ret0, _ := ret[0].(*domain.IssuedApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockApiKeyServiceMockRecorder) RotateKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockApiKeyService)(nil).RotateKey), ctx, id)
+}
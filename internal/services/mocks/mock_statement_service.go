@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statement_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=statement_service.go -destination=mocks/mock_statement_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	statement "github.com/credit-card-api/internal/statement"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementService is a mock of StatementService interface.
+type MockStatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementServiceMockRecorder is the mock recorder for MockStatementService.
+type MockStatementServiceMockRecorder struct {
+	mock *MockStatementService
+}
+
+// NewMockStatementService creates a new mock instance.
+func NewMockStatementService(ctrl *gomock.Controller) *MockStatementService {
+	mock := &MockStatementService{ctrl: ctrl}
+	mock.recorder = &MockStatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementService) EXPECT() *MockStatementServiceMockRecorder {
+	return m.recorder
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementService) GetStatement(ctx context.Context, accountId, statementId int64) (*statement.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, accountId, statementId)
+	ret0, _ := ret[0].(*statement.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementServiceMockRecorder) GetStatement(ctx, accountId, statementId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementService)(nil).GetStatement), ctx, accountId, statementId)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transaction_export_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=transaction_export_service.go -destination=mocks/mock_transaction_export_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/credit-card-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionExportService is a mock of TransactionExportService interface.
+type MockTransactionExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionExportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionExportServiceMockRecorder is the mock recorder for MockTransactionExportService.
+type MockTransactionExportServiceMockRecorder struct {
+	mock *MockTransactionExportService
+}
+
+// NewMockTransactionExportService creates a new mock instance.
+func NewMockTransactionExportService(ctrl *gomock.Controller) *MockTransactionExportService {
+	mock := &MockTransactionExportService{ctrl: ctrl}
+	mock.recorder = &MockTransactionExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionExportService) EXPECT() *MockTransactionExportServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportTransactions mocks base method.
+func (m *MockTransactionExportService) ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockTransactionExportServiceMockRecorder) ExportTransactions(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockTransactionExportService)(nil).ExportTransactions), ctx, filter, fn)
+}
+
+// OpenExport mocks base method.
+func (m *MockTransactionExportService) OpenExport(ctx context.Context, accountId int64) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenExport", ctx, accountId)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenExport indicates an expected call of OpenExport.
+func (mr *MockTransactionExportServiceMockRecorder) OpenExport(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenExport", reflect.TypeOf((*MockTransactionExportService)(nil).OpenExport), ctx, accountId)
+}
@@ -56,3 +56,47 @@ func (mr *MockTransactionServiceMockRecorder) CreateTransaction(ctx, request any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionService)(nil).CreateTransaction), ctx, request)
 }
+
+// CreateTransactions mocks base method.
+func (m *MockTransactionService) CreateTransactions(ctx context.Context, requests []models.TransactionRequest, allOrNothing bool) []domain.BatchItemOutcome {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransactions", ctx, requests, allOrNothing)
+	ret0, _ := ret[0].([]domain.BatchItemOutcome)
+	return ret0
+}
+
+// CreateTransactions indicates an expected call of CreateTransactions.
+func (mr *MockTransactionServiceMockRecorder) CreateTransactions(ctx, requests, allOrNothing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransactions", reflect.TypeOf((*MockTransactionService)(nil).CreateTransactions), ctx, requests, allOrNothing)
+}
+
+// ListTransactions mocks base method.
+func (m *MockTransactionService) ListTransactions(ctx context.Context, accountId int64) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, accountId)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockTransactionServiceMockRecorder) ListTransactions(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockTransactionService)(nil).ListTransactions), ctx, accountId)
+}
+
+// RecomputeBalances mocks base method.
+func (m *MockTransactionService) RecomputeBalances(ctx context.Context, accountId int64) ([]domain.BalanceCorrection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeBalances", ctx, accountId)
+	ret0, _ := ret[0].([]domain.BalanceCorrection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeBalances indicates an expected call of RecomputeBalances.
+func (mr *MockTransactionServiceMockRecorder) RecomputeBalances(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeBalances", reflect.TypeOf((*MockTransactionService)(nil).RecomputeBalances), ctx, accountId)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transaction_stream_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=transaction_stream_service.go -destination=mocks/mock_transaction_stream_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/credit-card-api/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionStreamService is a mock of TransactionStreamService interface.
+type MockTransactionStreamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionStreamServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionStreamServiceMockRecorder is the mock recorder for MockTransactionStreamService.
+type MockTransactionStreamServiceMockRecorder struct {
+	mock *MockTransactionStreamService
+}
+
+// NewMockTransactionStreamService creates a new mock instance.
+func NewMockTransactionStreamService(ctrl *gomock.Controller) *MockTransactionStreamService {
+	mock := &MockTransactionStreamService{ctrl: ctrl}
+	mock.recorder = &MockTransactionStreamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionStreamService) EXPECT() *MockTransactionStreamServiceMockRecorder {
+	return m.recorder
+}
+
+// EventsAfter mocks base method.
+func (m *MockTransactionStreamService) EventsAfter(ctx context.Context, accountId, afterEventId int64) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventsAfter", ctx, accountId, afterEventId)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventsAfter indicates an expected call of EventsAfter.
+func (mr *MockTransactionStreamServiceMockRecorder) EventsAfter(ctx, accountId, afterEventId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsAfter", reflect.TypeOf((*MockTransactionStreamService)(nil).EventsAfter), ctx, accountId, afterEventId)
+}
+
+// OpenStream mocks base method.
+func (m *MockTransactionStreamService) OpenStream(ctx context.Context, accountId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenStream", ctx, accountId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenStream indicates an expected call of OpenStream.
+func (mr *MockTransactionStreamServiceMockRecorder) OpenStream(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenStream", reflect.TypeOf((*MockTransactionStreamService)(nil).OpenStream), ctx, accountId)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// scenario is a testdata/scenarios file: steps run in order through the services over the memory storage.
+//
+//	description: a credit voucher discharges the oldest purchase first
+//	steps:
+//	  - create_account: alice
+//	  - transaction: {account: alice, operation_type_id: 1, amount: 50}
+//	    balances: [-50]
+//	  - transaction: {account: alice, operation_type_id: 4, amount: 60}
+//	    balances: [0, 60]
+type scenario struct {
+	Description string         `yaml:"description"`
+	Steps       []scenarioStep `yaml:"steps"`
+}
+
+// scenarioStep does exactly one of create_account, block_account and transaction. balances are those of every
+// transaction of the account, oldest first, after the step; error is the code of the AppError the step fails with.
+type scenarioStep struct {
+	CreateAccount string               `yaml:"create_account"`
+	BlockAccount  string               `yaml:"block_account"`
+	Transaction   *scenarioTransaction `yaml:"transaction"`
+	Balances      []float64            `yaml:"balances"`
+	Error         string               `yaml:"error"`
+}
+
+type scenarioTransaction struct {
+	Account         string  `yaml:"account"`
+	OperationTypeId int64   `yaml:"operation_type_id"`
+	Amount          float64 `yaml:"amount"`
+}
+
+func TestScenarios(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "scenarios", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".yaml"), func(t *testing.T) {
+			runScenario(t, loadScenario(t, path))
+		})
+	}
+}
+
+func loadScenario(t *testing.T, path string) scenario {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	var s scenario
+	require.NoError(t, decoder.Decode(&s), path)
+	return s
+}
+
+func runScenario(t *testing.T, s scenario) {
+	ctx := context.TODO()
+	repositories := memory.NewRepositories()
+	auditService := NewAuditService(repositories.Audit, repositories.Transactor)
+	accountService := NewAccountService(repositories.Accounts, repositories.Outbox, repositories.Transactor, auditService, metrics.Noop{})
+	transactionService := NewTransactionService(repositories.Transactions, repositories.Accounts, repositories.Outbox, repositories.Transactor, auditService, metrics.Noop{})
+	// accountIds resolves the names the scenario gives its accounts.
+	accountIds := map[string]int64{}
+
+	for i, step := range s.Steps {
+		name := fmt.Sprintf("%s, step %d", s.Description, i+1)
+		var account string
+		var err error
+		switch {
+		case step.CreateAccount != "":
+			account = step.CreateAccount
+			var created *domain.Account
+			created, err = accountService.RegisterAccount(ctx, models.CreateAccountRequest{DocumentNumber: fmt.Sprintf("%011d", len(accountIds)+1)})
+			if err == nil {
+				accountIds[account] = created.Id
+			}
+		case step.BlockAccount != "":
+			account = step.BlockAccount
+			_, err = accountService.BlockAccount(ctx, accountIds[account])
+		case step.Transaction != nil:
+			account = step.Transaction.Account
+			_, err = transactionService.CreateTransaction(ctx, models.TransactionRequest{
+				AccountId:       accountIds[account],
+				OperationTypeId: step.Transaction.OperationTypeId,
+				Amount:          step.Transaction.Amount,
+			})
+		default:
+			require.FailNow(t, "step has no action", name)
+		}
+
+		if step.Error != "" {
+			var appErr *domain.AppError
+			require.ErrorAs(t, err, &appErr, name)
+			assert.Equal(t, step.Error, appErr.Code, name)
+		} else {
+			require.NoError(t, err, name)
+		}
+
+		if step.Balances == nil {
+			continue
+		}
+		transactions, listErr := transactionService.ListTransactions(ctx, accountIds[account])
+		require.NoError(t, listErr, name)
+		balances := make([]float64, 0, len(transactions))
+		for _, transaction := range transactions {
+			balances = append(balances, transaction.Balance)
+		}
+		if assert.Len(t, balances, len(step.Balances), name) {
+			assert.InDeltaSlice(t, step.Balances, balances, 0.001, name)
+		}
+	}
+
+	// A replay must find the balances the steps stored, or recompute-balances would rewrite them.
+	for account, accountId := range accountIds {
+		corrections, err := transactionService.RecomputeBalances(ctx, accountId)
+		require.NoError(t, err, account)
+		assert.Empty(t, corrections, "%s: recompute corrected %s", s.Description, account)
+	}
+}
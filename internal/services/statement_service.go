@@ -0,0 +1,70 @@
+package services
+
+//go:generate mockgen -source=statement_service.go -destination=mocks/mock_statement_service.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/statement"
+)
+
+type StatementService interface {
+	// GetStatement builds the statement of the cycle statementId of an account from the transactions created within
+	// it. Only the closed cycles since the account was created have a statement.
+	GetStatement(ctx context.Context, accountId int64, statementId int64) (*statement.Document, error)
+}
+
+type statementService struct {
+	accountRepository     repository.AccountRepository
+	transactionRepository repository.TransactionRepository
+	transactor            repository.Transactor
+	config                statement.Config
+	now                   func() time.Time
+}
+
+func NewStatementService(accountRepository repository.AccountRepository, transactionRepository repository.TransactionRepository, transactor repository.Transactor, config statement.Config) StatementService {
+	return &statementService{accountRepository: accountRepository, transactionRepository: transactionRepository, transactor: transactor, config: config, now: time.Now}
+}
+
+func (ss *statementService) GetStatement(ctx context.Context, accountId int64, statementId int64) (*statement.Document, error) {
+	logging.FromContext(ctx).Infof("Started to build statement %d of accountId: %d", statementId, accountId)
+	account, err := ss.accountRepository.GetById(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+	now := ss.now().UTC()
+	start, end, ok := statement.Cycle(statementId)
+	if !ok || end.After(now) || !end.After(account.CreatedAt) {
+		logging.FromContext(ctx).Errorf("error: statement %d of accountId: %d is not a closed cycle of the account", statementId, accountId)
+		return nil, domain.ErrStatementNotFound
+	}
+
+	document := &statement.Document{
+		Id:          statementId,
+		AccountId:   accountId,
+		HolderName:  account.Profile.FullName,
+		Currency:    ss.config.Currency,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		DueDate:     end.AddDate(0, 0, ss.config.DueDays),
+		GeneratedAt: now,
+	}
+	// A cycle is a month of transactions, so they are read at once through the export cursor.
+	filter := domain.TransactionExportFilter{AccountId: accountId, From: &start, To: &end}
+	err = ss.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return ss.transactionRepository.ExportTransactions(txCtx, filter, func(transaction domain.ExportedTransaction) error {
+			document.Transactions = append(document.Transactions, transaction)
+			return nil
+		})
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while reading the transactions of statement %d of accountId: %d, error: %s", statementId, accountId, err.Error())
+		return nil, err
+	}
+	document.MinimumPayment = statement.MinimumPayment(document.Totals(), ss.config.MinimumPaymentRate)
+	return document, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/statement"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type StatementServiceTestSuite struct {
+	suite.Suite
+	context                   context.Context
+	mockTransactionRepository *mocks.MockTransactionRepository
+	mockAccountRepository     *mocks.MockAccountRepository
+	statementService          *statementService
+	account                   *domain.Account
+}
+
+func TestStatementServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(StatementServiceTestSuite))
+}
+
+func (suite *StatementServiceTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	mockController := gomock.NewController(suite.T())
+	suite.mockTransactionRepository = mocks.NewMockTransactionRepository(mockController)
+	suite.mockAccountRepository = mocks.NewMockAccountRepository(mockController)
+	mockTransactor := mocks.NewMockTransactor(mockController)
+	mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runWithinTransaction).AnyTimes()
+	suite.statementService = NewStatementService(suite.mockAccountRepository, suite.mockTransactionRepository, mockTransactor, statement.DefaultConfig()).(*statementService)
+	suite.statementService.now = func() time.Time { return time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC) }
+	suite.account = &domain.Account{Id: 1, CreatedAt: time.Date(2026, 8, 20, 0, 0, 0, 0, time.UTC), Profile: domain.AccountProfile{FullName: "Maria da Silva"}}
+}
+
+func (suite *StatementServiceTestSuite) TestGetStatement_Builds_Closed_Cycle() {
+	start, end := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	purchase := domain.ExportedTransaction{Transaction: domain.Transaction{Id: 3, AccountId: 1, OperationTypeId: 1, Amount: -100, Balance: -100}, OperationDescription: "Normal Purchase"}
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, int64(1)).Return(suite.account, nil)
+	suite.mockTransactionRepository.EXPECT().ExportTransactions(suite.context, domain.TransactionExportFilter{AccountId: 1, From: &start, To: &end}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+			return fn(purchase)
+		})
+
+	document, err := suite.statementService.GetStatement(suite.context, 1, 202609)
+
+	suite.NoError(err)
+	suite.Equal(&statement.Document{
+		Id: 202609, AccountId: 1, HolderName: "Maria da Silva", Currency: "BRL",
+		PeriodStart: start, PeriodEnd: end, DueDate: time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC),
+		MinimumPayment: 15, Transactions: []domain.ExportedTransaction{purchase},
+		GeneratedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}, document)
+}
+
+func (suite *StatementServiceTestSuite) TestGetStatement_Only_Closed_Cycles_Of_The_Account() {
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, int64(1)).Return(suite.account, nil).Times(4)
+
+	for _, statementId := range []int64{202610, 202607, 202613, 9} {
+		document, err := suite.statementService.GetStatement(suite.context, 1, statementId)
+
+		suite.Nil(document, "statement %d", statementId)
+		suite.ErrorIs(err, domain.ErrStatementNotFound, "statement %d", statementId)
+	}
+}
+
+func (suite *StatementServiceTestSuite) TestGetStatement_ReturnErr_When_Transactions_Fail() {
+	expectedErr := errors.New("connection reset")
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, int64(1)).Return(suite.account, nil)
+	suite.mockTransactionRepository.EXPECT().ExportTransactions(suite.context, gomock.Any(), gomock.Any()).Return(expectedErr)
+
+	document, err := suite.statementService.GetStatement(suite.context, 1, 202609)
+
+	suite.Nil(document)
+	suite.Equal(expectedErr, err)
+}
@@ -0,0 +1,50 @@
+package services
+
+//go:generate mockgen -source=transaction_export_service.go -destination=mocks/mock_transaction_export_service.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type TransactionExportService interface {
+	// OpenExport returns the account to export, so a missing account is reported before the export starts.
+	OpenExport(ctx context.Context, accountId int64) (*domain.Account, error)
+	// ExportTransactions calls fn with every transaction of filter, oldest first, streamed from the database in
+	// a transaction of its own. fn returning an error stops the export with it.
+	ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error
+}
+
+type transactionExportService struct {
+	accountRepository     repository.AccountRepository
+	transactionRepository repository.TransactionRepository
+	transactor            repository.Transactor
+}
+
+func NewTransactionExportService(accountRepository repository.AccountRepository, transactionRepository repository.TransactionRepository, transactor repository.Transactor) TransactionExportService {
+	return &transactionExportService{accountRepository: accountRepository, transactionRepository: transactionRepository, transactor: transactor}
+}
+
+func (es *transactionExportService) OpenExport(ctx context.Context, accountId int64) (*domain.Account, error) {
+	logging.FromContext(ctx).Infof("Started to open transaction export for accountId: %d", accountId)
+	return es.accountRepository.GetById(ctx, accountId)
+}
+
+func (es *transactionExportService) ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) error {
+	var exported int
+	err := es.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return es.transactionRepository.ExportTransactions(txCtx, filter, func(transaction domain.ExportedTransaction) error {
+			exported++
+			return fn(transaction)
+		})
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while export transactions of accountId: %d after %d transactions, error: %s", filter.AccountId, exported, err.Error())
+		return err
+	}
+	logging.FromContext(ctx).Infof("exported %d transactions of accountId: %d", exported, filter.AccountId)
+	return nil
+}
@@ -3,117 +3,370 @@ package services
 //go:generate mockgen -source=transaction_service.go -destination=mocks/mock_transaction_service.go -package=mocks
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"math"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/metrics"
 	"github.com/credit-card-api/internal/models"
 	"github.com/credit-card-api/internal/repository"
-	logger "github.com/sirupsen/logrus"
 )
 
 type TransactionService interface {
 	CreateTransaction(ctx context.Context, request models.TransactionRequest) (*domain.Transaction, error)
+	// CreateTransactions creates requests in account order, keeping the order of each account. Each request is
+	// created on its own unless allOrNothing, where the first failure rolls back every request. The outcomes follow
+	// the order of requests.
+	CreateTransactions(ctx context.Context, requests []models.TransactionRequest, allOrNothing bool) []domain.BatchItemOutcome
+	// ListTransactions returns the transactions of an account, oldest first.
+	ListTransactions(ctx context.Context, accountId int64) ([]domain.Transaction, error)
+	// RecomputeBalances replays the transactions of an account and corrects every balance that differs.
+	RecomputeBalances(ctx context.Context, accountId int64) ([]domain.BalanceCorrection, error)
 }
 
 type transactionService struct {
 	transactionRepo repository.TransactionRepository
 	accountRepo     repository.AccountRepository
+	outboxRepo      repository.OutboxRepository
+	transactor      repository.Transactor
+	auditService    AuditService
+	recorder        metrics.Recorder
 }
 
-func NewTransactionService(transactionRepo repository.TransactionRepository, accountRepo repository.AccountRepository) TransactionService {
-	return &transactionService{transactionRepo: transactionRepo, accountRepo: accountRepo}
+func NewTransactionService(transactionRepo repository.TransactionRepository, accountRepo repository.AccountRepository, outboxRepo repository.OutboxRepository, transactor repository.Transactor, auditService AuditService, recorder metrics.Recorder) TransactionService {
+	return &transactionService{transactionRepo: transactionRepo, accountRepo: accountRepo, outboxRepo: outboxRepo, transactor: transactor, auditService: auditService, recorder: recorder}
 }
 
 func (ts *transactionService) CreateTransaction(ctx context.Context, request models.TransactionRequest) (*domain.Transaction, error) {
-	logger.Infof("Started to create transaction with accountId: %d and operationTypeId :%d", request.AccountId, request.OperationTypeId)
+	// The account comes in the body rather than the route, the request logger picks it up here.
+	logging.AddField(ctx, logging.AccountIdField, request.AccountId)
+	var dischargeSteps []string
+	transaction, err := ts.createWithinTransaction(ctx, request, &dischargeSteps)
+	if err != nil {
+		ts.recorder.TransactionRejected(errorCode(err))
+		return nil, err
+	}
+
+	// Recorded once committed, a rolled back voucher discharged nothing.
+	for _, outcome := range dischargeSteps {
+		ts.recorder.VoucherDischargeStep(outcome)
+	}
+	ts.recorder.TransactionCreated(transaction.OperationTypeId)
+	return transaction, nil
+}
+
+func (ts *transactionService) CreateTransactions(ctx context.Context, requests []models.TransactionRequest, allOrNothing bool) []domain.BatchItemOutcome {
+	logging.FromContext(ctx).Infof("Started to create a batch of %d transactions, all or nothing: %t", len(requests), allOrNothing)
+	// Accounts are taken in order, so concurrent all or nothing batches lock the accounts they share in the same order.
+	order := make([]int, len(requests))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(requests[a].AccountId, requests[b].AccountId)
+	})
+
+	outcomes := make([]domain.BatchItemOutcome, len(requests))
+	if !allOrNothing {
+		for _, i := range order {
+			transaction, err := ts.CreateTransaction(ctx, requests[i])
+			if err != nil {
+				outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemFailed, Err: err}
+				continue
+			}
+			outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemCreated, Transaction: transaction}
+		}
+		return outcomes
+	}
+
+	for i := range outcomes {
+		outcomes[i].Status = domain.BatchItemSkipped
+	}
+	var dischargeSteps []string
+	failed := -1
+	err := ts.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		for _, i := range order {
+			transaction, createErr := ts.createWithinTransaction(txCtx, requests[i], &dischargeSteps)
+			if createErr != nil {
+				failed = i
+				return createErr
+			}
+			outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemCreated, Transaction: transaction}
+		}
+		return nil
+	})
+	if err != nil {
+		ts.recorder.TransactionRejected(errorCode(err))
+		for i := range outcomes {
+			switch {
+			case i == failed || failed < 0:
+				// failed stays unset when the commit itself failed, which every item shares.
+				outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemFailed, Err: err}
+			case outcomes[i].Status == domain.BatchItemCreated:
+				outcomes[i] = domain.BatchItemOutcome{Status: domain.BatchItemRolledBack}
+			}
+		}
+		return outcomes
+	}
+
+	for _, outcome := range dischargeSteps {
+		ts.recorder.VoucherDischargeStep(outcome)
+	}
+	for _, outcome := range outcomes {
+		ts.recorder.TransactionCreated(outcome.Transaction.OperationTypeId)
+	}
+	return outcomes
+}
+
+func (ts *transactionService) createWithinTransaction(ctx context.Context, request models.TransactionRequest, dischargeSteps *[]string) (*domain.Transaction, error) {
+	logging.FromContext(ctx).Infof("Started to create transaction with accountId: %d and operationTypeId :%d", request.AccountId, request.OperationTypeId)
 	operationType, exists := domain.ValidOperations[request.OperationTypeId]
 	if !exists {
-		logger.Errorf("error: operation type id %d is not supported by the system.", request.OperationTypeId)
+		logging.FromContext(ctx).Errorf("error: operation type id %d is not supported by the system.", request.OperationTypeId)
 		return nil, domain.ErrInvalidOperationType
 	}
 
-	_, err := ts.accountRepo.GetById(ctx, request.AccountId)
+	// The transaction, every balance it discharges and their outbox events are committed together.
+	var transaction *domain.Transaction
+	err := ts.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var createErr error
+		transaction, createErr = ts.createTransaction(txCtx, request, operationType, dischargeSteps)
+		return createErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// createTransaction appends the outcome of every purchase balance a credit voucher discharges to dischargeSteps. The
+// account stays locked until commit, so concurrent vouchers of an account never discharge the same balances.
+func (ts *transactionService) createTransaction(ctx context.Context, request models.TransactionRequest, operationType domain.TransactionType, dischargeSteps *[]string) (*domain.Transaction, error) {
+	account, err := ts.accountRepo.LockById(ctx, request.AccountId)
 	if err != nil {
 		if isAccountNotFoundError(err) {
-			logger.Errorf("error: account is not exist with provided id: %d", request.AccountId)
+			logging.FromContext(ctx).Errorf("error: account is not exist with provided id: %d", request.AccountId)
 			return nil, domain.ErrTransactionAccountNotFound
 		}
 		return nil, err
 	}
+	if account.BlockedAt != nil {
+		logging.FromContext(ctx).Errorf("error: account %d is blocked since %s", request.AccountId, account.BlockedAt.Format(time.RFC3339))
+		return nil, domain.ErrAccountBlocked
+	}
 
+	finalAmount := normalizeAmountByOperation(request.Amount, operationType)
+	balance := finalAmount
 	if request.OperationTypeId == 4 {
 		//Need to fetch transactions for same accountID based on eventDate in ascending order.
 		transactions, fetchTransactionErr := ts.transactionRepo.GetAllTransactions(ctx, request.AccountId)
 
 		if fetchTransactionErr != nil {
-			if !isAccountNotFoundError(err) {
-				logger.Errorf("error: account is not exist with provided id: %d", request.AccountId)
+			if isAccountNotFoundError(fetchTransactionErr) {
+				logging.FromContext(ctx).Errorf("error: account is not exist with provided id: %d", request.AccountId)
 				return nil, domain.ErrTransactionAccountNotFound
 			}
+			logging.FromContext(ctx).Errorf("error: failed to fetch transactions of account %d: %v", request.AccountId, fetchTransactionErr)
+			return nil, domain.ErrInternal
 		}
-		// 60,
-		// -50. 23.5, -18.7
-		remainingBalance := request.Amount
-		var updatedBalance float64
-
-		for _, tx := range transactions {
-			if remainingBalance > tx.Balance && remainingBalance > 0 {
-				remainingBalance = remainingBalance + tx.Balance
-				if remainingBalance > 0 {
-					transactionUpdateErr := ts.transactionRepo.UpdateTransactionById(ctx, tx.Id, 0.0)
-					if transactionUpdateErr != nil {
-						return nil, domain.ErrInternal
-					}
-				} else if remainingBalance != 0 {
-					transactionUpdateErr := ts.transactionRepo.UpdateTransactionById(ctx, tx.Id, remainingBalance)
-					if transactionUpdateErr != nil {
-						return nil, domain.ErrInternal
-					}
-				}
-				continue
-			}
-			finalAmount := normalizeAmountByOperation(request.Amount, operationType)
-			if remainingBalance > 0 {
-				updatedBalance = request.Amount + remainingBalance
-			} else {
-				updatedBalance = 0.0
+		discharge := dischargeVoucher(transactions, finalAmount)
+		for _, update := range discharge.updates {
+			if transactionUpdateErr := ts.updateBalance(ctx, transactions[update.index], update.balance); transactionUpdateErr != nil {
+				return nil, domain.ErrInternal
 			}
-
-			transaction := domain.CreateTransactionParam{
-				AccountId:       request.AccountId,
-				OperationTypeId: request.OperationTypeId,
-				Amount:          finalAmount,
-				Balance:         updatedBalance,
-			}
-			return ts.transactionRepo.Create(ctx, transaction)
-			//else if tx.Balance < 0 { // 60 > -50 && -50 < 0 ,  // 10 > -23.5 &&
-			//	remainingBalance = remainingBalance + tx.Balance // 60 - 50 = 10, // 10 - 23.5 == 13.5
-			//	// Update query to update transaction balance with the correct value
-			//	if remainingBalance > 0 {
-			//		updatedBalance = 0
-			//	} else {
-			//		updatedBalance = remainingBalance
-			//	}
-			//
-			//	transactionUpdateErr := ts.transactionRepo.UpdateTransactionById(ctx, tx.Id, updatedBalance)
-			//
-			//	if transactionUpdateErr != nil {
-			//		return nil, domain.ErrInternal
-			//	}
-			//}
+			*dischargeSteps = append(*dischargeSteps, update.step)
 		}
+		balance = discharge.balance
 	}
 
-	finalAmount := normalizeAmountByOperation(request.Amount, operationType)
 	transaction := domain.CreateTransactionParam{
 		AccountId:       request.AccountId,
 		OperationTypeId: request.OperationTypeId,
 		Amount:          finalAmount,
-		Balance:         finalAmount,
+		Balance:         balance,
+	}
+	return ts.create(ctx, transaction)
+}
+func (ts *transactionService) ListTransactions(ctx context.Context, accountId int64) ([]domain.Transaction, error) {
+	logging.FromContext(ctx).Infof("Started to list transactions of accountId: %d", accountId)
+	if _, err := ts.accountRepo.GetById(ctx, accountId); err != nil {
+		return nil, err
 	}
-	return ts.transactionRepo.Create(ctx, transaction)
+	return ts.transactionRepo.GetAllTransactions(ctx, accountId)
+}
+
+func (ts *transactionService) RecomputeBalances(ctx context.Context, accountId int64) ([]domain.BalanceCorrection, error) {
+	logging.FromContext(ctx).Infof("Started to recompute balances of accountId: %d", accountId)
+	var corrections []domain.BalanceCorrection
+	err := ts.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := ts.accountRepo.LockById(txCtx, accountId); err != nil {
+			return err
+		}
+		transactions, err := ts.transactionRepo.GetAllTransactions(txCtx, accountId)
+		if err != nil {
+			return err
+		}
+		for i, balance := range replayBalances(transactions) {
+			transaction := transactions[i]
+			if balance == roundCents(transaction.Balance) {
+				continue
+			}
+			if err := ts.updateBalance(txCtx, transaction, balance); err != nil {
+				return err
+			}
+			corrections = append(corrections, domain.BalanceCorrection{
+				TransactionId:   transaction.Id,
+				AccountId:       transaction.AccountId,
+				PreviousBalance: transaction.Balance,
+				Balance:         balance,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return corrections, nil
+}
+
+// replayBalances recomputes the balances of transactions sorted oldest first from their amounts alone: a purchase
+// or a withdrawal opens its amount, a credit voucher runs dischargeVoucher over the replayed balances before it.
+func replayBalances(transactions []domain.Transaction) []float64 {
+	replayed := make([]domain.Transaction, len(transactions))
+	balances := make([]float64, len(transactions))
+	for i, transaction := range transactions {
+		replayed[i] = transaction
+		balances[i] = roundCents(transaction.Amount)
+		if transaction.OperationTypeId == 4 {
+			discharge := dischargeVoucher(replayed[:i], transaction.Amount)
+			for _, update := range discharge.updates {
+				replayed[update.index].Balance = update.balance
+				balances[update.index] = update.balance
+			}
+			balances[i] = discharge.balance
+		}
+		replayed[i].Balance = balances[i]
+	}
+	return balances
+}
+
+// voucherDischarge is what a credit voucher does to the transactions of its account and the balance it is stored
+// with.
+type voucherDischarge struct {
+	updates []balanceUpdate
+	balance float64
+}
+
+// balanceUpdate is the new balance of the transaction at index, with the metrics.Discharge step it counts as.
+type balanceUpdate struct {
+	index   int
+	balance float64
+	step    string
+}
+
+// dischargeVoucher applies a credit voucher of amount to transactions, the transactions of its account oldest
+// first. While the voucher has something left and is above a balance, that balance is added to what is left and the
+// transaction is settled, or keeps what is still owed. The voucher is stored with its amount plus what is left when
+// it stops at a larger balance, with 0 when it ran out, and with its full amount when it went through every
+// transaction. CreateTransaction and RecomputeBalances share it, so a replay finds the balances creation stored.
+func dischargeVoucher(transactions []domain.Transaction, amount float64) voucherDischarge {
+	var discharge voucherDischarge
+	remaining := amount
+	for i, transaction := range transactions {
+		if remaining > transaction.Balance && remaining > 0 {
+			remaining = roundCents(remaining + transaction.Balance)
+			if remaining > 0 {
+				discharge.updates = append(discharge.updates, balanceUpdate{index: i, balance: 0, step: metrics.DischargeSettled})
+			} else if remaining != 0 {
+				discharge.updates = append(discharge.updates, balanceUpdate{index: i, balance: remaining, step: metrics.DischargePartial})
+			}
+			continue
+		}
+		if remaining > 0 {
+			discharge.balance = roundCents(amount + remaining)
+		}
+		return discharge
+	}
+	discharge.balance = amount
+	return discharge
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (ts *transactionService) create(ctx context.Context, transactionParam domain.CreateTransactionParam) (*domain.Transaction, error) {
+	transaction, err := ts.transactionRepo.Create(ctx, transactionParam)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := domain.TransactionCreatedPayload{
+		TransactionId:   transaction.Id,
+		AccountId:       transaction.AccountId,
+		OperationTypeId: transaction.OperationTypeId,
+		Amount:          transaction.Amount,
+		Balance:         transaction.Balance,
+		CreatedAt:       transaction.CreatedAt,
+	}
+	_, eventErr := ts.outboxRepo.Create(ctx, domain.CreateOutboxEventParam{
+		EventType:     domain.EventTransactionCreated,
+		AggregateType: domain.AggregateTransaction,
+		AggregateId:   transaction.Id,
+		AccountId:     transaction.AccountId,
+		Payload:       payload,
+	})
+	if eventErr != nil {
+		return nil, eventErr
+	}
+
+	auditErr := ts.auditService.Record(ctx, domain.AuditRecord{
+		Action:       domain.AuditActionTransactionCreate,
+		ResourceType: domain.AuditResourceTransaction,
+		ResourceId:   strconv.FormatInt(transaction.Id, 10),
+		After:        payload,
+	})
+	if auditErr != nil {
+		return nil, auditErr
+	}
+	return transaction, nil
+}
+
+func (ts *transactionService) updateBalance(ctx context.Context, transaction domain.Transaction, balance float64) error {
+	err := ts.transactionRepo.UpdateTransactionById(ctx, transaction.Id, balance)
+	if err != nil {
+		return err
+	}
+
+	_, eventErr := ts.outboxRepo.Create(ctx, domain.CreateOutboxEventParam{
+		EventType:     domain.EventTransactionBalanceUpdated,
+		AggregateType: domain.AggregateTransaction,
+		AggregateId:   transaction.Id,
+		AccountId:     transaction.AccountId,
+		Payload: domain.TransactionBalanceUpdatedPayload{
+			TransactionId:   transaction.Id,
+			AccountId:       transaction.AccountId,
+			PreviousBalance: transaction.Balance,
+			Balance:         balance,
+		},
+	})
+	if eventErr != nil {
+		return eventErr
+	}
+
+	return ts.auditService.Record(ctx, domain.AuditRecord{
+		Action:       domain.AuditActionTransactionBalanceUpdate,
+		ResourceType: domain.AuditResourceTransaction,
+		ResourceId:   strconv.FormatInt(transaction.Id, 10),
+		Before:       map[string]float64{"balance": transaction.Balance},
+		After:        map[string]float64{"balance": balance},
+	})
 }
 
 func normalizeAmountByOperation(amount float64, opType domain.TransactionType) float64 {
@@ -124,6 +377,15 @@ func normalizeAmountByOperation(amount float64, opType domain.TransactionType) f
 	return abs
 }
 
+// errorCode labels a failure with its AppError code; unexpected errors count as internal.
+func errorCode(err error) string {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return domain.ErrInternal.Code
+}
+
 func isAccountNotFoundError(err error) bool {
 	var appErr *domain.AppError
 	if errors.As(err, &appErr) {
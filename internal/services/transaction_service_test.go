@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/metrics"
+	metricsMocks "github.com/credit-card-api/internal/metrics/mocks"
 	"github.com/credit-card-api/internal/models"
 	"github.com/credit-card-api/internal/repository/mocks"
+	serviceMocks "github.com/credit-card-api/internal/services/mocks"
 	"github.com/credit-card-api/pkg/constants"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -25,6 +28,10 @@ type TransactionServiceTestSuite struct {
 	mockController            *gomock.Controller
 	mockTransactionRepository *mocks.MockTransactionRepository
 	mockAccountRepository     *mocks.MockAccountRepository
+	mockOutboxRepository      *mocks.MockOutboxRepository
+	mockTransactor            *mocks.MockTransactor
+	mockAuditService          *serviceMocks.MockAuditService
+	mockRecorder              *metricsMocks.MockRecorder
 	transactionService        TransactionService
 }
 
@@ -37,7 +44,13 @@ func (suite *TransactionServiceTestSuite) SetupTest() {
 	suite.mockController = gomock.NewController(suite.T())
 	suite.mockTransactionRepository = mocks.NewMockTransactionRepository(suite.mockController)
 	suite.mockAccountRepository = mocks.NewMockAccountRepository(suite.mockController)
-	suite.transactionService = NewTransactionService(suite.mockTransactionRepository, suite.mockAccountRepository)
+	suite.mockOutboxRepository = mocks.NewMockOutboxRepository(suite.mockController)
+	suite.mockTransactor = mocks.NewMockTransactor(suite.mockController)
+	suite.mockAuditService = serviceMocks.NewMockAuditService(suite.mockController)
+	suite.mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runWithinTransaction).AnyTimes()
+	suite.mockAuditService.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	suite.mockRecorder = metricsMocks.NewMockRecorder(suite.mockController)
+	suite.transactionService = NewTransactionService(suite.mockTransactionRepository, suite.mockAccountRepository, suite.mockOutboxRepository, suite.mockTransactor, suite.mockAuditService, suite.mockRecorder)
 	testAccountId = 1
 	testTransactionId = 1
 }
@@ -58,17 +71,35 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_Success() {
 		AccountId:       testAccountId,
 		OperationTypeId: 2,
 		Amount:          -2345.67,
+		Balance:         -2345.67,
 	}
 	expectedTransaction := &domain.Transaction{
 		Id:              testTransactionId,
 		AccountId:       accountId,
 		OperationTypeId: 2,
 		Amount:          -2345.67,
+		Balance:         -2345.67,
 		CreatedAt:       time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
 	}
+	eventParam := domain.CreateOutboxEventParam{
+		EventType:     domain.EventTransactionCreated,
+		AggregateType: domain.AggregateTransaction,
+		AggregateId:   testTransactionId,
+		AccountId:     accountId,
+		Payload: domain.TransactionCreatedPayload{
+			TransactionId:   testTransactionId,
+			AccountId:       accountId,
+			OperationTypeId: 2,
+			Amount:          -2345.67,
+			Balance:         -2345.67,
+			CreatedAt:       time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
 
-	suite.mockAccountRepository.EXPECT().GetById(suite.context, testAccountId).Return(account, nil)
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(account, nil)
 	suite.mockTransactionRepository.EXPECT().Create(suite.context, transactionParam).Return(expectedTransaction, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, eventParam).Return(&domain.OutboxEvent{Id: 1}, nil).Times(1)
+	suite.mockRecorder.EXPECT().TransactionCreated(int64(2))
 
 	response, err := suite.transactionService.CreateTransaction(suite.context, request)
 
@@ -92,17 +123,22 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_When_OperationTy
 		AccountId:       testAccountId,
 		OperationTypeId: 4,
 		Amount:          4567.67,
+		Balance:         4567.67,
 	}
 	expectedTransaction := &domain.Transaction{
 		Id:              testTransactionId,
 		AccountId:       accountId,
 		OperationTypeId: 4,
 		Amount:          4567.67,
+		Balance:         4567.67,
 		CreatedAt:       time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC),
 	}
 
-	suite.mockAccountRepository.EXPECT().GetById(suite.context, testAccountId).Return(account, nil)
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(account, nil)
+	suite.mockTransactionRepository.EXPECT().GetAllTransactions(suite.context, testAccountId).Return(nil, nil)
 	suite.mockTransactionRepository.EXPECT().Create(suite.context, transactionParam).Return(expectedTransaction, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{Id: 1}, nil).Times(1)
+	suite.mockRecorder.EXPECT().TransactionCreated(int64(4))
 
 	response, err := suite.transactionService.CreateTransaction(suite.context, request)
 
@@ -110,6 +146,88 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_When_OperationTy
 	suite.Equal(expectedTransaction, response)
 }
 
+func (suite *TransactionServiceTestSuite) TestCreateTransaction_When_Payment_Discharges_Balance_Records_BalanceUpdated_Event() {
+	request := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 4,
+		Amount:          60,
+	}
+	purchase := domain.Transaction{
+		Id:              testTransactionId,
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          -50,
+		Balance:         -50,
+	}
+	eventParam := domain.CreateOutboxEventParam{
+		EventType:     domain.EventTransactionBalanceUpdated,
+		AggregateType: domain.AggregateTransaction,
+		AggregateId:   testTransactionId,
+		AccountId:     testAccountId,
+		Payload: domain.TransactionBalanceUpdatedPayload{
+			TransactionId:   testTransactionId,
+			AccountId:       testAccountId,
+			PreviousBalance: -50,
+			Balance:         0,
+		},
+	}
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: testAccountId}, nil)
+	suite.mockTransactionRepository.EXPECT().GetAllTransactions(suite.context, testAccountId).Return([]domain.Transaction{purchase}, nil)
+	suite.mockTransactionRepository.EXPECT().UpdateTransactionById(suite.context, testTransactionId, 0.0).Return(nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, eventParam).Return(&domain.OutboxEvent{Id: 1}, nil).Times(1)
+	suite.mockTransactionRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Transaction{Id: 2, AccountId: testAccountId}, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{Id: 2}, nil).Times(1)
+	suite.mockRecorder.EXPECT().VoucherDischargeStep(metrics.DischargeSettled)
+	suite.mockRecorder.EXPECT().TransactionCreated(int64(0))
+
+	response, err := suite.transactionService.CreateTransaction(suite.context, request)
+
+	suite.Nil(err)
+	suite.Equal(int64(2), response.Id)
+}
+
+func (suite *TransactionServiceTestSuite) TestCreateTransaction_When_Payment_Partially_Discharges_Balance_Records_Partial_Step() {
+	request := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 4,
+		Amount:          30,
+	}
+	purchase := domain.Transaction{Id: testTransactionId, AccountId: testAccountId, OperationTypeId: 1, Amount: -50, Balance: -50}
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: testAccountId}, nil)
+	suite.mockTransactionRepository.EXPECT().GetAllTransactions(suite.context, testAccountId).Return([]domain.Transaction{purchase}, nil)
+	suite.mockTransactionRepository.EXPECT().UpdateTransactionById(suite.context, testTransactionId, -20.0).Return(nil).Times(1)
+	suite.mockTransactionRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Transaction{Id: 2, AccountId: testAccountId, OperationTypeId: 4}, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{Id: 1}, nil).Times(2)
+	suite.mockRecorder.EXPECT().VoucherDischargeStep(metrics.DischargePartial)
+	suite.mockRecorder.EXPECT().TransactionCreated(int64(4))
+
+	response, err := suite.transactionService.CreateTransaction(suite.context, request)
+
+	suite.Nil(err)
+	suite.Equal(int64(2), response.Id)
+}
+
+func (suite *TransactionServiceTestSuite) TestCreateTransaction_ReturnErr_When_OutboxRepo_Returns_Error() {
+	request := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          10,
+	}
+	expectedErr := errors.New("failed to create outbox event")
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: testAccountId}, nil)
+	suite.mockTransactionRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Transaction{Id: 1, AccountId: testAccountId}, nil).Times(1)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(nil, expectedErr).Times(1)
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.InternalServerErrCode)
+
+	response, err := suite.transactionService.CreateTransaction(suite.context, request)
+
+	suite.Nil(response)
+	suite.Equal(expectedErr, err)
+}
+
 func (suite *TransactionServiceTestSuite) TestCreateTransaction_Return_Error_When_OperationTypeId_IsNotSupported() {
 	request := models.TransactionRequest{
 		AccountId:       testAccountId,
@@ -121,6 +239,8 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_Return_Error_Whe
 		Message: "operation type ID provided is not supported by the system.",
 	}
 
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.InvalidOperationTypeErrCode)
+
 	response, err := suite.transactionService.CreateTransaction(suite.context, request)
 
 	suite.Nil(response)
@@ -144,7 +264,8 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_ReturnErr_When_A
 		Message: "account does not exist with provided id.",
 	}
 
-	suite.mockAccountRepository.EXPECT().GetById(suite.context, testAccountId).Return(nil, accountErr)
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(nil, accountErr)
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.TransactionAccountNotFoundErrCode)
 
 	response, err := suite.transactionService.CreateTransaction(suite.context, request)
 
@@ -161,7 +282,8 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_ReturnErr_When_A
 
 	expectedErr := errors.New("failed to fetch an account")
 
-	suite.mockAccountRepository.EXPECT().GetById(suite.context, testAccountId).Return(nil, expectedErr)
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(nil, expectedErr)
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.InternalServerErrCode)
 
 	response, err := suite.transactionService.CreateTransaction(suite.context, request)
 
@@ -169,6 +291,156 @@ func (suite *TransactionServiceTestSuite) TestCreateTransaction_ReturnErr_When_A
 	suite.Nil(response)
 }
 
+func (suite *TransactionServiceTestSuite) TestCreateTransaction_ReturnErr_When_Payment_Fails_To_Fetch_Transactions() {
+	request := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 4,
+		Amount:          100,
+	}
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: accountId}, nil)
+	suite.mockTransactionRepository.EXPECT().GetAllTransactions(suite.context, testAccountId).Return(nil, errors.New("connection reset"))
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.InternalServerErrCode)
+
+	response, err := suite.transactionService.CreateTransaction(suite.context, request)
+
+	suite.Equal(domain.ErrInternal, err)
+	suite.Nil(response)
+}
+
+func (suite *TransactionServiceTestSuite) TestCreateTransaction_ReturnErr_When_Account_Is_Blocked() {
+	request := models.TransactionRequest{
+		AccountId:       testAccountId,
+		OperationTypeId: 1,
+		Amount:          10,
+	}
+	blockedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: testAccountId, BlockedAt: &blockedAt}, nil)
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.AccountBlockedErrCode)
+
+	response, err := suite.transactionService.CreateTransaction(suite.context, request)
+
+	suite.Nil(response)
+	suite.Equal(domain.ErrAccountBlocked, err)
+}
+
+func (suite *TransactionServiceTestSuite) TestListTransactions_ReturnErr_When_Account_Does_Not_Exist() {
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, testAccountId).Return(nil, domain.ErrAccountNotFound)
+
+	transactions, err := suite.transactionService.ListTransactions(suite.context, testAccountId)
+
+	suite.Nil(transactions)
+	suite.Equal(domain.ErrAccountNotFound, err)
+}
+
+func (suite *TransactionServiceTestSuite) TestRecomputeBalances_Corrects_Drifted_Balances() {
+	purchase := domain.Transaction{Id: 1, AccountId: testAccountId, OperationTypeId: 1, Amount: -50, Balance: -50}
+	withdrawal := domain.Transaction{Id: 2, AccountId: testAccountId, OperationTypeId: 3, Amount: -23.5, Balance: -23.5}
+	voucher := domain.Transaction{Id: 3, AccountId: testAccountId, OperationTypeId: 4, Amount: 100, Balance: 100}
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: testAccountId}, nil)
+	suite.mockTransactionRepository.EXPECT().GetAllTransactions(suite.context, testAccountId).Return([]domain.Transaction{purchase, withdrawal, voucher}, nil)
+	suite.mockTransactionRepository.EXPECT().UpdateTransactionById(suite.context, int64(1), 0.0).Return(nil)
+	suite.mockTransactionRepository.EXPECT().UpdateTransactionById(suite.context, int64(2), 0.0).Return(nil)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{Id: 1}, nil).Times(2)
+
+	corrections, err := suite.transactionService.RecomputeBalances(suite.context, testAccountId)
+
+	// The voucher went through every purchase, CreateTransaction stores it with its full amount.
+	suite.Nil(err)
+	suite.Equal([]domain.BalanceCorrection{
+		{TransactionId: 1, AccountId: testAccountId, PreviousBalance: -50, Balance: 0},
+		{TransactionId: 2, AccountId: testAccountId, PreviousBalance: -23.5, Balance: 0},
+	}, corrections)
+}
+
+func (suite *TransactionServiceTestSuite) TestRecomputeBalances_Leaves_Consistent_Balances() {
+	transactions := []domain.Transaction{
+		{Id: 1, AccountId: testAccountId, OperationTypeId: 1, Amount: -50, Balance: 0},
+		{Id: 2, AccountId: testAccountId, OperationTypeId: 1, Amount: -23.5, Balance: -13.5},
+		{Id: 3, AccountId: testAccountId, OperationTypeId: 2, Amount: -18.7, Balance: -18.7},
+		{Id: 4, AccountId: testAccountId, OperationTypeId: 4, Amount: 60, Balance: 0},
+		{Id: 5, AccountId: testAccountId, OperationTypeId: 1, Amount: -10, Balance: -10},
+	}
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, testAccountId).Return(&domain.Account{Id: testAccountId}, nil)
+	suite.mockTransactionRepository.EXPECT().GetAllTransactions(suite.context, testAccountId).Return(transactions, nil)
+
+	corrections, err := suite.transactionService.RecomputeBalances(suite.context, testAccountId)
+
+	suite.Nil(err)
+	suite.Empty(corrections)
+}
+
+func (suite *TransactionServiceTestSuite) TestCreateTransactions_All_Or_Nothing_Creates_In_Account_Order() {
+	requests := []models.TransactionRequest{
+		{AccountId: 2, OperationTypeId: 1, Amount: 10},
+		{AccountId: 1, OperationTypeId: 1, Amount: 5},
+	}
+
+	gomock.InOrder(
+		suite.mockAccountRepository.EXPECT().LockById(suite.context, int64(1)).Return(&domain.Account{Id: 1}, nil),
+		suite.mockTransactionRepository.EXPECT().Create(suite.context, domain.CreateTransactionParam{AccountId: 1, OperationTypeId: 1, Amount: -5, Balance: -5}).
+			Return(&domain.Transaction{Id: 1, AccountId: 1, OperationTypeId: 1}, nil),
+		suite.mockAccountRepository.EXPECT().LockById(suite.context, int64(2)).Return(&domain.Account{Id: 2}, nil),
+		suite.mockTransactionRepository.EXPECT().Create(suite.context, domain.CreateTransactionParam{AccountId: 2, OperationTypeId: 1, Amount: -10, Balance: -10}).
+			Return(&domain.Transaction{Id: 2, AccountId: 2, OperationTypeId: 1}, nil),
+	)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{}, nil).Times(2)
+	suite.mockRecorder.EXPECT().TransactionCreated(int64(1)).Times(2)
+
+	outcomes := suite.transactionService.CreateTransactions(suite.context, requests, true)
+
+	suite.Require().Len(outcomes, 2)
+	suite.Equal(domain.BatchItemCreated, outcomes[0].Status)
+	suite.Equal(int64(2), outcomes[0].Transaction.Id)
+	suite.Equal(domain.BatchItemCreated, outcomes[1].Status)
+	suite.Equal(int64(1), outcomes[1].Transaction.Id)
+}
+
+func (suite *TransactionServiceTestSuite) TestCreateTransactions_All_Or_Nothing_Rolls_Back_On_First_Failure() {
+	requests := []models.TransactionRequest{
+		{AccountId: 2, OperationTypeId: 1, Amount: 10},
+		{AccountId: 1, OperationTypeId: 1, Amount: 5},
+		{AccountId: 3, OperationTypeId: 1, Amount: 5},
+	}
+	blockedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, int64(1)).Return(&domain.Account{Id: 1}, nil)
+	suite.mockTransactionRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Transaction{Id: 1, AccountId: 1, OperationTypeId: 1}, nil)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{}, nil)
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, int64(2)).Return(&domain.Account{Id: 2, BlockedAt: &blockedAt}, nil)
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.AccountBlockedErrCode)
+
+	outcomes := suite.transactionService.CreateTransactions(suite.context, requests, true)
+
+	suite.Equal([]domain.BatchItemOutcome{
+		{Status: domain.BatchItemFailed, Err: domain.ErrAccountBlocked},
+		{Status: domain.BatchItemRolledBack},
+		{Status: domain.BatchItemSkipped},
+	}, outcomes)
+}
+
+func (suite *TransactionServiceTestSuite) TestCreateTransactions_Best_Effort_Keeps_Going_After_A_Failure() {
+	requests := []models.TransactionRequest{
+		{AccountId: 1, OperationTypeId: 9, Amount: 10},
+		{AccountId: 1, OperationTypeId: 1, Amount: 5},
+	}
+
+	suite.mockRecorder.EXPECT().TransactionRejected(constants.InvalidOperationTypeErrCode)
+	suite.mockAccountRepository.EXPECT().LockById(suite.context, int64(1)).Return(&domain.Account{Id: 1}, nil)
+	suite.mockTransactionRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.Transaction{Id: 1, AccountId: 1, OperationTypeId: 1}, nil)
+	suite.mockOutboxRepository.EXPECT().Create(suite.context, gomock.Any()).Return(&domain.OutboxEvent{}, nil)
+	suite.mockRecorder.EXPECT().TransactionCreated(int64(1))
+
+	outcomes := suite.transactionService.CreateTransactions(suite.context, requests, false)
+
+	suite.Require().Len(outcomes, 2)
+	suite.Equal(domain.BatchItemOutcome{Status: domain.BatchItemFailed, Err: domain.ErrInvalidOperationType}, outcomes[0])
+	suite.Equal(domain.BatchItemCreated, outcomes[1].Status)
+}
+
 func (suite *TransactionServiceTestSuite) TearDownTest() {
 	suite.mockController.Finish()
 }
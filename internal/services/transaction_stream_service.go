@@ -0,0 +1,41 @@
+package services
+
+//go:generate mockgen -source=transaction_stream_service.go -destination=mocks/mock_transaction_stream_service.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/repository"
+)
+
+const streamBatchSize = 100
+
+type TransactionStreamService interface {
+	// OpenStream checks the account exists and returns the id after which a fresh stream starts.
+	OpenStream(ctx context.Context, accountId int64) (int64, error)
+	EventsAfter(ctx context.Context, accountId int64, afterEventId int64) ([]domain.OutboxEvent, error)
+}
+
+type transactionStreamService struct {
+	accountRepository repository.AccountRepository
+	outboxRepository  repository.OutboxRepository
+}
+
+func NewTransactionStreamService(accountRepository repository.AccountRepository, outboxRepository repository.OutboxRepository) TransactionStreamService {
+	return &transactionStreamService{accountRepository: accountRepository, outboxRepository: outboxRepository}
+}
+
+func (ss *transactionStreamService) OpenStream(ctx context.Context, accountId int64) (int64, error) {
+	logging.FromContext(ctx).Infof("Started to open transaction stream for accountId: %d", accountId)
+	_, err := ss.accountRepository.GetById(ctx, accountId)
+	if err != nil {
+		return 0, err
+	}
+	return ss.outboxRepository.GetLatestEventId(ctx, accountId)
+}
+
+func (ss *transactionStreamService) EventsAfter(ctx context.Context, accountId int64, afterEventId int64) ([]domain.OutboxEvent, error) {
+	return ss.outboxRepository.ListByAccountAfter(ctx, accountId, afterEventId, domain.TransactionEventTypes, streamBatchSize)
+}
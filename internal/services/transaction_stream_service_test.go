@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TransactionStreamServiceTestSuite struct {
+	suite.Suite
+	context               context.Context
+	mockController        *gomock.Controller
+	mockAccountRepository *mocks.MockAccountRepository
+	mockOutboxRepository  *mocks.MockOutboxRepository
+	streamService         TransactionStreamService
+}
+
+func TestTransactionStreamServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionStreamServiceTestSuite))
+}
+
+func (suite *TransactionStreamServiceTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockAccountRepository = mocks.NewMockAccountRepository(suite.mockController)
+	suite.mockOutboxRepository = mocks.NewMockOutboxRepository(suite.mockController)
+	suite.streamService = NewTransactionStreamService(suite.mockAccountRepository, suite.mockOutboxRepository)
+}
+
+func (suite *TransactionStreamServiceTestSuite) TestOpenStream_Returns_Latest_EventId() {
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, int64(1)).Return(&domain.Account{Id: 1}, nil)
+	suite.mockOutboxRepository.EXPECT().GetLatestEventId(suite.context, int64(1)).Return(int64(42), nil)
+
+	latest, err := suite.streamService.OpenStream(suite.context, 1)
+
+	suite.Nil(err)
+	suite.Equal(int64(42), latest)
+}
+
+func (suite *TransactionStreamServiceTestSuite) TestOpenStream_When_Account_NotFound() {
+	suite.mockAccountRepository.EXPECT().GetById(suite.context, int64(1)).Return(nil, domain.ErrAccountNotFound)
+
+	_, err := suite.streamService.OpenStream(suite.context, 1)
+
+	suite.Equal(domain.ErrAccountNotFound, err)
+}
+
+func (suite *TransactionStreamServiceTestSuite) TestEventsAfter_Only_Requests_Transaction_Events() {
+	suite.mockOutboxRepository.EXPECT().ListByAccountAfter(suite.context, int64(1), int64(5), domain.TransactionEventTypes, int32(100)).Return(nil, nil)
+
+	events, err := suite.streamService.EventsAfter(suite.context, 1, 5)
+
+	suite.Nil(err)
+	suite.Empty(events)
+}
+
+func (suite *TransactionStreamServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
@@ -0,0 +1,173 @@
+package services
+
+//go:generate mockgen -source=webhook_service.go -destination=mocks/mock_webhook_service.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/logging"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository"
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+	webhookSecretBytes  = 32
+	deliveryListLimit   = 100
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, request models.CreateWebhookRequest) (*domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id int64, request models.UpdateWebhookRequest) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, id int64, status string) ([]domain.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	webhookRepository repository.WebhookRepository
+	transactor        repository.Transactor
+	auditService      AuditService
+}
+
+func NewWebhookService(webhookRepository repository.WebhookRepository, transactor repository.Transactor, auditService AuditService) WebhookService {
+	return &webhookService{webhookRepository: webhookRepository, transactor: transactor, auditService: auditService}
+}
+
+func (ws *webhookService) CreateSubscription(ctx context.Context, request models.CreateWebhookRequest) (*domain.WebhookSubscription, error) {
+	logging.FromContext(ctx).Infof("Started to create webhook subscription for url: %s", request.Url)
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		logging.FromContext(ctx).Errorf("error while generate webhook secret: %s", err.Error())
+		return nil, domain.ErrInternal
+	}
+
+	subscriptionParam := domain.CreateWebhookSubscriptionParam{
+		Url:        request.Url,
+		Secret:     secret,
+		EventTypes: request.EventTypes,
+	}
+
+	var subscription *domain.WebhookSubscription
+	err = ws.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var createErr error
+		subscription, createErr = ws.webhookRepository.CreateSubscription(txCtx, subscriptionParam)
+		if createErr != nil {
+			return createErr
+		}
+		return ws.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionWebhookCreate,
+			ResourceType: domain.AuditResourceWebhookSubscription,
+			ResourceId:   strconv.FormatInt(subscription.Id, 10),
+			After:        webhookSnapshot(*subscription),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (ws *webhookService) GetSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	logging.FromContext(ctx).Infof("Started to get webhook subscription by id: %d", id)
+	return ws.webhookRepository.GetSubscriptionById(ctx, id)
+}
+
+func (ws *webhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	logging.FromContext(ctx).Info("Started to list webhook subscriptions")
+	return ws.webhookRepository.ListSubscriptions(ctx)
+}
+
+func (ws *webhookService) UpdateSubscription(ctx context.Context, id int64, request models.UpdateWebhookRequest) (*domain.WebhookSubscription, error) {
+	logging.FromContext(ctx).Infof("Started to update webhook subscription by id: %d", id)
+	var updated *domain.WebhookSubscription
+	err := ws.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		subscription, getErr := ws.webhookRepository.GetSubscriptionById(txCtx, id)
+		if getErr != nil {
+			return getErr
+		}
+
+		subscriptionParam := domain.UpdateWebhookSubscriptionParam{
+			Id:         subscription.Id,
+			Url:        subscription.Url,
+			EventTypes: subscription.EventTypes,
+			Active:     subscription.Active,
+		}
+		if request.Url != nil {
+			subscriptionParam.Url = *request.Url
+		}
+		if len(request.EventTypes) > 0 {
+			subscriptionParam.EventTypes = request.EventTypes
+		}
+		if request.Active != nil {
+			subscriptionParam.Active = *request.Active
+		}
+
+		var updateErr error
+		updated, updateErr = ws.webhookRepository.UpdateSubscription(txCtx, subscriptionParam)
+		if updateErr != nil {
+			return updateErr
+		}
+		return ws.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionWebhookUpdate,
+			ResourceType: domain.AuditResourceWebhookSubscription,
+			ResourceId:   strconv.FormatInt(id, 10),
+			Before:       webhookSnapshot(*subscription),
+			After:        webhookSnapshot(*updated),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (ws *webhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	logging.FromContext(ctx).Infof("Started to delete webhook subscription by id: %d", id)
+	return ws.transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		subscription, getErr := ws.webhookRepository.GetSubscriptionById(txCtx, id)
+		if getErr != nil {
+			return getErr
+		}
+		if deleteErr := ws.webhookRepository.DeleteSubscription(txCtx, id); deleteErr != nil {
+			return deleteErr
+		}
+		return ws.auditService.Record(txCtx, domain.AuditRecord{
+			Action:       domain.AuditActionWebhookDelete,
+			ResourceType: domain.AuditResourceWebhookSubscription,
+			ResourceId:   strconv.FormatInt(id, 10),
+			Before:       webhookSnapshot(*subscription),
+		})
+	})
+}
+
+func (ws *webhookService) ListDeliveries(ctx context.Context, id int64, status string) ([]domain.WebhookDelivery, error) {
+	logging.FromContext(ctx).Infof("Started to list deliveries of webhook subscription id: %d", id)
+	_, err := ws.webhookRepository.GetSubscriptionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ws.webhookRepository.ListDeliveries(ctx, id, status, deliveryListLimit)
+}
+
+// webhookSnapshot is the audited state of a subscription; the signing secret is left out on purpose.
+func webhookSnapshot(subscription domain.WebhookSubscription) map[string]any {
+	return map[string]any{
+		"url":         subscription.Url,
+		"event_types": subscription.EventTypes,
+		"active":      subscription.Active,
+	}
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/repository/mocks"
+	serviceMocks "github.com/credit-card-api/internal/services/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	context               context.Context
+	mockController        *gomock.Controller
+	mockWebhookRepository *mocks.MockWebhookRepository
+	mockTransactor        *mocks.MockTransactor
+	mockAuditService      *serviceMocks.MockAuditService
+	webhookService        WebhookService
+}
+
+func TestWebhookServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}
+
+func (suite *WebhookServiceTestSuite) SetupTest() {
+	suite.context = context.TODO()
+	suite.mockController = gomock.NewController(suite.T())
+	suite.mockWebhookRepository = mocks.NewMockWebhookRepository(suite.mockController)
+	suite.mockTransactor = mocks.NewMockTransactor(suite.mockController)
+	suite.mockAuditService = serviceMocks.NewMockAuditService(suite.mockController)
+	suite.mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runWithinTransaction).AnyTimes()
+	suite.webhookService = NewWebhookService(suite.mockWebhookRepository, suite.mockTransactor, suite.mockAuditService)
+}
+
+func (suite *WebhookServiceTestSuite) TestCreateSubscription_Generates_Secret() {
+	request := models.CreateWebhookRequest{
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{domain.EventTransactionCreated},
+	}
+
+	suite.mockWebhookRepository.EXPECT().CreateSubscription(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, param domain.CreateWebhookSubscriptionParam) (*domain.WebhookSubscription, error) {
+			suite.Equal(request.Url, param.Url)
+			suite.Equal(request.EventTypes, param.EventTypes)
+			suite.True(strings.HasPrefix(param.Secret, "whsec_"))
+			suite.Len(param.Secret, len("whsec_")+64)
+			return &domain.WebhookSubscription{Id: 1, Url: param.Url, Secret: param.Secret, EventTypes: param.EventTypes, Active: true}, nil
+		})
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, record domain.AuditRecord) error {
+			suite.Equal(domain.AuditActionWebhookCreate, record.Action)
+			suite.Equal("1", record.ResourceId)
+			suite.NotContains(record.After, "secret")
+			return nil
+		})
+
+	response, err := suite.webhookService.CreateSubscription(suite.context, request)
+
+	suite.Nil(err)
+	suite.Equal(int64(1), response.Id)
+}
+
+func (suite *WebhookServiceTestSuite) TestUpdateSubscription_Keeps_Unchanged_Fields() {
+	active := false
+	existing := &domain.WebhookSubscription{
+		Id:         1,
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{domain.EventAccountCreated},
+		Active:     true,
+	}
+	expectedParam := domain.UpdateWebhookSubscriptionParam{
+		Id:         1,
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{domain.EventAccountCreated},
+		Active:     false,
+	}
+
+	suite.mockWebhookRepository.EXPECT().GetSubscriptionById(suite.context, int64(1)).Return(existing, nil)
+	suite.mockWebhookRepository.EXPECT().UpdateSubscription(suite.context, expectedParam).Return(&domain.WebhookSubscription{Id: 1}, nil)
+	suite.mockAuditService.EXPECT().Record(suite.context, gomock.Any()).
+		DoAndReturn(func(_ context.Context, record domain.AuditRecord) error {
+			suite.Equal(domain.AuditActionWebhookUpdate, record.Action)
+			suite.Equal(true, record.Before.(map[string]any)["active"])
+			return nil
+		})
+
+	response, err := suite.webhookService.UpdateSubscription(suite.context, 1, models.UpdateWebhookRequest{Active: &active})
+
+	suite.Nil(err)
+	suite.Equal(int64(1), response.Id)
+}
+
+func (suite *WebhookServiceTestSuite) TestUpdateSubscription_When_NotFound_Returns_Error() {
+	suite.mockWebhookRepository.EXPECT().GetSubscriptionById(suite.context, int64(404)).Return(nil, domain.ErrWebhookNotFound)
+
+	response, err := suite.webhookService.UpdateSubscription(suite.context, 404, models.UpdateWebhookRequest{})
+
+	suite.Nil(response)
+	suite.Equal(domain.ErrWebhookNotFound, err)
+}
+
+func (suite *WebhookServiceTestSuite) TestDeleteSubscription_Records_Previous_State() {
+	existing := &domain.WebhookSubscription{Id: 1, Url: "https://example.com/hooks", Active: true}
+
+	suite.mockWebhookRepository.EXPECT().GetSubscriptionById(suite.context, int64(1)).Return(existing, nil)
+	suite.mockWebhookRepository.EXPECT().DeleteSubscription(suite.context, int64(1)).Return(nil)
+	suite.mockAuditService.EXPECT().Record(suite.context, domain.AuditRecord{
+		Action:       domain.AuditActionWebhookDelete,
+		ResourceType: domain.AuditResourceWebhookSubscription,
+		ResourceId:   "1",
+		Before:       webhookSnapshot(*existing),
+	}).Return(nil)
+
+	err := suite.webhookService.DeleteSubscription(suite.context, 1)
+
+	suite.Nil(err)
+}
+
+func (suite *WebhookServiceTestSuite) TestListDeliveries_When_Subscription_NotFound_Returns_Error() {
+	suite.mockWebhookRepository.EXPECT().GetSubscriptionById(suite.context, int64(404)).Return(nil, domain.ErrWebhookNotFound)
+
+	response, err := suite.webhookService.ListDeliveries(suite.context, 404, domain.DeliveryStatusDead)
+
+	suite.Nil(response)
+	suite.Equal(domain.ErrWebhookNotFound, err)
+}
+
+func (suite *WebhookServiceTestSuite) TestListDeliveries_Success() {
+	deliveries := []domain.WebhookDelivery{{Id: 1, Status: domain.DeliveryStatusDead}}
+
+	suite.mockWebhookRepository.EXPECT().GetSubscriptionById(suite.context, int64(1)).Return(&domain.WebhookSubscription{Id: 1}, nil)
+	suite.mockWebhookRepository.EXPECT().ListDeliveries(suite.context, int64(1), domain.DeliveryStatusDead, int32(100)).Return(deliveries, nil)
+
+	response, err := suite.webhookService.ListDeliveries(suite.context, 1, domain.DeliveryStatusDead)
+
+	suite.Nil(err)
+	suite.Equal(deliveries, response)
+}
+
+func (suite *WebhookServiceTestSuite) TearDownTest() {
+	suite.mockController.Finish()
+}
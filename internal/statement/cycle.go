@@ -0,0 +1,43 @@
+package statement
+
+import (
+	"math"
+	"time"
+)
+
+// A billing cycle is a calendar month in UTC. The statement of a cycle is identified by its month as YYYYMM, 202609
+// for September 2026, so every account has the same ids for the same cycles.
+
+// Config holds the rules the statement of a closed cycle is built with.
+type Config struct {
+	// Currency is the ISO 4217 currency of the amounts.
+	Currency string `yaml:"currency" validate:"iso4217"`
+	// DueDays is how many days after the closing date of a cycle its payment is due.
+	DueDays int `yaml:"due_days" validate:"gte=1"`
+	// MinimumPaymentRate is the share of the amount owed at closing that the minimum payment asks for.
+	MinimumPaymentRate float64 `yaml:"minimum_payment_rate" validate:"gt=0,lte=1"`
+}
+
+func DefaultConfig() Config {
+	return Config{Currency: "BRL", DueDays: 10, MinimumPaymentRate: 0.15}
+}
+
+// Cycle returns the first instant of the cycle of statement id and its closing date, the first instant it no longer
+// covers. ok is false when id is not a month.
+func Cycle(id int64) (start time.Time, end time.Time, ok bool) {
+	year, month := id/100, id%100
+	if year < 1 || year > 9999 || month < 1 || month > 12 {
+		return time.Time{}, time.Time{}, false
+	}
+	start = time.Date(int(year), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0), true
+}
+
+// MinimumPayment is rate of what the transactions of a cycle still owe, rounded to cents. Nothing is due when they
+// owe nothing.
+func MinimumPayment(totals Totals, rate float64) float64 {
+	if totals.Outstanding >= 0 {
+		return 0
+	}
+	return math.Round(-totals.Outstanding*rate*100) / 100
+}
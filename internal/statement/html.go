@@ -0,0 +1,30 @@
+package statement
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"time"
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+var htmlTemplate = template.Must(template.New("statement.html").Funcs(template.FuncMap{
+	"date":  formatDate,
+	"money": formatMoney,
+	"datetime": func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	},
+}).ParseFS(templates, "templates/statement.html"))
+
+// htmlView is the data of the template, the document with its totals computed once.
+type htmlView struct {
+	*Document
+	Totals Totals
+}
+
+// RenderHTML writes the statement as a standalone HTML page; html/template escapes every value it prints.
+func RenderHTML(w io.Writer, document *Document) error {
+	return htmlTemplate.Execute(w, htmlView{Document: document, Totals: document.Totals()})
+}
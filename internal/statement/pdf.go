@@ -0,0 +1,221 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// The PDF is A4 in points, with the same margin on every side.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+)
+
+// Fonts are the standard Type 1 fonts every PDF reader has, so none is embedded. Courier prints the transactions,
+// its fixed width lines the amounts up without font metrics.
+const (
+	pdfFontRegular   = "F1"
+	pdfFontBold      = "F2"
+	pdfFontFixed     = "F3"
+	pdfFontFixedBold = "F4"
+)
+
+var pdfFonts = []struct{ name, baseFont string }{
+	{pdfFontRegular, "Helvetica"},
+	{pdfFontBold, "Helvetica-Bold"},
+	{pdfFontFixed, "Courier"},
+	{pdfFontFixedBold, "Courier-Bold"},
+}
+
+// pdfRowFormat lays out a transaction row in Courier: date, description, amount and balance.
+const (
+	pdfRowFormat         = "%-10s  %-38s %15s %15s"
+	pdfDescriptionLength = 38
+)
+
+// pdfText is a line of text placed on a page, x and y from the bottom left corner.
+type pdfText struct {
+	font string
+	size float64
+	x, y float64
+	text string
+}
+
+// pdfLayout places lines top to bottom, starting a new page when the current one is full.
+type pdfLayout struct {
+	pages [][]pdfText
+	y     float64
+	// pageHeader is printed at the top of every page after the first.
+	pageHeader func(layout *pdfLayout)
+}
+
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, nil)
+	l.y = pdfPageHeight - pdfMargin
+	if len(l.pages) > 1 && l.pageHeader != nil {
+		l.pageHeader(l)
+	}
+}
+
+// line prints text below the previous line, leading points apart.
+func (l *pdfLayout) line(font string, size, leading float64, text string) {
+	if len(l.pages) == 0 || l.y-leading < pdfMargin {
+		l.newPage()
+	}
+	l.y -= leading
+	page := len(l.pages) - 1
+	l.pages[page] = append(l.pages[page], pdfText{font: font, size: size, x: pdfMargin, y: l.y, text: text})
+}
+
+func (l *pdfLayout) space(points float64) {
+	l.y -= points
+}
+
+// RenderPDF writes the statement as a PDF 1.4 document. The output depends only on document, streams are left
+// uncompressed and the creation date is GeneratedAt, so the same statement always renders the same bytes.
+func RenderPDF(w io.Writer, document *Document) error {
+	layout := layoutPDF(document)
+	_, err := w.Write(writePDF(layout.pages, document))
+	return err
+}
+
+func layoutPDF(document *Document) *pdfLayout {
+	tableHeader := func(l *pdfLayout) {
+		l.line(pdfFontFixedBold, 9, 14, fmt.Sprintf(pdfRowFormat, "Date", "Description", "Amount", "Balance"))
+	}
+	layout := &pdfLayout{pageHeader: func(l *pdfLayout) {
+		l.line(pdfFontRegular, 9, 12, fmt.Sprintf("Account %d, statement %d, continued", document.AccountId, document.Id))
+		l.space(6)
+		tableHeader(l)
+	}}
+
+	layout.line(pdfFontBold, 18, 22, "Credit card statement")
+	layout.space(8)
+	layout.line(pdfFontRegular, 11, 15, fmt.Sprintf("Account: %d", document.AccountId))
+	if document.HolderName != "" {
+		layout.line(pdfFontRegular, 11, 15, "Holder: "+document.HolderName)
+	}
+	layout.line(pdfFontRegular, 11, 15, fmt.Sprintf("Statement: %d", document.Id))
+	layout.line(pdfFontRegular, 11, 15, fmt.Sprintf("Period: %s to %s", formatDate(document.PeriodStart), formatDate(document.PeriodEnd)))
+	layout.line(pdfFontBold, 11, 15, "Minimum payment: "+formatMoney(document.Currency, document.MinimumPayment))
+	layout.line(pdfFontBold, 11, 15, "Due date: "+formatDate(document.DueDate))
+	layout.space(12)
+
+	layout.line(pdfFontBold, 13, 18, "Transactions")
+	tableHeader(layout)
+	for _, transaction := range document.Transactions {
+		layout.line(pdfFontFixed, 9, 12, fmt.Sprintf(pdfRowFormat,
+			formatDate(transaction.CreatedAt),
+			truncate(transaction.OperationDescription, pdfDescriptionLength),
+			formatMoney(document.Currency, transaction.Amount),
+			formatMoney(document.Currency, transaction.Balance)))
+	}
+	if len(document.Transactions) == 0 {
+		layout.line(pdfFontFixed, 9, 12, "No transactions in this cycle.")
+	}
+
+	totals := document.Totals()
+	layout.space(6)
+	layout.line(pdfFontFixedBold, 9, 12, fmt.Sprintf(pdfRowFormat, "", "Debits", formatMoney(document.Currency, totals.Debits), ""))
+	layout.line(pdfFontFixedBold, 9, 12, fmt.Sprintf(pdfRowFormat, "", "Credits", formatMoney(document.Currency, totals.Credits), ""))
+	layout.line(pdfFontFixedBold, 9, 12, fmt.Sprintf(pdfRowFormat, "", "Outstanding", "", formatMoney(document.Currency, totals.Outstanding)))
+	layout.space(12)
+	layout.line(pdfFontRegular, 8, 10, "Generated "+document.GeneratedAt.UTC().Format(time.RFC3339))
+	return layout
+}
+
+// writePDF assembles the document: catalog, page tree, fonts, then a page and its content stream for every page,
+// followed by the cross-reference table whose offsets readers seek by.
+func writePDF(pages [][]pdfText, document *Document) []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1 to 4 are fixed: catalog, page tree, info and the font resources; fonts follow from 5.
+	const firstFont = 5
+	firstPage := firstFont + len(pdfFonts)
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object(fmt.Sprintf("<< /Title %s /Producer (credit-card-api) /CreationDate (D:%s) >>",
+		pdfString(fmt.Sprintf("Statement %d of account %d", document.Id, document.AccountId)),
+		document.GeneratedAt.UTC().Format("20060102150405Z")))
+	fonts := make([]string, len(pdfFonts))
+	for i, font := range pdfFonts {
+		fonts[i] = fmt.Sprintf("/%s %d 0 R", font.name, firstFont+i)
+	}
+	object(fmt.Sprintf("<< /Font << %s >> >>", strings.Join(fonts, " ")))
+	for _, font := range pdfFonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.baseFont))
+	}
+
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources 4 0 R /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPage+2*i+1))
+		content := pageContent(page, i+1, len(pages))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pageContent draws the lines of a page and its number at the bottom.
+func pageContent(texts []pdfText, number, count int) string {
+	var content strings.Builder
+	footer := pdfText{font: pdfFontRegular, size: 8, x: pdfPageWidth - pdfMargin - 50, y: pdfMargin / 2, text: fmt.Sprintf("Page %d of %d", number, count)}
+	for _, text := range append(texts, footer) {
+		fmt.Fprintf(&content, "BT /%s %g Tf %g %g Td %s Tj ET\n", text.font, text.size, text.x, text.y, pdfString(text.text))
+	}
+	return content.String()
+}
+
+// pdfString is a literal string in WinAnsiEncoding. Latin-1 characters keep their code, which covers the accents of
+// Portuguese names; anything else becomes a question mark.
+func pdfString(value string) string {
+	var out strings.Builder
+	out.WriteByte('(')
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r < 0x20:
+			out.WriteByte(' ')
+		case r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			out.WriteByte(byte(r))
+		default:
+			out.WriteByte('?')
+		}
+	}
+	out.WriteByte(')')
+	return out.String()
+}
+
+// truncate cuts value to length characters, marking the cut with an ellipsis.
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length-3]) + "..."
+}
@@ -0,0 +1,65 @@
+// Package statement renders the statement of a billing cycle as the document customers receive: HTML from a Go
+// template, or PDF written in pure Go so that no external binary is needed to produce it. Statements are not stored:
+// the document of a closed cycle is built from the transactions created within it whenever it is asked for.
+package statement
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+)
+
+// dateLayout is how every date of a statement is printed.
+const dateLayout = "2006-01-02"
+
+// Document is what a statement shows: the header, the transactions of the cycle oldest first, the minimum payment
+// and the due date. Totals are computed from the transactions.
+type Document struct {
+	Id          int64
+	AccountId   int64
+	HolderName  string
+	Currency    string
+	PeriodStart time.Time
+	// PeriodEnd is the closing date of the cycle, the first instant it no longer covers.
+	PeriodEnd      time.Time
+	DueDate        time.Time
+	MinimumPayment float64
+	Transactions   []domain.ExportedTransaction
+	GeneratedAt    time.Time
+}
+
+// Totals sums the transactions of a cycle. Debits and Credits are the sums of the negative and positive amounts,
+// Outstanding is the sum of their balances, which is what is still open of them.
+type Totals struct {
+	Debits      float64
+	Credits     float64
+	Outstanding float64
+}
+
+func (d *Document) Totals() Totals {
+	var totals Totals
+	for _, transaction := range d.Transactions {
+		if transaction.Amount < 0 {
+			totals.Debits += transaction.Amount
+		} else {
+			totals.Credits += transaction.Amount
+		}
+		totals.Outstanding += transaction.Balance
+	}
+	return totals
+}
+
+// FileName is the name the statement is downloaded as, with the extension of its format.
+func (d *Document) FileName(extension string) string {
+	return fmt.Sprintf("account-%d-statement-%d.%s", d.AccountId, d.Id, extension)
+}
+
+func formatDate(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
+
+// formatMoney prints an amount with two decimals after its currency, "BRL -12.50".
+func formatMoney(currency string, amount float64) string {
+	return fmt.Sprintf("%s %.2f", currency, amount)
+}
@@ -0,0 +1,143 @@
+package statement
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files with the current output: go test ./internal/statement -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func testDocument() *Document {
+	return &Document{
+		Id:             3,
+		AccountId:      7,
+		HolderName:     "João <Silva> & Filhos",
+		Currency:       "BRL",
+		PeriodStart:    time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:        time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+		MinimumPayment: 25.75,
+		GeneratedAt:    time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC),
+		Transactions: []domain.ExportedTransaction{
+			{
+				Transaction:          domain.Transaction{Id: 1, AccountId: 7, OperationTypeId: 1, Amount: -50, Balance: 0, CreatedAt: time.Date(2026, 9, 3, 14, 5, 0, 0, time.UTC)},
+				OperationDescription: "Normal Purchase",
+			},
+			{
+				Transaction:          domain.Transaction{Id: 2, AccountId: 7, OperationTypeId: 4, Amount: 60, Balance: 10, CreatedAt: time.Date(2026, 9, 10, 9, 30, 0, 0, time.UTC)},
+				OperationDescription: "Credit Voucher",
+			},
+			{
+				Transaction:          domain.Transaction{Id: 3, AccountId: 7, OperationTypeId: 3, Amount: -12.5, Balance: -12.5, CreatedAt: time.Date(2026, 9, 21, 18, 0, 0, 0, time.UTC)},
+				OperationDescription: "Withdrawal (ATM) at the airport terminal, fees included",
+			},
+		},
+	}
+}
+
+func TestRender_Golden(t *testing.T) {
+	renderers := map[string]func(*bytes.Buffer, *Document) error{
+		"html": func(out *bytes.Buffer, document *Document) error { return RenderHTML(out, document) },
+		"pdf":  func(out *bytes.Buffer, document *Document) error { return RenderPDF(out, document) },
+	}
+
+	for extension, render := range renderers {
+		t.Run(extension, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, render(&out, testDocument()))
+
+			golden := filepath.Join("testdata", "statement."+extension)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, out.Bytes(), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+func TestDocument_Totals(t *testing.T) {
+	totals := testDocument().Totals()
+
+	assert.Equal(t, Totals{Debits: -62.5, Credits: 60, Outstanding: -2.5}, totals)
+}
+
+func TestRenderHTML_Escapes_Values(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, RenderHTML(&out, testDocument()))
+
+	assert.Contains(t, out.String(), "João &lt;Silva&gt; &amp; Filhos")
+	assert.NotContains(t, out.String(), "<Silva>")
+}
+
+// TestRenderPDF_Cross_Reference checks every offset of the xref table points at the object it lists, which is how
+// readers find the objects of the document.
+func TestRenderPDF_Cross_Reference(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, RenderPDF(&out, testDocument()))
+	document := out.Bytes()
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(document)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(document[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(document[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(document[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestRenderPDF_Long_Cycle_Spans_Pages(t *testing.T) {
+	document := testDocument()
+	for i := 0; i < 120; i++ {
+		document.Transactions = append(document.Transactions, document.Transactions[0])
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, RenderPDF(&out, document))
+
+	assert.Contains(t, out.String(), "/Count 3 >>")
+	assert.Contains(t, out.String(), "(Page 3 of 3)")
+	assert.Equal(t, 2, bytes.Count(out.Bytes(), []byte("statement 3, continued")))
+}
+
+func TestRenderPDF_Strings(t *testing.T) {
+	assert.Equal(t, `(a \(b\) \\ c)`, pdfString(`a (b) \ c`))
+	assert.Equal(t, "(Jo\xe3o ?)", pdfString("João €"))
+	assert.Equal(t, "Withdrawal...", truncate("Withdrawal (ATM)", 13))
+}
+
+func TestCycle(t *testing.T) {
+	start, end, ok := Cycle(202612)
+	_, _, monthOk := Cycle(202613)
+	_, _, yearOk := Cycle(12)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	assert.False(t, monthOk)
+	assert.False(t, yearOk)
+}
+
+func TestMinimumPayment(t *testing.T) {
+	assert.Equal(t, 15.38, MinimumPayment(Totals{Outstanding: -102.5}, 0.15))
+	assert.Equal(t, 0.0, MinimumPayment(Totals{Outstanding: 10}, 0.15))
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var errServerError = errors.New("server error")
+
+// Middleware opens a server span per request, continuing the trace of an incoming traceparent header.
+// The span is named after the route template and carried by the request context.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parentCtx := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		name := ctx.Request.Method
+		if route != "" {
+			name = fmt.Sprintf("%s %s", ctx.Request.Method, route)
+		}
+		spanCtx, span := start(parentCtx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+			),
+		)
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		var err error
+		if status >= http.StatusInternalServerError {
+			err = errServerError
+		}
+		end(span, err)
+	}
+}
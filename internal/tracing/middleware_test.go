@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/credit-card-api/internal/tracing/tracingtest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware_Continues_Incoming_Trace(t *testing.T) {
+	exporter := tracingtest.Install(t)
+	router := gin.New()
+	router.Use(Middleware())
+	var handlerSpan trace.SpanContext
+	router.GET("/accounts/:accountId", func(ctx *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(ctx.Request.Context())
+		ctx.Status(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/accounts/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /accounts/:accountId", spans[0].Name)
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	assert.Equal(t, spans[0].SpanContext.SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+}
+
+func TestMiddleware_Marks_Server_Errors(t *testing.T) {
+	exporter := tracingtest.Install(t)
+	router := gin.New()
+	router.Use(Middleware())
+	router.POST("/transactions", func(ctx *gin.Context) { ctx.Status(http.StatusInternalServerError) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/transactions", nil))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
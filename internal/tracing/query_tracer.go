@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// querySpanKey marks the span opened by TraceQueryStart, so TraceQueryEnd never ends the caller's span.
+type querySpanKey struct{}
+
+// QueryTracer is a pgx.QueryTracer opening a client span per SQL statement, named after the sqlc query.
+// Statements outside a trace, like the webhook dispatcher polls, are not traced so they do not flood the
+// exporter with root spans. Arguments are not recorded, they may carry personal data.
+type QueryTracer struct{}
+
+var _ pgx.QueryTracer = QueryTracer{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	name := statementName(data.SQL)
+	ctx, span := start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if span, ok := ctx.Value(querySpanKey{}).(trace.Span); ok {
+		end(span, data.Err)
+	}
+}
+
+// statementName is the sqlc query name, "-- name: <Name> :<kind>", or the first keyword of other statements.
+func statementName(sql string) string {
+	if name, ok := metrics.QueryName(sql); ok {
+		return name
+	}
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/credit-card-api/internal/tracing/tracingtest"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestQueryTracer_Opens_Span_Per_Statement(t *testing.T) {
+	exporter := tracingtest.Install(t)
+	tracer := QueryTracer{}
+	parentCtx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+
+	ctx := tracer.TraceQueryStart(parentCtx, nil, pgx.TraceQueryStartData{SQL: "-- name: GetAccountByID :one\nSELECT 1", Args: []any{"12345678900"}})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("no rows in result set")})
+	ctx = tracer.TraceQueryStart(parentCtx, nil, pgx.TraceQueryStartData{SQL: "begin"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "GetAccountByID", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	for _, attr := range spans[0].Attributes {
+		assert.NotContains(t, attr.Value.Emit(), "12345678900")
+	}
+	assert.Equal(t, "BEGIN", spans[1].Name)
+}
+
+func TestQueryTracer_Skips_Statements_Outside_A_Trace(t *testing.T) {
+	exporter := tracingtest.Install(t)
+	tracer := QueryTracer{}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "-- name: ListUndispatchedEvents :many\nSELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	assert.Empty(t, exporter.GetSpans())
+}
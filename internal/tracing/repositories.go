@@ -0,0 +1,249 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/repository"
+)
+
+type accountRepository struct {
+	next repository.AccountRepository
+}
+
+// WrapAccountRepository opens a span around every AccountRepository call.
+func WrapAccountRepository(next repository.AccountRepository) repository.AccountRepository {
+	return &accountRepository{next: next}
+}
+
+func (ar *accountRepository) Create(ctx context.Context, accountParam domain.CreateAccountParam) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountRepository.Create")
+	defer func() { end(span, err) }()
+	return ar.next.Create(ctx, accountParam)
+}
+
+func (ar *accountRepository) GetById(ctx context.Context, id int64) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountRepository.GetById", idAttribute("account.id", id))
+	defer func() { end(span, err) }()
+	return ar.next.GetById(ctx, id)
+}
+
+func (ar *accountRepository) LockById(ctx context.Context, id int64) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountRepository.LockById", idAttribute("account.id", id))
+	defer func() { end(span, err) }()
+	return ar.next.LockById(ctx, id)
+}
+
+func (ar *accountRepository) Block(ctx context.Context, id int64) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountRepository.Block", idAttribute("account.id", id))
+	defer func() { end(span, err) }()
+	return ar.next.Block(ctx, id)
+}
+
+func (ar *accountRepository) UpdateProfile(ctx context.Context, profileParam domain.UpdateAccountProfileParam) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountRepository.UpdateProfile", idAttribute("account.id", profileParam.Id))
+	defer func() { end(span, err) }()
+	return ar.next.UpdateProfile(ctx, profileParam)
+}
+
+type transactionRepository struct {
+	next repository.TransactionRepository
+}
+
+// WrapTransactionRepository opens a span around every TransactionRepository call.
+func WrapTransactionRepository(next repository.TransactionRepository) repository.TransactionRepository {
+	return &transactionRepository{next: next}
+}
+
+func (tr *transactionRepository) Create(ctx context.Context, transactionParam domain.CreateTransactionParam) (transaction *domain.Transaction, err error) {
+	ctx, span := start(ctx, "TransactionRepository.Create", idAttribute("account.id", transactionParam.AccountId))
+	defer func() { end(span, err) }()
+	return tr.next.Create(ctx, transactionParam)
+}
+
+func (tr *transactionRepository) GetAllTransactions(ctx context.Context, accountId int64) (transactions []domain.Transaction, err error) {
+	ctx, span := start(ctx, "TransactionRepository.GetAllTransactions", idAttribute("account.id", accountId))
+	defer func() { end(span, err) }()
+	return tr.next.GetAllTransactions(ctx, accountId)
+}
+
+func (tr *transactionRepository) UpdateTransactionById(ctx context.Context, transactionId int64, balance float64) (err error) {
+	ctx, span := start(ctx, "TransactionRepository.UpdateTransactionById", idAttribute("transaction.id", transactionId))
+	defer func() { end(span, err) }()
+	return tr.next.UpdateTransactionById(ctx, transactionId, balance)
+}
+
+func (tr *transactionRepository) ExportTransactions(ctx context.Context, filter domain.TransactionExportFilter, fn func(domain.ExportedTransaction) error) (err error) {
+	ctx, span := start(ctx, "TransactionRepository.ExportTransactions", idAttribute("account.id", filter.AccountId))
+	defer func() { end(span, err) }()
+	return tr.next.ExportTransactions(ctx, filter, fn)
+}
+
+type outboxRepository struct {
+	next repository.OutboxRepository
+}
+
+// WrapOutboxRepository opens a span around every OutboxRepository call.
+func WrapOutboxRepository(next repository.OutboxRepository) repository.OutboxRepository {
+	return &outboxRepository{next: next}
+}
+
+func (or *outboxRepository) Create(ctx context.Context, eventParam domain.CreateOutboxEventParam) (event *domain.OutboxEvent, err error) {
+	ctx, span := start(ctx, "OutboxRepository.Create")
+	defer func() { end(span, err) }()
+	return or.next.Create(ctx, eventParam)
+}
+
+func (or *outboxRepository) ListUndispatched(ctx context.Context, limit int32) (events []domain.OutboxEvent, err error) {
+	ctx, span := start(ctx, "OutboxRepository.ListUndispatched")
+	defer func() { end(span, err) }()
+	return or.next.ListUndispatched(ctx, limit)
+}
+
+func (or *outboxRepository) MarkDispatched(ctx context.Context, eventId int64) (err error) {
+	ctx, span := start(ctx, "OutboxRepository.MarkDispatched", idAttribute("event.id", eventId))
+	defer func() { end(span, err) }()
+	return or.next.MarkDispatched(ctx, eventId)
+}
+
+func (or *outboxRepository) ListByAccountAfter(ctx context.Context, accountId int64, afterEventId int64, eventTypes []string, limit int32) (events []domain.OutboxEvent, err error) {
+	ctx, span := start(ctx, "OutboxRepository.ListByAccountAfter", idAttribute("account.id", accountId))
+	defer func() { end(span, err) }()
+	return or.next.ListByAccountAfter(ctx, accountId, afterEventId, eventTypes, limit)
+}
+
+func (or *outboxRepository) GetLatestEventId(ctx context.Context, accountId int64) (eventId int64, err error) {
+	ctx, span := start(ctx, "OutboxRepository.GetLatestEventId", idAttribute("account.id", accountId))
+	defer func() { end(span, err) }()
+	return or.next.GetLatestEventId(ctx, accountId)
+}
+
+type auditRepository struct {
+	next repository.AuditRepository
+}
+
+// WrapAuditRepository opens a span around every AuditRepository call.
+func WrapAuditRepository(next repository.AuditRepository) repository.AuditRepository {
+	return &auditRepository{next: next}
+}
+
+func (ar *auditRepository) LockChainHead(ctx context.Context) (hash string, err error) {
+	ctx, span := start(ctx, "AuditRepository.LockChainHead")
+	defer func() { end(span, err) }()
+	return ar.next.LockChainHead(ctx)
+}
+
+func (ar *auditRepository) Create(ctx context.Context, entry domain.AuditEntry) (created *domain.AuditEntry, err error) {
+	ctx, span := start(ctx, "AuditRepository.Create")
+	defer func() { end(span, err) }()
+	return ar.next.Create(ctx, entry)
+}
+
+func (ar *auditRepository) List(ctx context.Context, filter domain.AuditFilter) (entries []domain.AuditEntry, err error) {
+	ctx, span := start(ctx, "AuditRepository.List")
+	defer func() { end(span, err) }()
+	return ar.next.List(ctx, filter)
+}
+
+type apiKeyRepository struct {
+	next repository.ApiKeyRepository
+}
+
+// WrapApiKeyRepository opens a span around every ApiKeyRepository call.
+func WrapApiKeyRepository(next repository.ApiKeyRepository) repository.ApiKeyRepository {
+	return &apiKeyRepository{next: next}
+}
+
+func (ar *apiKeyRepository) Create(ctx context.Context, apiKeyParam domain.CreateApiKeyParam) (apiKey *domain.ApiKey, err error) {
+	ctx, span := start(ctx, "ApiKeyRepository.Create")
+	defer func() { end(span, err) }()
+	return ar.next.Create(ctx, apiKeyParam)
+}
+
+func (ar *apiKeyRepository) GetActiveByHash(ctx context.Context, hash string) (apiKey *domain.ApiKey, err error) {
+	ctx, span := start(ctx, "ApiKeyRepository.GetActiveByHash")
+	defer func() { end(span, err) }()
+	return ar.next.GetActiveByHash(ctx, hash)
+}
+
+func (ar *apiKeyRepository) List(ctx context.Context) (apiKeys []domain.ApiKey, err error) {
+	ctx, span := start(ctx, "ApiKeyRepository.List")
+	defer func() { end(span, err) }()
+	return ar.next.List(ctx)
+}
+
+func (ar *apiKeyRepository) Revoke(ctx context.Context, id int64) (apiKey *domain.ApiKey, err error) {
+	ctx, span := start(ctx, "ApiKeyRepository.Revoke", idAttribute("api_key.id", id))
+	defer func() { end(span, err) }()
+	return ar.next.Revoke(ctx, id)
+}
+
+type webhookRepository struct {
+	next repository.WebhookRepository
+}
+
+// WrapWebhookRepository opens a span around every WebhookRepository call.
+func WrapWebhookRepository(next repository.WebhookRepository) repository.WebhookRepository {
+	return &webhookRepository{next: next}
+}
+
+func (wr *webhookRepository) CreateSubscription(ctx context.Context, subscriptionParam domain.CreateWebhookSubscriptionParam) (subscription *domain.WebhookSubscription, err error) {
+	ctx, span := start(ctx, "WebhookRepository.CreateSubscription")
+	defer func() { end(span, err) }()
+	return wr.next.CreateSubscription(ctx, subscriptionParam)
+}
+
+func (wr *webhookRepository) GetSubscriptionById(ctx context.Context, id int64) (subscription *domain.WebhookSubscription, err error) {
+	ctx, span := start(ctx, "WebhookRepository.GetSubscriptionById", idAttribute("webhook.id", id))
+	defer func() { end(span, err) }()
+	return wr.next.GetSubscriptionById(ctx, id)
+}
+
+func (wr *webhookRepository) ListSubscriptions(ctx context.Context) (subscriptions []domain.WebhookSubscription, err error) {
+	ctx, span := start(ctx, "WebhookRepository.ListSubscriptions")
+	defer func() { end(span, err) }()
+	return wr.next.ListSubscriptions(ctx)
+}
+
+func (wr *webhookRepository) UpdateSubscription(ctx context.Context, subscriptionParam domain.UpdateWebhookSubscriptionParam) (subscription *domain.WebhookSubscription, err error) {
+	ctx, span := start(ctx, "WebhookRepository.UpdateSubscription", idAttribute("webhook.id", subscriptionParam.Id))
+	defer func() { end(span, err) }()
+	return wr.next.UpdateSubscription(ctx, subscriptionParam)
+}
+
+func (wr *webhookRepository) DeleteSubscription(ctx context.Context, id int64) (err error) {
+	ctx, span := start(ctx, "WebhookRepository.DeleteSubscription", idAttribute("webhook.id", id))
+	defer func() { end(span, err) }()
+	return wr.next.DeleteSubscription(ctx, id)
+}
+
+func (wr *webhookRepository) CreateDeliveries(ctx context.Context, eventId int64, eventType string) (count int64, err error) {
+	ctx, span := start(ctx, "WebhookRepository.CreateDeliveries", idAttribute("event.id", eventId))
+	defer func() { end(span, err) }()
+	return wr.next.CreateDeliveries(ctx, eventId, eventType)
+}
+
+func (wr *webhookRepository) ClaimDueDeliveries(ctx context.Context, lease time.Duration, batchSize int32) (deliveries []domain.PendingDelivery, err error) {
+	ctx, span := start(ctx, "WebhookRepository.ClaimDueDeliveries")
+	defer func() { end(span, err) }()
+	return wr.next.ClaimDueDeliveries(ctx, lease, batchSize)
+}
+
+func (wr *webhookRepository) MarkDeliverySucceeded(ctx context.Context, deliveryId int64, statusCode int32) (err error) {
+	ctx, span := start(ctx, "WebhookRepository.MarkDeliverySucceeded", idAttribute("delivery.id", deliveryId))
+	defer func() { end(span, err) }()
+	return wr.next.MarkDeliverySucceeded(ctx, deliveryId, statusCode)
+}
+
+func (wr *webhookRepository) MarkDeliveryFailed(ctx context.Context, failureParam domain.DeliveryFailureParam) (err error) {
+	ctx, span := start(ctx, "WebhookRepository.MarkDeliveryFailed", idAttribute("delivery.id", failureParam.Id))
+	defer func() { end(span, err) }()
+	return wr.next.MarkDeliveryFailed(ctx, failureParam)
+}
+
+func (wr *webhookRepository) ListDeliveries(ctx context.Context, subscriptionId int64, status string, limit int32) (deliveries []domain.WebhookDelivery, err error) {
+	ctx, span := start(ctx, "WebhookRepository.ListDeliveries", idAttribute("webhook.id", subscriptionId))
+	defer func() { end(span, err) }()
+	return wr.next.ListDeliveries(ctx, subscriptionId, status, limit)
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	"github.com/credit-card-api/internal/services"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type accountService struct {
+	next services.AccountService
+}
+
+// WrapAccountService opens a span around every AccountService call.
+func WrapAccountService(next services.AccountService) services.AccountService {
+	return &accountService{next: next}
+}
+
+func (as *accountService) RegisterAccount(ctx context.Context, request models.CreateAccountRequest) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountService.RegisterAccount")
+	defer func() { end(span, err) }()
+	return as.next.RegisterAccount(ctx, request)
+}
+
+func (as *accountService) GetAccount(ctx context.Context, id int64) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountService.GetAccount", idAttribute("account.id", id))
+	defer func() { end(span, err) }()
+	return as.next.GetAccount(ctx, id)
+}
+
+func (as *accountService) BlockAccount(ctx context.Context, id int64) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountService.BlockAccount", idAttribute("account.id", id))
+	defer func() { end(span, err) }()
+	return as.next.BlockAccount(ctx, id)
+}
+
+func (as *accountService) UpdateAccount(ctx context.Context, id int64, version int32, request models.UpdateAccountRequest) (account *domain.Account, err error) {
+	ctx, span := start(ctx, "AccountService.UpdateAccount", idAttribute("account.id", id))
+	defer func() { end(span, err) }()
+	return as.next.UpdateAccount(ctx, id, version, request)
+}
+
+type transactionService struct {
+	next services.TransactionService
+}
+
+// WrapTransactionService opens a span around every TransactionService call.
+func WrapTransactionService(next services.TransactionService) services.TransactionService {
+	return &transactionService{next: next}
+}
+
+func (ts *transactionService) CreateTransaction(ctx context.Context, request models.TransactionRequest) (transaction *domain.Transaction, err error) {
+	ctx, span := start(ctx, "TransactionService.CreateTransaction",
+		idAttribute("account.id", request.AccountId),
+		idAttribute("operation_type.id", request.OperationTypeId),
+	)
+	defer func() { end(span, err) }()
+	return ts.next.CreateTransaction(ctx, request)
+}
+
+func (ts *transactionService) CreateTransactions(ctx context.Context, requests []models.TransactionRequest, allOrNothing bool) []domain.BatchItemOutcome {
+	ctx, span := start(ctx, "TransactionService.CreateTransactions", trace.WithAttributes(
+		attribute.Int("batch.size", len(requests)),
+		attribute.Bool("batch.all_or_nothing", allOrNothing),
+	))
+	defer span.End()
+	return ts.next.CreateTransactions(ctx, requests, allOrNothing)
+}
+
+func (ts *transactionService) ListTransactions(ctx context.Context, accountId int64) (transactions []domain.Transaction, err error) {
+	ctx, span := start(ctx, "TransactionService.ListTransactions", idAttribute("account.id", accountId))
+	defer func() { end(span, err) }()
+	return ts.next.ListTransactions(ctx, accountId)
+}
+
+func (ts *transactionService) RecomputeBalances(ctx context.Context, accountId int64) (corrections []domain.BalanceCorrection, err error) {
+	ctx, span := start(ctx, "TransactionService.RecomputeBalances", idAttribute("account.id", accountId))
+	defer func() { end(span, err) }()
+	return ts.next.RecomputeBalances(ctx, accountId)
+}
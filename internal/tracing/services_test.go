@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+	repositoryMocks "github.com/credit-card-api/internal/repository/mocks"
+	"github.com/credit-card-api/internal/services/mocks"
+	"github.com/credit-card-api/internal/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWrapTransactionService_Nests_Repository_Spans(t *testing.T) {
+	exporter := tracingtest.Install(t)
+	mockController := gomock.NewController(t)
+	mockTransactionService := mocks.NewMockTransactionService(mockController)
+	mockAccountRepository := repositoryMocks.NewMockAccountRepository(mockController)
+	accountRepository := WrapAccountRepository(mockAccountRepository)
+	request := models.TransactionRequest{AccountId: 1, OperationTypeId: 1, Amount: 10}
+
+	mockAccountRepository.EXPECT().GetById(gomock.Any(), int64(1)).Return(&domain.Account{Id: 1}, nil)
+	mockTransactionService.EXPECT().CreateTransaction(gomock.Any(), request).
+		DoAndReturn(func(ctx context.Context, request models.TransactionRequest) (*domain.Transaction, error) {
+			_, err := accountRepository.GetById(ctx, request.AccountId)
+			return &domain.Transaction{Id: 2}, err
+		})
+
+	transaction, err := WrapTransactionService(mockTransactionService).CreateTransaction(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), transaction.Id)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "AccountRepository.GetById", spans[0].Name)
+	assert.Equal(t, "TransactionService.CreateTransaction", spans[1].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}
+
+func TestWrapAccountService_Records_Error(t *testing.T) {
+	exporter := tracingtest.Install(t)
+	mockAccountService := mocks.NewMockAccountService(gomock.NewController(t))
+	mockAccountService.EXPECT().GetAccount(gomock.Any(), int64(9)).Return(nil, domain.ErrAccountNotFound)
+
+	_, err := WrapAccountService(mockAccountService).GetAccount(context.Background(), 9)
+
+	assert.Equal(t, domain.ErrAccountNotFound, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "AccountService.GetAccount", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, domain.ErrAccountNotFound.Error(), spans[0].Status.Description)
+}
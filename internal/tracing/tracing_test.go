@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSetup_Builds_Each_Exporter(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	for _, cfg := range []Config{
+		DefaultConfig(),
+		{Exporter: ExporterStdout, ServiceName: "credit-card-api", SampleRatio: 1},
+		// The OTLP exporter connects lazily, no collector is needed to build it.
+		{Exporter: ExporterOTLP, Endpoint: "http://127.0.0.1:4318", ServiceName: "credit-card-api", SampleRatio: 0.5},
+	} {
+		shutdown, err := Setup(context.Background(), cfg)
+
+		require.NoError(t, err, cfg.Exporter)
+		assert.NoError(t, shutdown(context.Background()), cfg.Exporter)
+	}
+}
+
+func TestSetup_Rejects_Unknown_Exporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "jaeger"})
+
+	assert.EqualError(t, err, `tracing: unknown exporter "jaeger"`)
+}
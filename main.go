@@ -3,36 +3,244 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	_ "github.com/credit-card-api/docs"
-	"github.com/credit-card-api/internal/repository/sqlc"
+	"github.com/credit-card-api/internal/auth"
+	"github.com/credit-card-api/internal/config"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/metrics"
+	"github.com/credit-card-api/internal/outbox"
+	"github.com/credit-card-api/internal/redact"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/repository/memory"
 	"github.com/credit-card-api/internal/routes"
-	"github.com/credit-card-api/pkg/constants"
+	"github.com/credit-card-api/internal/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	logger "github.com/sirupsen/logrus"
 )
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
-	dbURL := os.Getenv(constants.DBUrl)
-	if dbURL == constants.EmptyString {
-		log.Fatal("please set DB_URL env as mentioned in README.md")
+	// Without a command, or with flags only, the binary serves the API as it always did.
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
 	}
 
-	dbPool, dbErr := pgxpool.New(context.Background(), dbURL)
-	if dbErr != nil {
-		log.Fatal("unable to connect with database:", dbErr)
+	switch command {
+	case "serve":
+		serve(args)
+	case "migrate":
+		migrate(args)
+	case "reencrypt":
+		reencrypt(args)
+	case "account":
+		accountCommand(args)
+	case "transaction":
+		transactionCommand(args)
+	case "recompute-balances":
+		recomputeBalances(args)
+	case "seed":
+		seed(args)
+	case "import":
+		importCommand(args)
+	default:
+		exitWithUsage(usage)
 	}
+}
 
-	logger.Info("database connected successfully.")
-	defer dbPool.Close()
+// serve runs the HTTP server and the outbox dispatcher until SIGINT or SIGTERM.
+func serve(args []string) {
+	cfg, cfgErr := config.Load(args, os.LookupEnv)
+	if cfgErr != nil {
+		log.Fatalf("invalid configuration, see README.md:\n%s", cfgErr)
+	}
+	configureLogger(cfg.Log)
+	logger.Infof("effective configuration:\n%s", strings.Join(cfg.Redacted(), "\n"))
 
-	queries := sqlc.New(dbPool)
-	router := routes.RegisterRoutes(queries)
+	shutdownTracing, tracingErr := tracing.Setup(context.Background(), cfg.Tracing)
+	if tracingErr != nil {
+		log.Fatal("unable to set up tracing:", tracingErr)
+	}
 
-	err := http.ListenAndServe(":8080", router)
-	if err != nil {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	recorder := metrics.NewPrometheus(registry)
+
+	var dbPool *pgxpool.Pool
+	var repositories repository.Repositories
+	if cfg.Storage == config.StorageMemory {
+		logger.Warn("storage is memory, every change is lost on shutdown.")
+		repositories = memory.NewRepositories()
+	} else {
+		keyring, keyringErr := encryption.LoadKeyring(cfg.Encryption.KeyringFile)
+		if keyringErr != nil {
+			log.Fatal("unable to load KEYRING_FILE:", keyringErr)
+		}
+
+		var dbErr error
+		dbPool, dbErr = newPool(cfg.Database, multitracer.New(metrics.NewQueryTracer(recorder), tracing.QueryTracer{}))
+		if dbErr != nil {
+			log.Fatal("unable to connect with database:", dbErr)
+		}
+
+		logger.Info("database connected successfully.")
+		if cfg.Database.AutoMigrate {
+			if _, err := newMigrationRunner(dbPool, keyring).Up(context.Background(), 0); err != nil {
+				log.Fatal("unable to migrate database:", err)
+			}
+		}
+		registry.MustRegister(metrics.NewPoolCollector(dbPool))
+		repositories = repository.NewPostgresRepositories(dbPool, keyring)
+	}
+
+	if cfg.Auth.AdminApiKey == "" {
+		logger.Warn("ADMIN_API_KEY is not set, only api keys stored in the database are accepted.")
+	}
+
+	// workersCtx outlives the drain; it is cancelled only once the drain is over or its deadline has passed.
+	workersCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+
+	var tokenAuthenticator auth.Authenticator
+	if cfg.Auth.JwksPath != "" {
+		jwks, jwksErr := auth.LoadJWKSFile(cfg.Auth.JwksPath)
+		if jwksErr != nil {
+			log.Fatal("unable to load JWKS_PATH:", jwksErr)
+		}
+		go jwks.Watch(workersCtx, cfg.Auth.JwksReloadInterval)
+		tokenAuthenticator = auth.NewTokenAuthenticator(jwks, cfg.Auth.Token)
+	}
+
+	shutdown := make(chan struct{})
+	router := routes.RegisterRoutes(cfg, routes.Dependencies{
+		Repositories:       repositories,
+		TokenAuthenticator: tokenAuthenticator,
+		Shutdown:           shutdown,
+		Metrics:            recorder,
+		MetricsHandler:     recorder.Handler(),
+	})
+
+	dispatcher := outbox.NewDispatcher(repositories.Outbox, repositories.Webhooks, repositories.Transactor, cfg.Webhooks)
+	go dispatcher.Run(workersCtx)
+
+	// requestsCtx is the parent of every request context, cancelling it stops the queries of requests that outlive
+	// the drain so their connections return to the pool.
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	server := &http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           router,
+		BaseContext:       func(net.Listener) context.Context { return requestsCtx },
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Infof("listening on %s", cfg.HTTP.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
 		logger.Fatal("failed to start server, error: ", err.Error())
+	case <-signalCtx.Done():
+	}
+	// A second signal falls back to the default behaviour and kills the process.
+	stopSignals()
+
+	// Readiness fails and transaction streams end right away, new requests are still served during the delay.
+	close(shutdown)
+	logger.Infof("shutdown signal received, serving for %s more, then draining for at most %s.", cfg.HTTP.ShutdownDelay, cfg.HTTP.ShutdownTimeout)
+	time.Sleep(cfg.HTTP.ShutdownDelay)
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("http server did not drain in time: ", err.Error())
+	}
+	if err := dispatcher.Shutdown(shutdownCtx); err != nil {
+		logger.Error("outbox dispatcher did not drain in time: ", err.Error())
+	}
+	cancelRequests()
+	cancelWorkers()
+
+	if dbPool != nil {
+		closePool(dbPool)
+	}
+	// The drain may have used up shutdownCtx, the last spans get a deadline of their own.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		logger.Error("unable to flush traces: ", err.Error())
+	}
+	logger.Info("shutdown complete.")
+}
+
+const (
+	// poolCloseTimeout bounds the close of the database pool once the drain is over.
+	poolCloseTimeout = 5 * time.Second
+	// tracingFlushTimeout bounds the export of the spans still buffered at shutdown.
+	tracingFlushTimeout = 5 * time.Second
+)
+
+// closePool closes the pool, giving up after poolCloseTimeout: Close waits for every acquired connection, and a
+// query that ignores its cancelled context would otherwise hold the process forever.
+func closePool(pool *pgxpool.Pool) {
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(poolCloseTimeout):
+		logger.Errorf("database pool did not close within %s, exiting with connections still acquired.", poolCloseTimeout)
+	}
+}
+
+func configureLogger(cfg config.LogConfig) {
+	level, _ := logger.ParseLevel(cfg.Level)
+	logger.SetLevel(level)
+	if cfg.Format == "json" {
+		logger.SetFormatter(&logger.JSONFormatter{})
+	}
+	logger.AddHook(redact.NewHook())
+}
+
+func newPool(cfg config.DatabaseConfig, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, err
 	}
+	poolConfig.MaxConns = cfg.MaxConns
+	poolConfig.MinConns = cfg.MinConns
+	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+	poolConfig.ConnConfig.Tracer = tracer
+	return pgxpool.NewWithConfig(context.Background(), poolConfig)
 }
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/credit-card-api/db"
+	"github.com/credit-card-api/internal/config"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/migrations"
+	"github.com/jackc/pgx/v5/pgxpool"
+	logger "github.com/sirupsen/logrus"
+)
+
+// migrationsDir is where `migrate create` writes, relative to the repository root.
+const migrationsDir = "db/migrations"
+
+const migrateUsage = `usage: credit-card-api migrate <command> [config flags]
+
+  up [N]         apply the next N pending migrations, all of them by default
+  down [N|all]   roll back the last N applied migrations, 1 by default
+  status         list the migrations and whether they are applied
+  force <N>      record version N as applied without running anything, for a schema made outside migrate
+  create <name>  add the next up and down files to ` + migrationsDir
+
+// migrate runs the migrate subcommand. It takes the same configuration as the server.
+func migrate(args []string) {
+	if len(args) == 0 {
+		exitWithUsage(migrateUsage)
+	}
+	command, args := args[0], args[1:]
+	if command == "create" {
+		if len(args) != 1 {
+			exitWithUsage(migrateUsage)
+		}
+		paths, err := migrations.Create(migrationsDir, args[0])
+		if err != nil {
+			log.Fatal("unable to create migration: ", err)
+		}
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+		return
+	}
+
+	if command != "up" && command != "down" && command != "status" && command != "force" {
+		exitWithUsage(migrateUsage)
+	}
+
+	var forceVersion int64
+	if command == "force" {
+		if len(args) == 0 {
+			exitWithUsage(migrateUsage)
+		}
+		parsed, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || parsed < 0 {
+			exitWithUsage(migrateUsage)
+		}
+		forceVersion, args = parsed, args[1:]
+	}
+
+	steps := 0
+	if command == "down" {
+		steps = 1
+	}
+	if (command == "up" || command == "down") && len(args) > 0 {
+		if parsed, err := strconv.Atoi(args[0]); err == nil && parsed > 0 {
+			steps, args = parsed, args[1:]
+		} else if command == "down" && args[0] == "all" {
+			steps, args = 0, args[1:]
+		}
+	}
+
+	cfg, cfgErr := config.Load(args, os.LookupEnv)
+	if cfgErr != nil {
+		log.Fatalf("invalid configuration, see README.md:\n%s", cfgErr)
+	}
+	configureLogger(cfg.Log)
+	// Only up and down run backfills, which seal and open values with the keyring.
+	var keyring *encryption.Keyring
+	if command == "up" || command == "down" {
+		var keyringErr error
+		keyring, keyringErr = encryption.LoadKeyring(cfg.Encryption.KeyringFile)
+		if keyringErr != nil {
+			log.Fatal("unable to load KEYRING_FILE:", keyringErr)
+		}
+	}
+	dbPool, dbErr := newPool(cfg.Database, nil)
+	if dbErr != nil {
+		log.Fatal("unable to connect with database:", dbErr)
+	}
+	defer dbPool.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	runner := newMigrationRunner(dbPool, keyring)
+	var done []migrations.Migration
+	var err error
+	switch command {
+	case "up":
+		done, err = runner.Up(ctx, steps)
+	case "down":
+		done, err = runner.Down(ctx, steps)
+	case "status":
+		err = printStatus(ctx, runner)
+	case "force":
+		if err = runner.Force(ctx, forceVersion); err == nil {
+			logger.Infof("migrate force: schema version set to %d.", forceVersion)
+		}
+	}
+	for _, migration := range done {
+		logger.Infof("migrate %s: %06d_%s done.", command, migration.Version, migration.Name)
+	}
+	if err != nil {
+		logger.Error("migrate ", command, " failed: ", err.Error())
+		dbPool.Close()
+		os.Exit(1)
+	}
+}
+
+// newMigrationRunner runs the embedded migrations. Without a keyring, migrations that need a backfill are refused.
+func newMigrationRunner(pool *pgxpool.Pool, keyring *encryption.Keyring) *migrations.Runner {
+	embedded, err := migrations.Load(db.Migrations())
+	if err != nil {
+		log.Fatal("invalid embedded migrations: ", err)
+	}
+	var backfills map[int64]migrations.Backfill
+	if keyring != nil {
+		backfills = migrations.Backfills(keyring)
+	}
+	return migrations.NewRunner(pool, embedded, backfills)
+}
+
+func printStatus(ctx context.Context, runner *migrations.Runner) error {
+	statuses, err := runner.Status(ctx)
+	if err != nil {
+		return err
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS")
+	for _, status := range statuses {
+		state := "pending"
+		if status.Dirty {
+			state = "dirty"
+		} else if status.Applied {
+			state = "applied"
+		}
+		_, _ = fmt.Fprintf(writer, "%06d\t%s\t%s\n", status.Version, status.Name, state)
+	}
+	return writer.Flush()
+}
@@ -2,6 +2,10 @@ package constants
 
 var (
 	AccountIdPathParam = "accountId"
+	WebhookIdPathParam = "webhookId"
+	ApiKeyIdPathParam  = "apiKeyId"
+	// StatementFilePathParam is the statement id with the extension of its format, 202609.pdf.
+	StatementFilePathParam = "statementFile"
 
 	BadRequestErrCode                 = "ERR_CC_BAD_REQUEST"
 	InternalServerErrCode             = "ERR_CC_INTERNAL_SERVER_ERROR"
@@ -9,16 +13,69 @@ var (
 	AccountNotFoundErrCode            = "ERR_CC_ACCOUNT_NOT_FOUND"
 	InvalidOperationTypeErrCode       = "ERR_CC_INVALID_OPERATION_TYPE"
 	TransactionAccountNotFoundErrCode = "ERR_CC_TRANSACTION_ACCOUNT_NOT_FOUND"
+	AccountBlockedErrCode             = "ERR_CC_ACCOUNT_BLOCKED"
+	WebhookNotFoundErrCode            = "ERR_CC_WEBHOOK_NOT_FOUND"
+	ApiKeyNotFoundErrCode             = "ERR_CC_API_KEY_NOT_FOUND"
+	StatementNotFoundErrCode          = "ERR_CC_STATEMENT_NOT_FOUND"
+	UnauthorizedErrCode               = "ERR_CC_UNAUTHORIZED"
+	ForbiddenErrCode                  = "ERR_CC_FORBIDDEN"
+	RateLimitedErrCode                = "ERR_CC_RATE_LIMITED"
+	RequestTooLargeErrCode            = "ERR_CC_REQUEST_TOO_LARGE"
+	PreconditionFailedErrCode         = "ERR_CC_PRECONDITION_FAILED"
+	PreconditionRequiredErrCode       = "ERR_CC_PRECONDITION_REQUIRED"
 
 	InvalidRequestBodyErrMsg = "invalid request body"
 	AccountIdMissingErrMsg   = "accountId is missing in path params"
-
-	DBUrl = "DB_URL"
+	WebhookIdMissingErrMsg   = "webhookId is missing in path params"
+	ApiKeyIdMissingErrMsg    = "apiKeyId is missing in path params"
+	InvalidQueryParamErrMsg  = "invalid query params"
+	InvalidLastEventIdErrMsg = "Last-Event-ID must be a numeric event id"
 
 	RequiredTag = "required"
 	MaxTag      = "max"
 	NumericTag  = "numeric"
 	GTTag       = "gt"
+	URLTag      = "url"
+	MinTag      = "min"
+	OneOfTag    = "oneof"
+	GTETag      = "gte"
+	LTETag      = "lte"
+	EmailTag    = "email"
+	E164Tag     = "e164"
+	DatetimeTag = "datetime"
+	CountryTag  = "iso3166_1_alpha2"
+	PastDateTag = "past_date"
+	MaxItemsTag = "max_items"
 
 	EmptyString = ""
+
+	WebhookSignatureHeader = "X-CC-Signature"
+	WebhookEventIdHeader   = "X-CC-Event-Id"
+	WebhookEventTypeHeader = "X-CC-Event-Type"
+
+	RequestIdHeader = "X-Request-ID"
+
+	AcceptHeader           = "Accept"
+	ContentTypeHeader      = "Content-Type"
+	AcceptLanguageHeader   = "Accept-Language"
+	ContentLanguageHeader  = "Content-Language"
+	ProblemJSONContentType = "application/problem+json"
+	ETagHeader             = "ETag"
+	IfMatchHeader          = "If-Match"
+
+	ApiKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+
+	LastEventIdHeader     = "Last-Event-ID"
+	LastEventIdQueryParam = "last_event_id"
+
+	ContentDispositionHeader = "Content-Disposition"
+	TrailerHeader            = "Trailer"
+	// ExportStatusTrailer is the trailer an export ends with: complete, or failed when it stopped part way.
+	ExportStatusTrailer = "X-Export-Status"
 )
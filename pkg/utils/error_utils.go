@@ -1,8 +1,16 @@
 package utils
 
 import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/credit-card-api/internal/i18n"
+	"github.com/credit-card-api/internal/logging"
 	"github.com/credit-card-api/internal/models"
 	"github.com/credit-card-api/pkg/constants"
+	"github.com/gin-gonic/gin"
 )
 
 func NewCCBadRequestError(errorMsg string) *models.CCError {
@@ -12,3 +20,46 @@ func NewCCBadRequestError(errorMsg string) *models.CCError {
 		StatusCode:   400,
 	}
 }
+
+// NewCCValidationError is the bad request error of a failed Validate, listing every invalid field.
+func NewCCValidationError(err error) *models.CCError {
+	ccError := NewCCBadRequestError(err.Error())
+	var fieldErrors models.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		ccError.Errors = fieldErrors
+	}
+	return ccError
+}
+
+// AbortWithError responds with ccError, as application/problem+json when the Accept header asks for it and in the
+// error_code format otherwise, with its messages in the language of the Accept-Language header.
+func AbortWithError(ctx *gin.Context, ccError *models.CCError) {
+	translator := i18n.Translator(ctx.GetHeader(constants.AcceptLanguageHeader))
+	ctx.Header(constants.ContentLanguageHeader, i18n.LanguageTag(translator))
+	ccError = ccError.Localized(translator)
+	if !AcceptsProblem(ctx.GetHeader(constants.AcceptHeader)) {
+		ctx.AbortWithStatusJSON(ccError.StatusCode, ccError)
+		return
+	}
+	ctx.Header(constants.ContentTypeHeader, constants.ProblemJSONContentType)
+	ctx.AbortWithStatusJSON(ccError.StatusCode, &models.Problem{
+		Type:     models.ProblemType(ccError.ErrorCode),
+		Title:    http.StatusText(ccError.StatusCode),
+		Status:   ccError.StatusCode,
+		Detail:   ccError.ErrorMessage,
+		Instance: logging.RequestId(ctx.Request.Context()),
+		Code:     ccError.ErrorCode,
+		Errors:   ccError.Errors,
+	})
+}
+
+// AcceptsProblem reports whether an Accept header lists application/problem+json.
+func AcceptsProblem(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err == nil && mediaType == constants.ProblemJSONContentType {
+			return true
+		}
+	}
+	return false
+}
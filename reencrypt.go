@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/credit-card-api/internal/config"
+	"github.com/credit-card-api/internal/encryption"
+	"github.com/credit-card-api/internal/repository"
+	"github.com/credit-card-api/internal/repository/sqlc"
+	logger "github.com/sirupsen/logrus"
+)
+
+const reencryptBatchSize = 500
+
+// reencrypt moves every document number to the primary key of the keyring, once a new key has been made
+// primary. It takes the same configuration as the server.
+func reencrypt(args []string) {
+	cfg, cfgErr := config.Load(args, os.LookupEnv)
+	if cfgErr != nil {
+		log.Fatalf("invalid configuration, see README.md:\n%s", cfgErr)
+	}
+	configureLogger(cfg.Log)
+
+	keyring, keyringErr := encryption.LoadKeyring(cfg.Encryption.KeyringFile)
+	if keyringErr != nil {
+		log.Fatal("unable to load KEYRING_FILE:", keyringErr)
+	}
+	dbPool, dbErr := newPool(cfg.Database, nil)
+	if dbErr != nil {
+		log.Fatal("unable to connect with database:", dbErr)
+	}
+	defer dbPool.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	reencryptor := repository.NewDocumentReencryptor(sqlc.New(dbPool), keyring, reencryptBatchSize)
+	reencrypted, err := reencryptor.Run(ctx)
+	if err != nil {
+		logger.Errorf("re-encryption stopped after %d accounts: %s", reencrypted, err.Error())
+		dbPool.Close()
+		os.Exit(1)
+	}
+	logger.Infof("re-encrypted %d accounts with key %s.", reencrypted, keyring.PrimaryKeyId())
+}
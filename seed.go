@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/credit-card-api/internal/models"
+)
+
+const defaultSeedAccounts = 10
+
+// seededAccountView is how seed prints an account it created.
+type seededAccountView struct {
+	AccountId      int64   `json:"account_id"`
+	DocumentNumber string  `json:"document_number"`
+	TransactionIds []int64 `json:"transaction_ids"`
+}
+
+// seed creates demo accounts through the services, each with a purchase, a purchase with installments, a
+// withdrawal and a credit voucher that discharges part of them.
+func seed(args []string) {
+	positional, options, configArgs := splitAdminArgs(args)
+	count := int64(defaultSeedAccounts)
+	switch len(positional) {
+	case 0:
+	case 1:
+		count = parseId("accounts", positional[0])
+	default:
+		exitWithUsage(usage)
+	}
+
+	a, closeAdmin := newAdmin(options, configArgs)
+	defer closeAdmin()
+
+	views := make([]seededAccountView, 0, count)
+	rows := make([][]string, 0, count)
+	for range count {
+		// Eleven digits, the length of a CPF.
+		documentNumber := fmt.Sprintf("%011d", rand.Int64N(100_000_000_000))
+		account, err := a.accounts.RegisterAccount(a.ctx, models.CreateAccountRequest{DocumentNumber: documentNumber})
+		if err != nil {
+			a.fail("seed", err)
+		}
+
+		view := seededAccountView{AccountId: account.Id, DocumentNumber: a.documentNumber(account.DocumentNumber)}
+		for _, operationTypeId := range []int64{1, 2, 3, 4} {
+			transaction, err := a.transactions.CreateTransaction(a.ctx, models.TransactionRequest{
+				AccountId:       account.Id,
+				OperationTypeId: operationTypeId,
+				Amount:          randomAmount(),
+			})
+			if err != nil {
+				a.fail("seed", err)
+			}
+			view.TransactionIds = append(view.TransactionIds, transaction.Id)
+		}
+
+		transactionIds := make([]string, 0, len(view.TransactionIds))
+		for _, id := range view.TransactionIds {
+			transactionIds = append(transactionIds, strconv.FormatInt(id, 10))
+		}
+		views = append(views, view)
+		rows = append(rows, []string{strconv.FormatInt(view.AccountId, 10), view.DocumentNumber, strings.Join(transactionIds, ",")})
+	}
+	a.print(views, []string{"ACCOUNT", "DOCUMENT NUMBER", "TRANSACTIONS"}, rows)
+}
+
+// randomAmount is between 1.00 and 500.00.
+func randomAmount() float64 {
+	return float64(100+rand.IntN(49_901)) / 100
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/credit-card-api/internal/domain"
+	"github.com/credit-card-api/internal/models"
+)
+
+// transactionView is how the admin commands print a transaction.
+type transactionView struct {
+	TransactionId   int64     `json:"transaction_id"`
+	AccountId       int64     `json:"account_id"`
+	OperationTypeId int64     `json:"operation_type_id"`
+	Amount          float64   `json:"amount"`
+	Balance         float64   `json:"balance"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// balanceCorrectionView is how recompute-balances prints a corrected balance.
+type balanceCorrectionView struct {
+	TransactionId   int64   `json:"transaction_id"`
+	AccountId       int64   `json:"account_id"`
+	PreviousBalance float64 `json:"previous_balance"`
+	Balance         float64 `json:"balance"`
+}
+
+// transactionCommand runs `transaction post|list`.
+func transactionCommand(args []string) {
+	positional, options, configArgs := splitAdminArgs(args)
+	if len(positional) < 2 {
+		exitWithUsage(usage)
+	}
+	subcommand := positional[0]
+	accountId := parseId("account-id", positional[1])
+	var request models.TransactionRequest
+	switch {
+	case subcommand == "post" && len(positional) == 4:
+		amount, err := strconv.ParseFloat(positional[3], 64)
+		if err != nil {
+			exitWithUsage("amount must be a number, got " + strconv.Quote(positional[3]))
+		}
+		request = models.TransactionRequest{AccountId: accountId, OperationTypeId: parseId("operation-type-id", positional[2]), Amount: amount}
+		if err := request.Validate(); err != nil {
+			exitWithUsage(err.Error())
+		}
+	case subcommand == "list" && len(positional) == 2:
+	default:
+		exitWithUsage(usage)
+	}
+
+	a, closeAdmin := newAdmin(options, configArgs)
+	defer closeAdmin()
+
+	if subcommand == "post" {
+		transaction, err := a.transactions.CreateTransaction(a.ctx, request)
+		if err != nil {
+			a.fail("transaction post", err)
+		}
+		view, row := newTransactionView(*transaction)
+		a.print(view, transactionHeader, [][]string{row})
+		return
+	}
+	transactions, err := a.transactions.ListTransactions(a.ctx, accountId)
+	if err != nil {
+		a.fail("transaction list", err)
+	}
+	a.printTransactions(transactions)
+}
+
+// recomputeBalances replays the transactions of every given account and corrects the balances that drifted,
+// each account in a database transaction of its own.
+func recomputeBalances(args []string) {
+	positional, options, configArgs := splitAdminArgs(args)
+	if len(positional) == 0 {
+		exitWithUsage(usage)
+	}
+	accountIds := make([]int64, 0, len(positional))
+	for _, argument := range positional {
+		accountIds = append(accountIds, parseId("account-id", argument))
+	}
+
+	a, closeAdmin := newAdmin(options, configArgs)
+	defer closeAdmin()
+
+	views := []balanceCorrectionView{}
+	var rows [][]string
+	for _, accountId := range accountIds {
+		corrections, err := a.transactions.RecomputeBalances(a.ctx, accountId)
+		if err != nil {
+			a.fail("recompute-balances of account "+strconv.FormatInt(accountId, 10), err)
+		}
+		for _, correction := range corrections {
+			views = append(views, balanceCorrectionView(correction))
+			rows = append(rows, []string{
+				strconv.FormatInt(correction.TransactionId, 10),
+				strconv.FormatInt(correction.AccountId, 10),
+				formatAmount(correction.PreviousBalance),
+				formatAmount(correction.Balance),
+			})
+		}
+	}
+	a.print(views, []string{"TRANSACTION", "ACCOUNT", "PREVIOUS BALANCE", "BALANCE"}, rows)
+}
+
+var transactionHeader = []string{"TRANSACTION", "ACCOUNT", "OPERATION TYPE", "AMOUNT", "BALANCE", "CREATED AT"}
+
+func (a *admin) printTransactions(transactions []domain.Transaction) {
+	views := make([]transactionView, 0, len(transactions))
+	rows := make([][]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		view, row := newTransactionView(transaction)
+		views = append(views, view)
+		rows = append(rows, row)
+	}
+	a.print(views, transactionHeader, rows)
+}
+
+func newTransactionView(transaction domain.Transaction) (transactionView, []string) {
+	view := transactionView{
+		TransactionId:   transaction.Id,
+		AccountId:       transaction.AccountId,
+		OperationTypeId: transaction.OperationTypeId,
+		Amount:          transaction.Amount,
+		Balance:         transaction.Balance,
+		CreatedAt:       transaction.CreatedAt,
+	}
+	return view, []string{
+		strconv.FormatInt(view.TransactionId, 10),
+		strconv.FormatInt(view.AccountId, 10),
+		strconv.FormatInt(view.OperationTypeId, 10),
+		formatAmount(view.Amount),
+		formatAmount(view.Balance),
+		view.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}